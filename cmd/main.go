@@ -10,11 +10,19 @@ import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrlogger"
 	"github.com/pothosware/go-soapy-sdr/pkg/version"
 	"log"
+	"os"
 )
 
 func main() {
 
 	sdrlogger.RegisterLogHandler(logSoapy)
+
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "rtl_tcp" {
+		runRtlTcp(os.Args[2:])
+		return
+	}
+
 	sdrlogger.Log(sdrlogger.Info, "Soapy SDR\n")
 	sdrlogger.Logf(sdrlogger.Info, "%v\n", "Demonstration")
 
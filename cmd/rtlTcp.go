@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/rtltcp"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+)
+
+// runRtlTcp runs the rtl_tcp subcommand: it opens a device and serves its first receive channel to rtl_tcp clients
+// until interrupted. The options follow the options of rtl_tcp.
+//
+// Params:
+//  - args: the arguments of the subcommand
+func runRtlTcp(args []string) {
+
+	flags := flag.NewFlagSet("rtl_tcp", flag.ExitOnError)
+	deviceArgs := flags.String("d", "", "device args, such as driver=rtlsdr,serial=00000001")
+	address := flags.String("a", "127.0.0.1", "listen address")
+	port := flags.Int("p", 1234, "listen port")
+	channel := flags.Uint("c", 0, "receive channel")
+	frequency := flags.Float64("f", 100e6, "frequency to tune to in Hz")
+	sampleRate := flags.Float64("s", 2048000, "sample rate in Hz")
+	gain := flags.Float64("g", 0, "gain in dB, 0 for automatic gain")
+	ppm := flags.Float64("P", 0, "frequency correction in PPM")
+	_ = flags.Parse(args)
+
+	dev, err := device.Make(parseDeviceArgs(*deviceArgs))
+	if err != nil {
+		log.Fatal(fmt.Printf("Make fail: error: %v\n", err))
+	}
+	defer dev.Unmake()
+
+	if err := dev.SetSampleRate(device.DirectionRX, *channel, *sampleRate); err != nil {
+		log.Fatal(fmt.Printf("setSampleRate fail: error: %v\n", err))
+	}
+	if err := dev.SetFrequency(device.DirectionRX, *channel, *frequency, nil); err != nil {
+		log.Fatal(fmt.Printf("setFrequency fail: error: %v\n", err))
+	}
+	if *ppm != 0 {
+		if err := dev.SetFrequencyCorrection(device.DirectionRX, *channel, *ppm); err != nil {
+			log.Fatal(fmt.Printf("setFrequencyCorrection fail: error: %v\n", err))
+		}
+	}
+	if *gain == 0 {
+		_ = dev.SetGainMode(device.DirectionRX, *channel, true)
+	} else if err := dev.SetGain(device.DirectionRX, *channel, *gain); err != nil {
+		log.Fatal(fmt.Printf("setGain fail: error: %v\n", err))
+	}
+
+	server, err := rtltcp.NewServer(dev, rtltcp.Config{Channel: *channel})
+	if err != nil {
+		log.Fatal(fmt.Printf("NewServer fail: error: %v\n", err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	listenAddress := net.JoinHostPort(*address, strconv.Itoa(*port))
+	fmt.Printf("Serving rtl_tcp on %v (stream format %v, %v gains)\n", listenAddress, server.Format(), len(server.Gains()))
+
+	if err := server.ListenAndServe(ctx, listenAddress); err != nil && ctx.Err() == nil {
+		fmt.Printf("rtl_tcp stopped: error: %v\n", err)
+	}
+}
+
+// parseDeviceArgs parses device args given as comma separated key=value pairs
+func parseDeviceArgs(args string) map[string]string {
+
+	parsed := make(map[string]string)
+	for _, pair := range strings.Split(args, ",") {
+		if key, value, found := strings.Cut(pair, "="); found {
+			parsed[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return parsed
+}
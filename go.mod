@@ -1,3 +1,3 @@
 module github.com/pothosware/go-soapy-sdr
 
-go 1.12
+go 1.18
@@ -0,0 +1,69 @@
+// Package simtest holds the fixtures of the tests running on simulated devices. It is internal to the module: the test
+// helpers are not part of the API of the sim package.
+package simtest
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"testing"
+)
+
+// Tone is the source of the devices created by NewDevice when the configuration has none: a tone at 100.1MHz,
+// at half the full scale
+var Tone = sim.Tone{Frequency: 100.1e6, Amplitude: 0.5}
+
+// NewDevice creates a simulated device for a test. The device receives Tone if the configuration has no source,
+// and is unmade at the end of the test.
+//
+// Params:
+//  - tb: the test or the benchmark
+//  - config: the configuration of the device
+//
+// Return the simulated device
+func NewDevice(tb testing.TB, config sim.Config) *sim.Device {
+
+	if config.Source == nil {
+		config.Source = Tone
+	}
+
+	dev := sim.New(config)
+	tb.Cleanup(func() {
+		_ = dev.Unmake()
+	})
+
+	return dev
+}
+
+// NewActiveRXStream creates a simulated device for a test with NewDevice, sets up a receive stream on all its
+// receive channels and activates it. The stream is deactivated and closed at the end of the test, before the device
+// is unmade. The test fails immediately if the stream can not be set up or activated.
+//
+// Params:
+//  - tb: the test or the benchmark
+//  - config: the configuration of the device
+//
+// Return the simulated device and the active stream, whose format is deduced from T
+func NewActiveRXStream[T device.Sample](tb testing.TB, config sim.Config) (*sim.Device, device.TypedStream[T]) {
+
+	dev := NewDevice(tb, config)
+
+	channels := make([]uint, dev.GetNumChannels(device.DirectionRX))
+	for i := range channels {
+		channels[i] = uint(i)
+	}
+
+	stream, err := sim.SetupStream[T](dev, device.DirectionRX, channels, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := stream.Activate(0, 0, 0); err != nil {
+		_ = stream.Close()
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		_ = stream.Deactivate(0, 0)
+		_ = stream.Close()
+	})
+
+	return dev, stream
+}
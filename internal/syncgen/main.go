@@ -0,0 +1,289 @@
+// Command syncgen generates the wrappers of SyncDevice serialising the calls of the Device API.
+//
+// It is run by go generate in the package device. The wrappers follow the groups of the Device interface declared in
+// api.go, and reuse the first sentence of the documentation of the matching SDRDevice methods. The methods that
+// SyncDevice already declares in the other files of the package, such as Unmake(), are not generated.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+// maxLineLength is the length of the lines of the comments of the repository
+const maxLineLength = 120
+
+// checkedSuffix is the suffix of the getters returning the failure reported by the driver
+const checkedSuffix = "Checked"
+
+// result is a result of a wrapped method
+type result struct {
+	name     string
+	typeExpr string
+}
+
+// method is a wrapped method of the Device API
+type method struct {
+	name    string
+	doc     string
+	params  []string
+	args    []string
+	results []result
+}
+
+func main() {
+
+	output := flag.String("output", "syncDeviceAPI.go", "the generated file")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != *output
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, found := packages["device"]
+	if !found {
+		log.Fatal("syncgen must be run in the package device")
+	}
+
+	methods, err := wrappedMethods(fset, pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := format.Source(generate(methods))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// wrappedMethods lists the methods of the Device API to wrap, in the order of the Device interface
+func wrappedMethods(fset *token.FileSet, pkg *ast.Package) ([]method, error) {
+
+	interfaces := make(map[string]*ast.InterfaceType)
+	implemented := make(map[string]*ast.FuncDecl)
+	handWritten := make(map[string]bool)
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+							interfaces[typeSpec.Name.Name] = iface
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				switch receiverName(decl) {
+				case "SDRDevice":
+					implemented[decl.Name.Name] = decl
+				case "SyncDevice":
+					handWritten[decl.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	device, found := interfaces["Device"]
+	if !found {
+		return nil, fmt.Errorf("no Device interface")
+	}
+
+	var methods []method
+	for _, field := range device.Methods.List {
+		group, ok := field.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		iface, found := interfaces[group.Name]
+		if !found {
+			return nil, fmt.Errorf("no %v interface", group.Name)
+		}
+		for _, groupMethod := range iface.Methods.List {
+			name := groupMethod.Names[0].Name
+			if handWritten[name] {
+				continue
+			}
+			decl, found := implemented[name]
+			if !found {
+				return nil, fmt.Errorf("SDRDevice does not implement %v", name)
+			}
+			m, err := newMethod(fset, decl, implemented[name+checkedSuffix])
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, m)
+		}
+	}
+
+	return methods, nil
+}
+
+// receiverName returns the name of the type of the receiver of a method, empty for a function
+func receiverName(decl *ast.FuncDecl) string {
+
+	if decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return ""
+	}
+	star, ok := decl.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	ident, ok := star.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+
+	return ident.Name
+}
+
+// newMethod describes the wrapper of a method of SDRDevice. The results which are not named take the names of the
+// results of the Checked variant of the method.
+func newMethod(fset *token.FileSet, decl *ast.FuncDecl, checked *ast.FuncDecl) (method, error) {
+
+	m := method{
+		name: decl.Name.Name,
+		doc:  summary(decl.Doc),
+	}
+
+	for _, field := range decl.Type.Params.List {
+		typeExpr := exprString(fset, field.Type)
+		for _, name := range field.Names {
+			m.params = append(m.params, name.Name+" "+typeExpr)
+			m.args = append(m.args, name.Name)
+		}
+	}
+
+	if decl.Type.Results == nil {
+		return m, nil
+	}
+	for i, field := range decl.Type.Results.List {
+		typeExpr := exprString(fset, field.Type)
+		if len(field.Names) == 0 {
+			if checked == nil || checked.Type.Results == nil || len(checked.Type.Results.List) <= i || len(checked.Type.Results.List[i].Names) == 0 {
+				return m, fmt.Errorf("no name for the result %v of %v", i, m.name)
+			}
+			m.results = append(m.results, result{name: checked.Type.Results.List[i].Names[0].Name, typeExpr: typeExpr})
+			continue
+		}
+		for _, name := range field.Names {
+			m.results = append(m.results, result{name: name.Name, typeExpr: typeExpr})
+		}
+	}
+
+	return m, nil
+}
+
+// exprString returns the source of an expression
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+
+	var buffer bytes.Buffer
+	_ = printer.Fprint(&buffer, fset, expr)
+
+	return buffer.String()
+}
+
+// summary returns the first sentence of the first paragraph of a documentation
+func summary(doc *ast.CommentGroup) string {
+
+	if doc == nil {
+		return ""
+	}
+
+	paragraph := strings.SplitN(doc.Text(), "\n\n", 2)[0]
+	sentence := strings.SplitN(strings.Join(strings.Fields(paragraph), " "), ". ", 2)[0]
+
+	return strings.TrimSuffix(sentence, ".") + "."
+}
+
+// op returns the name of the operation of a method, which is the name of the getter for a Checked getter
+func (m *method) op() string {
+
+	return strings.TrimSuffix(m.name, checkedSuffix)
+}
+
+// returnsError checks if the last result of a method is its error
+func (m *method) returnsError() bool {
+
+	if len(m.results) == 0 {
+		return false
+	}
+	last := m.results[len(m.results)-1]
+
+	return last.name == "err" && (last.typeExpr == "sdrerror.SDRError" || last.typeExpr == "error")
+}
+
+// generate generates the source of the wrappers
+func generate(methods []method) []byte {
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString("// Code generated by syncgen from api.go; DO NOT EDIT.\n\n")
+	buffer.WriteString("package device\n\n")
+	buffer.WriteString("import (\n\t\"github.com/pothosware/go-soapy-sdr/pkg/sdrerror\"\n)\n")
+
+	for _, m := range methods {
+
+		buffer.WriteString("\n")
+		buffer.WriteString(comment(m.doc))
+
+		var results []string
+		var names []string
+		for _, r := range m.results {
+			results = append(results, r.name+" "+r.typeExpr)
+			names = append(names, r.name)
+		}
+		fmt.Fprintf(&buffer, "func (dev *SyncDevice) %v(%v) (%v) {\n\n", m.name, strings.Join(m.params, ", "), strings.Join(results, ", "))
+
+		call := fmt.Sprintf("dev.device.%v(%v)", m.name, strings.Join(m.args, ", "))
+		if len(names) > 0 {
+			call = strings.Join(names, ", ") + " = " + call
+		}
+
+		if !m.returnsError() {
+			fmt.Fprintf(&buffer, "\tdev.do(%q, func() {\n\t\t%v\n\t})\n\n", m.op(), call)
+			fmt.Fprintf(&buffer, "\treturn %v\n}\n", strings.Join(names, ", "))
+			continue
+		}
+
+		rejected := append(append([]string(nil), names[:len(names)-1]...), "rejected")
+		fmt.Fprintf(&buffer, "\tif rejected := dev.do(%q, func() {\n\t\t%v\n\t}); rejected != nil {\n", m.op(), call)
+		fmt.Fprintf(&buffer, "\t\treturn %v\n\t}\n\n", strings.Join(rejected, ", "))
+		fmt.Fprintf(&buffer, "\treturn %v\n}\n", strings.Join(names, ", "))
+	}
+
+	return buffer.Bytes()
+}
+
+// comment formats a text as a comment whose lines do not exceed the length of the lines of the repository
+func comment(text string) string {
+
+	var builder strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > maxLineLength && line != "//" {
+			builder.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	builder.WriteString(line + "\n")
+
+	return builder.String()
+}
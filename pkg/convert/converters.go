@@ -0,0 +1,161 @@
+package convert
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/convert/kernel"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"math"
+)
+
+// formatLayout describes the elements of a format handled by the generic converters
+type formatLayout struct {
+	// size is the size of an element in bytes
+	size int
+	// fullScale is the full scale of the integer components, given by device.FormatFullScale(), 0 for the float
+	// formats
+	fullScale float64
+	// offset is the value of the zero of the unsigned formats
+	offset int32
+}
+
+// formatLayouts gives the layout of the formats handled by the generic converters
+var formatLayouts = map[string]formatLayout{
+	device.FormatCU8:  {2, device.FormatFullScale(device.FormatCU8), 128},
+	device.FormatCS8:  {2, device.FormatFullScale(device.FormatCS8), 0},
+	device.FormatCS12: {3, device.FormatFullScale(device.FormatCS12), 0},
+	device.FormatCS16: {4, device.FormatFullScale(device.FormatCS16), 0},
+	device.FormatCF32: {8, 0, 0},
+	device.FormatCF64: {16, 0, 0},
+}
+
+// chunkSize is the number of elements of a packed format unpacked at once
+const chunkSize = 256
+
+// component is the constraint gathering the types of the components of the formats handled by the generic
+// converters. The components of CS12 are unpacked to int16.
+type component interface {
+	uint8 | int8 | int16 | float32 | float64
+}
+
+// kernelFunc converts components, I and Q interleaved
+type kernelFunc[S component, D component] func(dst []D, src []S, scaler float64)
+
+func init() {
+
+	for source := range formatLayouts {
+		for target := range formatLayouts {
+			Register(source, target, PriorityGeneric, genericConverter(source, target))
+		}
+	}
+}
+
+// genericConverter returns the portable converter between two formats of formatLayouts
+func genericConverter(source string, target string) Func {
+
+	if source == target {
+		size := formatLayouts[source].size
+		return func(dst []byte, src []byte, numElems int, scaler float64) {
+			copy(dst[:numElems*size], src[:numElems*size])
+		}
+	}
+
+	switch source {
+	case device.FormatCU8:
+		return converterFrom[uint8](source, target)
+	case device.FormatCS8:
+		return converterFrom[int8](source, target)
+	case device.FormatCS12, device.FormatCS16:
+		return converterFrom[int16](source, target)
+	case device.FormatCF32:
+		return converterFrom[float32](source, target)
+	default:
+		return converterFrom[float64](source, target)
+	}
+}
+
+// converterFrom returns the converter from a source format whose components are of type S
+func converterFrom[S component](source string, target string) Func {
+
+	switch target {
+	case device.FormatCU8:
+		return converterBetween[S, uint8](source, target)
+	case device.FormatCS8:
+		return converterBetween[S, int8](source, target)
+	case device.FormatCS12, device.FormatCS16:
+		return converterBetween[S, int16](source, target)
+	case device.FormatCF32:
+		return converterBetween[S, float32](source, target)
+	default:
+		return converterBetween[S, float64](source, target)
+	}
+}
+
+// converterBetween returns the converter between a source format whose components are of type S and a target format
+// whose components are of type D. The packed formats are unpacked or packed by chunks.
+func converterBetween[S component, D component](source string, target string) Func {
+
+	from := formatLayouts[source]
+	to := formatLayouts[target]
+	convert := newKernel[S, D](from, to)
+
+	switch {
+	case source == device.FormatCS12:
+		return func(dst []byte, src []byte, numElems int, scaler float64) {
+
+			var unpacked [2 * chunkSize]int16
+
+			out := kernel.Components[D](dst, numElems, to.size)
+			src = src[:numElems*from.size]
+
+			for offset := 0; offset < numElems; offset += chunkSize {
+				nbUnpacked := device.UnpackCS12(src[offset*from.size:], unpacked[:])
+				convert(out[2*offset:2*(offset+nbUnpacked)], any(unpacked[:2*nbUnpacked]).([]S), scaler)
+			}
+		}
+	case target == device.FormatCS12:
+		return func(dst []byte, src []byte, numElems int, scaler float64) {
+
+			var unpacked [2 * chunkSize]int16
+
+			in := kernel.Components[S](src, numElems, from.size)
+			dst = dst[:numElems*to.size]
+
+			for offset := 0; offset < numElems; offset += chunkSize {
+				end := offset + chunkSize
+				if end > numElems {
+					end = numElems
+				}
+				convert(any(unpacked[:2*(end-offset)]).([]D), in[2*offset:2*end], scaler)
+				device.PackCS12(unpacked[:2*(end-offset)], dst[offset*to.size:])
+			}
+		}
+	default:
+		return func(dst []byte, src []byte, numElems int, scaler float64) {
+
+			convert(kernel.Components[D](dst, numElems, to.size), kernel.Components[S](src, numElems, from.size), scaler)
+		}
+	}
+}
+
+// newKernel returns the function converting the components of a source format to the components of a target format
+func newKernel[S component, D component](from formatLayout, to formatLayout) kernelFunc[S, D] {
+
+	switch {
+	case from.fullScale == 0 && to.fullScale == 0:
+		return kernel.ScaleFloats[S, D]
+	case from.fullScale == 0:
+		// The float values are rounded and clipped to the full scale of the target format
+		offset := float64(to.offset)
+		return func(dst []D, src []S, scaler float64) {
+			kernel.FloatsToInts(dst, src, offset, scaler, offset-to.fullScale, offset+to.fullScale-1)
+		}
+	case to.fullScale == 0:
+		return func(dst []D, src []S, scaler float64) {
+			kernel.IntsToFloats(dst, src, float64(from.offset), 1/scaler)
+		}
+	default:
+		shift := int(math.Log2(to.fullScale / from.fullScale))
+		return func(dst []D, src []S, scaler float64) {
+			kernel.ShiftInts(dst, src, from.offset, to.offset, shift)
+		}
+	}
+}
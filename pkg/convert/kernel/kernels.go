@@ -0,0 +1,88 @@
+// Package kernel holds the conversion core of the samples: the functions converting their components, I and Q
+// interleaved, between the integer and the float types. The converters of the convert package, the complex integer
+// sample types of the device package and the simulated device are built on them.
+package kernel
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Component is the constraint gathering the types of the components of the samples
+type Component interface {
+	uint8 | int8 | uint16 | int16 | uint32 | int32 | float32 | float64
+}
+
+// Interleaved returns the components of complex samples, I and Q interleaved, sharing the memory of the samples. E
+// must be the type of the components of T.
+func Interleaved[T any, E Component](samples []T) []E {
+
+	if len(samples) == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*E)(unsafe.Pointer(&samples[0])), 2*len(samples))
+}
+
+// Components returns the first numElems elements of a buffer as their components, I and Q interleaved, sharing the
+// memory of the buffer. It panics if the buffer is too short.
+//
+// Params:
+//  - buffer: the elements as bytes
+//  - numElems: the number of elements
+//  - size: the size of an element in bytes
+//
+// Return the components of the elements
+func Components[E Component](buffer []byte, numElems int, size int) []E {
+
+	buffer = buffer[:numElems*size]
+	if numElems == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*E)(unsafe.Pointer(&buffer[0])), 2*numElems)
+}
+
+// ScaleFloats converts float components: dst[i] = src[i] * scale
+func ScaleFloats[S Component, D Component](dst []D, src []S, scale float64) {
+
+	factor := D(scale)
+	for i, value := range src {
+		dst[i] = D(value) * factor
+	}
+}
+
+// IntsToFloats converts integer components to float components: dst[i] = (src[i] - offset) * scale
+func IntsToFloats[S Component, D Component](dst []D, src []S, offset float64, scale float64) {
+
+	zero := D(offset)
+	factor := D(scale)
+	for i, value := range src {
+		dst[i] = (D(value) - zero) * factor
+	}
+}
+
+// FloatsToInts converts float components to integer components, rounded and clipped to [minimum, maximum]:
+// dst[i] = src[i] * scale + offset
+func FloatsToInts[S Component, D Component](dst []D, src []S, offset float64, scale float64, minimum float64, maximum float64) {
+
+	for i, value := range src {
+		dst[i] = D(math.Max(minimum, math.Min(maximum, math.Round(float64(value)*scale+offset))))
+	}
+}
+
+// ShiftInts converts integer components to integer components with another number of bits, shifting them by the
+// difference of bits: dst[i] = (src[i] - fromOffset) << shift + toOffset
+func ShiftInts[S Component, D Component](dst []D, src []S, fromOffset int32, toOffset int32, shift int) {
+
+	if shift >= 0 {
+		for i, value := range src {
+			dst[i] = D((int32(value)-fromOffset)<<shift + toOffset)
+		}
+		return
+	}
+
+	for i, value := range src {
+		dst[i] = D((int32(value)-fromOffset)>>-shift + toOffset)
+	}
+}
@@ -0,0 +1,234 @@
+// Package convert provides a registry of converters between stream formats, mirroring the ConverterRegistry of the
+// SoapySDR C++ API. A typical use reads a stream in the native format of the device, given by GetNativeStreamFormat(),
+// and converts the samples in Go:
+//
+//	format, fullScale := dev.GetNativeStreamFormat(device.DirectionRX, 0)
+//	converter, err := convert.GetFunction(format, device.FormatCF32)
+//	...
+//	converter(convert.Bytes(samples), convert.Bytes(raw), numElems, fullScale)
+package convert
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// Func converts numElems elements from the source buffer, in the source format of the converter, to the target buffer,
+// in the target format of the converter. The scaler is the full scale of the integer format of the conversion: the
+// integer values are divided by the scaler when converted to a float format and the float values are multiplied by
+// the scaler when converted to an integer format. Between two float formats, the values are multiplied by the scaler.
+// Between two integer formats, the values are shifted to the number of bits of the target format and the scaler is
+// ignored.
+//
+// The buffers must hold at least numElems elements of their format, the converter panics otherwise.
+type Func func(dst []byte, src []byte, numElems int, scaler float64)
+
+// Priority is the priority of a converter. When several converters are registered for the same source and target
+// formats, GetFunction() returns the one with the highest priority.
+type Priority int
+
+const (
+	// PriorityGeneric is the priority of the portable converters registered by the package
+	PriorityGeneric Priority = 0
+	// PriorityVectorized is the priority of the converters using vector instructions
+	PriorityVectorized Priority = 3
+	// PriorityCustom is the priority of the converters registered by the users, overriding the other ones
+	PriorityCustom Priority = 5
+)
+
+// registry holds the converters: source format -> target format -> priority -> converter
+var registry = struct {
+	sync.RWMutex
+	converters map[string]map[string]map[Priority]Func
+}{
+	converters: make(map[string]map[string]map[Priority]Func),
+}
+
+// Register registers a converter, replacing the converter registered with the same formats and priority if any.
+//
+// Params:
+//  - source: the source format, such as "CS16"
+//  - target: the target format, such as "CF32"
+//  - priority: the priority of the converter
+//  - converter: the converter
+func Register(source string, target string, priority Priority, converter Func) {
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	targets, found := registry.converters[source]
+	if !found {
+		targets = make(map[string]map[Priority]Func)
+		registry.converters[source] = targets
+	}
+
+	priorities, found := targets[target]
+	if !found {
+		priorities = make(map[Priority]Func)
+		targets[target] = priorities
+	}
+
+	priorities[priority] = converter
+}
+
+// Unregister removes a converter.
+//
+// Params:
+//  - source: the source format
+//  - target: the target format
+//  - priority: the priority of the converter
+func Unregister(source string, target string, priority Priority) {
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	priorities := registry.converters[source][target]
+	delete(priorities, priority)
+	if len(priorities) == 0 {
+		delete(registry.converters[source], target)
+	}
+	if len(registry.converters[source]) == 0 {
+		delete(registry.converters, source)
+	}
+}
+
+// GetFunction gets the converter with the highest priority between two formats.
+//
+// Params:
+//  - source: the source format
+//  - target: the target format
+//
+// Return the converter or an error if no converter is registered between the formats
+func GetFunction(source string, target string) (converter Func, err error) {
+
+	priorities := ListPriorities(source, target)
+	if len(priorities) == 0 {
+		return nil, fmt.Errorf("no converter from %v to %v", source, target)
+	}
+
+	return GetFunctionWithPriority(source, target, priorities[len(priorities)-1])
+}
+
+// GetFunctionWithPriority gets the converter with the given priority between two formats.
+//
+// Params:
+//  - source: the source format
+//  - target: the target format
+//  - priority: the priority of the converter
+//
+// Return the converter or an error if no converter is registered with this priority between the formats
+func GetFunctionWithPriority(source string, target string, priority Priority) (converter Func, err error) {
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	converter, found := registry.converters[source][target][priority]
+	if !found {
+		return nil, fmt.Errorf("no converter from %v to %v with priority %v", source, target, priority)
+	}
+
+	return converter, nil
+}
+
+// ListTargetFormats lists the formats a source format can be converted to.
+//
+// Params:
+//  - source: the source format
+//
+// Return the target formats, sorted
+func ListTargetFormats(source string) []string {
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	targets := make([]string, 0, len(registry.converters[source]))
+	for target := range registry.converters[source] {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	return targets
+}
+
+// ListSourceFormats lists the formats which can be converted to a target format.
+//
+// Params:
+//  - target: the target format
+//
+// Return the source formats, sorted
+func ListSourceFormats(target string) []string {
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	var sources []string
+	for source, targets := range registry.converters {
+		if _, found := targets[target]; found {
+			sources = append(sources, source)
+		}
+	}
+	sort.Strings(sources)
+
+	return sources
+}
+
+// ListAvailableSourceFormats lists the formats which can be converted to at least one format.
+//
+// Return the source formats, sorted
+func ListAvailableSourceFormats() []string {
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	sources := make([]string, 0, len(registry.converters))
+	for source := range registry.converters {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	return sources
+}
+
+// ListPriorities lists the priorities of the converters registered between two formats.
+//
+// Params:
+//  - source: the source format
+//  - target: the target format
+//
+// Return the priorities, in increasing order
+func ListPriorities(source string, target string) []Priority {
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	priorities := make([]Priority, 0, len(registry.converters[source][target]))
+	for priority := range registry.converters[source][target] {
+		priorities = append(priorities, priority)
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i] < priorities[j]
+	})
+
+	return priorities
+}
+
+// Bytes returns the memory of a slice of stream elements as bytes, to be given to a converter. The bytes share the
+// memory of the elements.
+//
+// Params:
+//  - elems: the elements
+//
+// Return the bytes of the elements
+func Bytes[T device.Sample](elems []T) []byte {
+
+	var elem T
+
+	if len(elems) == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*byte)(unsafe.Pointer(&elems[0])), len(elems)*int(unsafe.Sizeof(elem)))
+}
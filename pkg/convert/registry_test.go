@@ -0,0 +1,109 @@
+package convert
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"testing"
+)
+
+func TestGenericConverters(t *testing.T) {
+
+	cs16 := []int16{0, 0, 16384, -16384, 32767, -32768}
+	cf32 := make([]complex64, 3)
+
+	converter, err := GetFunction(device.FormatCS16, device.FormatCF32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converter(Bytes(cf32), Bytes(cs16), 3, 32768)
+	if cf32[1] != complex(0.5, -0.5) {
+		t.Errorf("CS16 %v converted to CF32 %v", cs16, cf32)
+	}
+
+	back := make([]int16, len(cs16))
+	converter, _ = GetFunction(device.FormatCF32, device.FormatCS16)
+	converter(Bytes(back), Bytes(cf32), 3, 32768)
+	for i := range cs16 {
+		if back[i] != cs16[i] {
+			t.Errorf("CS16 %v converted back to %v", cs16, back)
+			break
+		}
+	}
+
+	cu8 := make([]uint8, len(cs16))
+	converter, _ = GetFunction(device.FormatCS16, device.FormatCU8)
+	converter(Bytes(cu8), Bytes(cs16), 3, 0)
+	if fmt.Sprint(cu8) != "[128 128 192 64 255 0]" {
+		t.Errorf("CS16 %v converted to CU8 %v", cs16, cu8)
+	}
+
+	cs12 := make([]uint8, 9)
+	converter, _ = GetFunction(device.FormatCF32, device.FormatCS12)
+	converter(cs12, Bytes(cf32), 3, 2048)
+	converter, _ = GetFunction(device.FormatCS12, device.FormatCS16)
+	converter(Bytes(back), cs12, 3, 0)
+	if fmt.Sprint(back) != "[0 0 16384 -16384 32752 -32768]" {
+		t.Errorf("CF32 %v converted to CS12 then CS16 %v", cf32, back)
+	}
+}
+
+func TestPriorities(t *testing.T) {
+
+	called := false
+	Register(device.FormatCS16, device.FormatCF32, PriorityCustom, func(dst []byte, src []byte, numElems int, scaler float64) {
+		called = true
+	})
+	defer Unregister(device.FormatCS16, device.FormatCF32, PriorityCustom)
+
+	if priorities := ListPriorities(device.FormatCS16, device.FormatCF32); fmt.Sprint(priorities) != "[0 5]" {
+		t.Errorf("priorities %v, [0 5] expected", priorities)
+	}
+
+	converter, err := GetFunction(device.FormatCS16, device.FormatCF32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converter(nil, nil, 0, 1)
+	if !called {
+		t.Error("the converter with the highest priority was not selected")
+	}
+
+	if _, err := GetFunction(device.FormatCS16, "CS24"); err == nil {
+		t.Error("converter to an unknown format found")
+	}
+
+	if targets := ListTargetFormats(device.FormatCU8); len(targets) != len(formatLayouts) {
+		t.Errorf("CU8 converted to %v", targets)
+	}
+}
+
+// benchmarkConverter benchmarks the converter with the highest priority between two formats
+func benchmarkConverter(b *testing.B, source string, target string) {
+
+	const numElems = 8192
+
+	converter, err := GetFunction(source, target)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	src := make([]byte, numElems*formatLayouts[source].size)
+	dst := make([]byte, numElems*formatLayouts[target].size)
+
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		converter(dst, src, numElems, 2048)
+	}
+}
+
+func BenchmarkCU8ToCF32(b *testing.B)  { benchmarkConverter(b, device.FormatCU8, device.FormatCF32) }
+func BenchmarkCS8ToCF32(b *testing.B)  { benchmarkConverter(b, device.FormatCS8, device.FormatCF32) }
+func BenchmarkCS12ToCF32(b *testing.B) { benchmarkConverter(b, device.FormatCS12, device.FormatCF32) }
+func BenchmarkCS16ToCF32(b *testing.B) { benchmarkConverter(b, device.FormatCS16, device.FormatCF32) }
+func BenchmarkCS16ToCF64(b *testing.B) { benchmarkConverter(b, device.FormatCS16, device.FormatCF64) }
+func BenchmarkCF32ToCS16(b *testing.B) { benchmarkConverter(b, device.FormatCF32, device.FormatCS16) }
+func BenchmarkCF32ToCS12(b *testing.B) { benchmarkConverter(b, device.FormatCF32, device.FormatCS12) }
+func BenchmarkCF32ToCF64(b *testing.B) { benchmarkConverter(b, device.FormatCF32, device.FormatCF64) }
+func BenchmarkCS16ToCS8(b *testing.B)  { benchmarkConverter(b, device.FormatCS16, device.FormatCS8) }
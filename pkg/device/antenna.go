@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -22,12 +23,28 @@ import (
 // Return a list of available antenna names
 func (dev *SDRDevice) ListAntennas(direction Direction, channel uint) []string {
 
+	names, _ := dev.ListAntennasChecked(direction, channel)
+
+	return names
+}
+
+// ListAntennasChecked gets a list of available antennas to select on a given chain, returning the failure reported by
+// the driver. See ListAntennas() for the details.
+//
+// Return a list of available antenna names and an error if the call failed
+func (dev *SDRDevice) ListAntennasChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListAntennas", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listAntennas(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listAntennas(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), channelError(&status, "ListAntennas", direction, channel)
 }
 
 // SetAntennas sets the selected antenna on a chain.
@@ -40,10 +57,17 @@ func (dev *SDRDevice) ListAntennas(direction Direction, channel uint) []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetAntennas(direction Direction, channel uint, name string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetAntennas", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setAntenna(dev.device, C.int(direction), C.size_t(channel), cName)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setAntenna(dev.device, C.int(direction), C.size_t(channel), cName, &status)
+
+	return channelError(&status, "SetAntennas", direction, channel)
 }
 
 // GetAntennas gets the selected antenna on a chain.
@@ -55,8 +79,24 @@ func (dev *SDRDevice) SetAntennas(direction Direction, channel uint, name string
 // Return the name of an available antenna
 func (dev *SDRDevice) GetAntennas(direction Direction, channel uint) string {
 
-	val := (*C.char)(C.SoapySDRDevice_getAntenna(dev.device, C.int(direction), C.size_t(channel)))
+	antenna, _ := dev.GetAntennasChecked(direction, channel)
+
+	return antenna
+}
+
+// GetAntennasChecked gets the selected antenna on a chain, returning the failure reported by the driver. See
+// GetAntennas() for the details.
+//
+// Return the name of an available antenna and an error if the call failed
+func (dev *SDRDevice) GetAntennasChecked(direction Direction, channel uint) (antenna string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetAntennas", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getAntenna(dev.device, C.int(direction), C.size_t(channel), &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), channelError(&status, "GetAntennas", direction, channel)
 }
@@ -0,0 +1,279 @@
+package device
+
+import (
+	"context"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// Device is the full API of a SoapySDR device.
+//
+// The getters returning a bare value have a Checked variant, such as GetFrequencyChecked(), which also returns the
+// failure reported by the driver, so that a failed call is not mistaken for a valid zero value.
+//
+// SDRDevice implements Device. Code depending on Device rather than on *SDRDevice can be used with other
+// implementations, such as the simulated device of the package sim, hence can be tested without hardware. The functions
+// are grouped in smaller interfaces following the layout of the package, so code only needing a subset of the API can
+// depend on the relevant group only.
+type Device interface {
+	IdentificationAPI
+	ChannelAPI
+	StreamAPI
+	AntennaAPI
+	FrontendAPI
+	GainAPI
+	FrequencyAPI
+	SampleRateAPI
+	BandwidthAPI
+	ClockingAPI
+	TimeAPI
+	SensorAPI
+	RegisterAPI
+	SettingAPI
+	GPIOAPI
+	I2CAPI
+	SPIAPI
+	UARTAPI
+
+	// Unmake unmakes or releases a device object handle.
+	//
+	// Return an error or nil in case of success
+	Unmake() (err sdrerror.SDRError)
+}
+
+// Ensure SDRDevice implements the full Device API
+var _ Device = (*SDRDevice)(nil)
+
+// IdentificationAPI groups the functions identifying a device and its driver.
+type IdentificationAPI interface {
+	GetDriverKey() (driverKey string)
+	GetDriverKeyChecked() (driverKey string, err sdrerror.SDRError)
+	GetHardwareKey() (hardwareKey string)
+	GetHardwareKeyChecked() (hardwareKey string, err sdrerror.SDRError)
+	GetHardwareInfo() (hardwareInfo map[string]string)
+	GetHardwareInfoChecked() (hardwareInfo map[string]string, err sdrerror.SDRError)
+}
+
+// ChannelAPI groups the functions describing the channels of a device and their mapping to the frontends.
+type ChannelAPI interface {
+	SetFrontendMapping(direction Direction, mapping string) (err sdrerror.SDRError)
+	GetFrontendMapping(direction Direction) string
+	GetFrontendMappingChecked(direction Direction) (mapping string, err sdrerror.SDRError)
+	GetNumChannels(direction Direction) uint
+	GetNumChannelsChecked(direction Direction) (nbChannels uint, err sdrerror.SDRError)
+	GetChannelInfo(direction Direction, channel uint) map[string]string
+	GetChannelInfoChecked(direction Direction, channel uint) (info map[string]string, err sdrerror.SDRError)
+	GetFullDuplex(direction Direction, channel uint) bool
+	GetFullDuplexChecked(direction Direction, channel uint) (fullDuplex bool, err sdrerror.SDRError)
+}
+
+// StreamAPI groups the functions describing and creating streams.
+type StreamAPI interface {
+	GetStreamFormats(direction Direction, channel uint) []string
+	GetStreamFormatsChecked(direction Direction, channel uint) (formats []string, err sdrerror.SDRError)
+	GetNativeStreamFormat(direction Direction, channel uint) (format string, fullScale float64)
+	GetNativeStreamFormatChecked(direction Direction, channel uint) (format string, fullScale float64, err sdrerror.SDRError)
+	GetStreamArgsInfo(direction Direction, channel uint) []SDRArgInfo
+	GetStreamArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError)
+	SetupSDRStreamCU8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU8, err error)
+	SetupSDRStreamCS8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS8, err error)
+	SetupSDRStreamCU16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU16, err error)
+	SetupSDRStreamCS16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS16, err error)
+	SetupSDRStreamCF32(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF32, err error)
+	SetupSDRStreamCF64(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF64, err error)
+}
+
+// AntennaAPI groups the functions selecting the antennas of the channels.
+type AntennaAPI interface {
+	ListAntennas(direction Direction, channel uint) []string
+	ListAntennasChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError)
+	SetAntennas(direction Direction, channel uint, name string) (err sdrerror.SDRError)
+	GetAntennas(direction Direction, channel uint) string
+	GetAntennasChecked(direction Direction, channel uint) (antenna string, err sdrerror.SDRError)
+}
+
+// FrontendAPI groups the functions controlling the frontend corrections (DC offset, IQ balance and frequency
+// correction).
+type FrontendAPI interface {
+	HasDCOffsetMode(direction Direction, channel uint) bool
+	HasDCOffsetModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError)
+	SetDCOffsetMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError)
+	GetDCOffsetMode(direction Direction, channel uint) bool
+	GetDCOffsetModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError)
+	HasDCOffset(direction Direction, channel uint) bool
+	HasDCOffsetChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError)
+	SetDCOffset(direction Direction, channel uint, offsetI float64, offsetQ float64) (err sdrerror.SDRError)
+	GetDCOffset(direction Direction, channel uint) (offsetI float64, offsetQ float64, err sdrerror.SDRError)
+	HasIQBalance(direction Direction, channel uint) bool
+	HasIQBalanceChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError)
+	SetIQBalance(direction Direction, channel uint, balanceI float64, balanceQ float64) (err sdrerror.SDRError)
+	GetIQBalance(direction Direction, channel uint) (balanceI float64, balanceQ float64, err sdrerror.SDRError)
+	HasFrequencyCorrection(direction Direction, channel uint) bool
+	HasFrequencyCorrectionChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError)
+	SetFrequencyCorrection(direction Direction, channel uint, value float64) (err sdrerror.SDRError)
+	GetFrequencyCorrection(direction Direction, channel uint) (value float64)
+	GetFrequencyCorrectionChecked(direction Direction, channel uint) (value float64, err sdrerror.SDRError)
+}
+
+// GainAPI groups the functions controlling the amplification elements of the channels.
+type GainAPI interface {
+	ListGains(direction Direction, channel uint) []string
+	ListGainsChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError)
+	HasGainMode(direction Direction, channel uint) bool
+	HasGainModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError)
+	SetGainMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError)
+	GetGainMode(direction Direction, channel uint) bool
+	GetGainModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError)
+	SetGain(direction Direction, channel uint, gain float64) (err sdrerror.SDRError)
+	SetGainElement(direction Direction, channel uint, name string, gain float64) (err sdrerror.SDRError)
+	GetGain(direction Direction, channel uint) float64
+	GetGainChecked(direction Direction, channel uint) (gain float64, err sdrerror.SDRError)
+	GetGainElement(direction Direction, channel uint, name string) float64
+	GetGainElementChecked(direction Direction, channel uint, name string) (gain float64, err sdrerror.SDRError)
+	GetGainRange(direction Direction, channel uint) SDRRange
+	GetGainRangeChecked(direction Direction, channel uint) (gainRange SDRRange, err sdrerror.SDRError)
+	GetGainElementRange(direction Direction, channel uint, name string) SDRRange
+	GetGainElementRangeChecked(direction Direction, channel uint, name string) (gainRange SDRRange, err sdrerror.SDRError)
+}
+
+// FrequencyAPI groups the functions tuning the channels.
+type FrequencyAPI interface {
+	SetFrequency(direction Direction, channel uint, frequency float64, args map[string]string) (err sdrerror.SDRError)
+	SetFrequencyComponent(direction Direction, channel uint, name string, frequency float64, args map[string]string) (err sdrerror.SDRError)
+	GetFrequency(direction Direction, channel uint) float64
+	GetFrequencyChecked(direction Direction, channel uint) (frequency float64, err sdrerror.SDRError)
+	GetFrequencyComponent(direction Direction, channel uint, name string) float64
+	GetFrequencyComponentChecked(direction Direction, channel uint, name string) (frequency float64, err sdrerror.SDRError)
+	ListFrequencies(direction Direction, channel uint) []string
+	ListFrequenciesChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError)
+	GetFrequencyRange(direction Direction, channel uint) []SDRRange
+	GetFrequencyRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError)
+	GetFrequencyRangeComponent(direction Direction, channel uint, name string) []SDRRange
+	GetFrequencyRangeComponentChecked(direction Direction, channel uint, name string) (ranges []SDRRange, err sdrerror.SDRError)
+	GetFrequencyArgsInfo(direction Direction, channel uint) []SDRArgInfo
+	GetFrequencyArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError)
+}
+
+// SampleRateAPI groups the functions controlling the sample rate of the channels.
+type SampleRateAPI interface {
+	SetSampleRate(direction Direction, channel uint, rate float64) (err sdrerror.SDRError)
+	GetSampleRate(direction Direction, channel uint) float64
+	GetSampleRateChecked(direction Direction, channel uint) (rate float64, err sdrerror.SDRError)
+	GetSampleRateRange(direction Direction, channel uint) []SDRRange
+	GetSampleRateRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError)
+}
+
+// BandwidthAPI groups the functions controlling the baseband filters of the channels.
+type BandwidthAPI interface {
+	SetBandwidth(direction Direction, channel uint, bw float64) (err sdrerror.SDRError)
+	GetBandwidth(direction Direction, channel uint) float64
+	GetBandwidthChecked(direction Direction, channel uint) (bw float64, err sdrerror.SDRError)
+	GetBandwidthRanges(direction Direction, channel uint) []SDRRange
+	GetBandwidthRangesChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError)
+}
+
+// ClockingAPI groups the functions controlling the master clock and the clock sources.
+type ClockingAPI interface {
+	SetMasterClockRate(rate float64) (err sdrerror.SDRError)
+	GetMasterClockRate() float64
+	GetMasterClockRateChecked() (rate float64, err sdrerror.SDRError)
+	GetMasterClockRates() []SDRRange
+	GetMasterClockRatesChecked() (ranges []SDRRange, err sdrerror.SDRError)
+	ListClockSources() []string
+	ListClockSourcesChecked() (sources []string, err sdrerror.SDRError)
+	SetClockSource(source string) (err sdrerror.SDRError)
+	GetClockSource() string
+	GetClockSourceChecked() (source string, err sdrerror.SDRError)
+}
+
+// TimeAPI groups the functions controlling the time sources and the hardware clock.
+type TimeAPI interface {
+	ListTimeSources() []string
+	ListTimeSourcesChecked() (sources []string, err sdrerror.SDRError)
+	SetTimeSource(source string) (err sdrerror.SDRError)
+	GetTimeSource() string
+	GetTimeSourceChecked() (source string, err sdrerror.SDRError)
+	HasHardwareTime(what string) bool
+	HasHardwareTimeChecked(what string) (supported bool, err sdrerror.SDRError)
+	GetHardwareTime(what string) uint
+	GetHardwareTimeChecked(what string) (timeNs uint, err sdrerror.SDRError)
+	SetHardwareTime(timeNs uint, what string) (err sdrerror.SDRError)
+}
+
+// SensorAPI groups the functions reading the global and channel sensors.
+type SensorAPI interface {
+	ListSensors() []string
+	ListSensorsChecked() (keys []string, err sdrerror.SDRError)
+	GetSensorInfo(key string) SDRArgInfo
+	GetSensorInfoChecked(key string) (info SDRArgInfo, err sdrerror.SDRError)
+	ReadSensor(key string) string
+	ReadSensorChecked(key string) (value string, err sdrerror.SDRError)
+	ListChannelSensors(direction Direction, channel uint) []string
+	ListChannelSensorsChecked(direction Direction, channel uint) (keys []string, err sdrerror.SDRError)
+	GetChannelSensorInfo(direction Direction, channel uint, key string) SDRArgInfo
+	GetChannelSensorInfoChecked(direction Direction, channel uint, key string) (info SDRArgInfo, err sdrerror.SDRError)
+	ReadChannelSensor(direction Direction, channel uint, key string) string
+	ReadChannelSensorChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError)
+}
+
+// RegisterAPI groups the functions accessing the registers of the device.
+type RegisterAPI interface {
+	ListRegisterInterfaces() []string
+	ListRegisterInterfacesChecked() (names []string, err sdrerror.SDRError)
+	WriteRegister(name string, addr uint32, value uint32) (err sdrerror.SDRError)
+	ReadRegister(name string, addr uint32) uint32
+	ReadRegisterChecked(name string, addr uint32) (value uint32, err sdrerror.SDRError)
+	WriteRegisters(name string, addr uint32, value []uint32) (err sdrerror.SDRError)
+	ReadRegisters(name string, addr uint32, length uint) []uint32
+	ReadRegistersChecked(name string, addr uint32, length uint) (values []uint32, err sdrerror.SDRError)
+}
+
+// SettingAPI groups the functions reading and writing the global and channel settings.
+type SettingAPI interface {
+	GetSettingInfo() []SDRArgInfo
+	GetSettingInfoChecked() (infos []SDRArgInfo, err sdrerror.SDRError)
+	WriteSetting(key string, value string) (err sdrerror.SDRError)
+	ReadSetting(key string) string
+	ReadSettingChecked(key string) (value string, err sdrerror.SDRError)
+	GetChannelSettingInfo(direction Direction, channel uint) []SDRArgInfo
+	GetChannelSettingInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError)
+	WriteChannelSetting(direction Direction, channel uint, key string, value string) (err sdrerror.SDRError)
+	ReadChannelSetting(direction Direction, channel uint, key string) string
+	ReadChannelSettingChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError)
+}
+
+// GPIOAPI groups the functions accessing the GPIO banks.
+type GPIOAPI interface {
+	ListGPIOBanks() []string
+	ListGPIOBanksChecked() (banks []string, err sdrerror.SDRError)
+	WriteGPIO(bank string, value uint32) (err sdrerror.SDRError)
+	WriteGPIOMasked(bank string, value uint32, mask uint32) (err sdrerror.SDRError)
+	ReadGPIO(bank string) uint32
+	ReadGPIOChecked(bank string) (value uint32, err sdrerror.SDRError)
+	WriteGPIODir(bank string, dir uint32) (err sdrerror.SDRError)
+	WriteGPIODirMasked(bank string, dir uint32, mask uint32) (err sdrerror.SDRError)
+	ReadGPIODir(bank string) uint32
+	ReadGPIODirChecked(bank string) (dir uint32, err sdrerror.SDRError)
+}
+
+// I2CAPI groups the functions accessing the I2C buses.
+type I2CAPI interface {
+	WriteI2C(addr int32, data []uint8) (err sdrerror.SDRError)
+	ReadI2C(addr int32, numBytes uint) (data []uint8)
+	ReadI2CChecked(addr int32, numBytes uint) (data []uint8, err sdrerror.SDRError)
+}
+
+// SPIAPI groups the functions accessing the SPI buses.
+type SPIAPI interface {
+	TransactSPI(addr int32, data uint32, numBits uint32) uint32
+	TransactSPIChecked(addr int32, data uint32, numBits uint32) (value uint32, err sdrerror.SDRError)
+}
+
+// UARTAPI groups the functions accessing the UART devices.
+type UARTAPI interface {
+	ListUARTs() []string
+	ListUARTsChecked() (uarts []string, err sdrerror.SDRError)
+	WriteUART(which string, data string) (err sdrerror.SDRError)
+	ReadUART(which string, timeoutUs uint) string
+	ReadUARTChecked(which string, timeoutUs uint) (data string, err sdrerror.SDRError)
+	ReadUARTContext(ctx context.Context, which string) (data string, err error)
+}
@@ -0,0 +1,200 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync/atomic"
+)
+
+// ReceiveEventType is the type of the events reported by an AsyncReceiver
+type ReceiveEventType int
+
+const (
+	// ReceiveEventTimeout reports a read which timed out. The receiver keeps reading.
+	ReceiveEventTimeout ReceiveEventType = iota
+	// ReceiveEventOverflow reports an overflow: samples were dropped by the device. The receiver keeps reading.
+	ReceiveEventOverflow
+	// ReceiveEventCorruption reports corrupted data, such as a malformed packet. The receiver keeps reading.
+	ReceiveEventCorruption
+	// ReceiveEventError reports an error which stopped the receiver.
+	ReceiveEventError
+)
+
+// ReceiveEvent is an event reported by an AsyncReceiver
+type ReceiveEvent struct {
+	// Type is the type of the event
+	Type ReceiveEventType
+	// Err is the error returned by the read
+	Err error
+}
+
+// ReceiveStats are the counters of an AsyncReceiver
+type ReceiveStats struct {
+	// Blocks is the number of blocks delivered
+	Blocks uint64
+	// Timeouts is the number of reads which timed out
+	Timeouts uint64
+	// Overflows is the number of overflows
+	Overflows uint64
+	// Corruptions is the number of reads with corrupted data
+	Corruptions uint64
+	// DroppedEvents is the number of events which could not be delivered because the events channel was full
+	DroppedEvents uint64
+}
+
+// AsyncReceiver owns the read loop of a receive stream. It reads the stream in a goroutine and delivers the blocks of
+// samples on a channel, the timeouts, overflows and errors being reported as events on another channel.
+type AsyncReceiver[T Sample] struct {
+	timeoutUs uint
+
+	pool   *BufferPool[T]
+	blocks chan SampleBlock[T]
+	events chan ReceiveEvent
+	done   chan struct{}
+	err    error
+
+	nbBlocks        uint64
+	nbTimeouts      uint64
+	nbOverflows     uint64
+	nbCorruptions   uint64
+	nbDroppedEvents uint64
+}
+
+// StartAsyncReceiver starts reading an activated receive stream in a goroutine. The goroutine stops when the context
+// is cancelled or when a read fails with an error other than a timeout, an overflow or a corruption. The stream is
+// neither deactivated nor closed by the receiver.
+//
+// Params:
+//  - ctx: the context controlling the life of the receiver
+//  - stream: the receive stream, which must not be read by anyone else while the receiver runs
+//  - timeoutUs: the timeout of each read in microseconds
+//  - queueSize: the number of blocks and of events which can be queued before being consumed. When the blocks are not
+//    consumed fast enough, the receiver waits, which can cause overflows on the device.
+//
+// Return the receiver or an error if the format of the stream is not supported
+func StartAsyncReceiver[T Sample](ctx context.Context, stream TypedStream[T], timeoutUs uint, queueSize int) (*AsyncReceiver[T], error) {
+
+	pool, err := NewBufferPool[T](stream)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := &AsyncReceiver[T]{
+		timeoutUs: timeoutUs,
+		pool:      pool,
+		blocks:    make(chan SampleBlock[T], queueSize),
+		events:    make(chan ReceiveEvent, queueSize),
+		done:      make(chan struct{}),
+	}
+
+	go receiver.run(ctx)
+
+	return receiver, nil
+}
+
+// Blocks returns the channel delivering the blocks of samples. The channel is closed when the receiver stops.
+func (receiver *AsyncReceiver[T]) Blocks() <-chan SampleBlock[T] {
+
+	return receiver.blocks
+}
+
+// Events returns the channel delivering the events. The channel is closed when the receiver stops. Events which do not
+// fit in the channel are dropped and counted in the statistics of the receiver.
+func (receiver *AsyncReceiver[T]) Events() <-chan ReceiveEvent {
+
+	return receiver.events
+}
+
+// Done returns a channel which is closed when the receiver stops
+func (receiver *AsyncReceiver[T]) Done() <-chan struct{} {
+
+	return receiver.done
+}
+
+// Wait waits for the receiver to stop.
+//
+// Return the error which stopped the receiver: the error of the context if it was cancelled, the error of the read
+// otherwise
+func (receiver *AsyncReceiver[T]) Wait() error {
+
+	<-receiver.done
+
+	return receiver.err
+}
+
+// Stats returns the counters of the receiver
+func (receiver *AsyncReceiver[T]) Stats() ReceiveStats {
+
+	return ReceiveStats{
+		Blocks:        atomic.LoadUint64(&receiver.nbBlocks),
+		Timeouts:      atomic.LoadUint64(&receiver.nbTimeouts),
+		Overflows:     atomic.LoadUint64(&receiver.nbOverflows),
+		Corruptions:   atomic.LoadUint64(&receiver.nbCorruptions),
+		DroppedEvents: atomic.LoadUint64(&receiver.nbDroppedEvents),
+	}
+}
+
+// run is the read loop of the receiver
+func (receiver *AsyncReceiver[T]) run(ctx context.Context) {
+
+	defer close(receiver.done)
+	defer close(receiver.events)
+	defer close(receiver.blocks)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			receiver.err = err
+			return
+		}
+
+		block, err := receiver.pool.ReadPooled(receiver.timeoutUs)
+		if err != nil {
+			if !receiver.report(err) {
+				receiver.err = err
+				return
+			}
+			continue
+		}
+
+		select {
+		case receiver.blocks <- block:
+			atomic.AddUint64(&receiver.nbBlocks, 1)
+		case <-ctx.Done():
+			block.Release()
+			receiver.err = ctx.Err()
+			return
+		}
+	}
+}
+
+// report counts a read error and publishes the matching event.
+//
+// Return true if the receiver can keep reading, false if the error stops the receiver
+func (receiver *AsyncReceiver[T]) report(err error) bool {
+
+	var timeout *sdrerror.Timeout
+	var overflow *sdrerror.Overflow
+	var corruption *sdrerror.Corruption
+
+	event := ReceiveEvent{Type: ReceiveEventError, Err: err}
+	switch {
+	case errors.As(err, &timeout):
+		event.Type = ReceiveEventTimeout
+		atomic.AddUint64(&receiver.nbTimeouts, 1)
+	case errors.As(err, &overflow):
+		event.Type = ReceiveEventOverflow
+		atomic.AddUint64(&receiver.nbOverflows, 1)
+	case errors.As(err, &corruption):
+		event.Type = ReceiveEventCorruption
+		atomic.AddUint64(&receiver.nbCorruptions, 1)
+	}
+
+	select {
+	case receiver.events <- event:
+	default:
+		atomic.AddUint64(&receiver.nbDroppedEvents, 1)
+	}
+
+	return event.Type != ReceiveEventError
+}
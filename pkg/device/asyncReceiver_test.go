@@ -0,0 +1,137 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+	"time"
+)
+
+// failingStream is a receive stream whose reads fail with a stream error after a number of reads
+type failingStream struct {
+	device.TypedStream[complex64]
+	reads int
+}
+
+func (s *failingStream) Read(buffers [][]complex64, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	if s.reads == 0 {
+		return 0, 0, sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), "lost device", "Read", device.DirectionRX.String(), -1)
+	}
+	s.reads--
+
+	return s.TypedStream.Read(buffers, nbElems, outputFlags, timeoutUs)
+}
+
+// nextBlock returns the next block delivered by a receiver, failing the test if none is delivered in time
+func nextBlock(t *testing.T, receiver *device.AsyncReceiver[complex64]) (block device.SampleBlock[complex64], ok bool) {
+
+	t.Helper()
+
+	select {
+	case block, ok = <-receiver.Blocks():
+		return block, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block delivered")
+	}
+
+	return block, false
+}
+
+// checkStopped checks that the channels of a stopped receiver are closed
+//
+// Return the events which were not consumed
+func checkStopped(t *testing.T, receiver *device.AsyncReceiver[complex64]) []device.ReceiveEvent {
+
+	t.Helper()
+
+	for {
+		block, ok := nextBlock(t, receiver)
+		if !ok {
+			break
+		}
+		block.Release()
+	}
+	select {
+	case <-receiver.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the receiver did not stop")
+	}
+
+	var events []device.ReceiveEvent
+	for event := range receiver.Events() {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestAsyncReceiverOrder(t *testing.T) {
+
+	_, stream := simtest.NewActiveRXStream[complex64](t, sim.Config{MTU: 100})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	receiver, err := device.StartAsyncReceiver[complex64](ctx, stream, 100000, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The blocks follow each other at the sample rate of 1MS/s
+	var expectedNs uint
+	for i := 0; i < 20; i++ {
+		block, ok := nextBlock(t, receiver)
+		if !ok {
+			t.Fatalf("the blocks channel closed after %v blocks: %v", i, receiver.Wait())
+		}
+		if i > 0 && block.TimeNs != expectedNs {
+			t.Errorf("block %v at %v, expected %v", i, block.TimeNs, expectedNs)
+		}
+		if block.NumElems != 100 {
+			t.Errorf("block %v of %v samples, expected 100", i, block.NumElems)
+		}
+		expectedNs = block.TimeNs + block.NumElems*1000
+		block.Release()
+	}
+
+	// Cancelling the context stops the receiver and closes its channels
+	cancel()
+	checkStopped(t, receiver)
+	if err := receiver.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("the receiver stopped with %v", err)
+	}
+	if stats := receiver.Stats(); stats.Blocks < 20 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestAsyncReceiverStreamError(t *testing.T) {
+
+	// Every third read overflows, the fifth read fails
+	_, stream := simtest.NewActiveRXStream[complex64](t, sim.Config{MTU: 100, Faults: sim.Faults{OverflowEvery: 3}})
+	failing := &failingStream{TypedStream: stream, reads: 4}
+
+	receiver, err := device.StartAsyncReceiver[complex64](context.Background(), failing, 100000, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The overflow is reported as an event and the receiver keeps reading until the stream error
+	events := checkStopped(t, receiver)
+	if err := receiver.Wait(); !errors.Is(err, sdrerror.ErrStream) {
+		t.Errorf("the receiver stopped with %v", err)
+	}
+	if len(events) != 2 || events[0].Type != device.ReceiveEventOverflow || events[1].Type != device.ReceiveEventError ||
+		!errors.Is(events[1].Err, sdrerror.ErrStream) {
+		t.Errorf("unexpected events %+v", events)
+	}
+
+	stats := receiver.Stats()
+	if stats.Blocks != 3 || stats.Overflows != 1 || stats.DroppedEvents != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
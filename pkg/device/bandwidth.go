@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -22,7 +23,14 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetBandwidth(direction Direction, channel uint, bw float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setBandwidth(dev.device, C.int(direction), C.size_t(channel), C.double(bw))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetBandwidth", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setBandwidth(dev.device, C.int(direction), C.size_t(channel), C.double(bw), &status)
+
+	return channelError(&status, "SetBandwidth", direction, channel)
 }
 
 // GetBandwidth gets the baseband filter width of the chain.
@@ -34,7 +42,25 @@ func (dev *SDRDevice) SetBandwidth(direction Direction, channel uint, bw float64
 // Return the baseband filter width in Hz
 func (dev *SDRDevice) GetBandwidth(direction Direction, channel uint) float64 {
 
-	return float64(C.SoapySDRDevice_getBandwidth(dev.device, C.int(direction), C.size_t(channel)))
+	bw, _ := dev.GetBandwidthChecked(direction, channel)
+
+	return bw
+}
+
+// GetBandwidthChecked gets the baseband filter width of the chain, returning the failure reported by the driver. See
+// GetBandwidth() for the details.
+//
+// Return the baseband filter width in Hz and an error if the call failed
+func (dev *SDRDevice) GetBandwidthChecked(direction Direction, channel uint) (bw float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetBandwidth", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	bw = float64(C.SoapySDRGo_getBandwidth(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return bw, channelError(&status, "GetBandwidth", direction, channel)
 }
 
 // GetBandwidthRanges gets the range of possible baseband filter widths.
@@ -46,10 +72,26 @@ func (dev *SDRDevice) GetBandwidth(direction Direction, channel uint) float64 {
 // Return a list of bandwidth ranges in Hz
 func (dev *SDRDevice) GetBandwidthRanges(direction Direction, channel uint) []SDRRange {
 
+	ranges, _ := dev.GetBandwidthRangesChecked(direction, channel)
+
+	return ranges
+}
+
+// GetBandwidthRangesChecked gets the range of possible baseband filter widths, returning the failure reported by the
+// driver. See GetBandwidthRanges() for the details.
+//
+// Return a list of bandwidth ranges in Hz and an error if the call failed
+func (dev *SDRDevice) GetBandwidthRangesChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetBandwidthRanges", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getBandwidthRange(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getBandwidthRange(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer rangeArrayClear(info)
 
-	return rangeArray2Go(info, length)
+	return rangeArray2Go(info, length), channelError(&status, "GetBandwidthRanges", direction, channel)
 }
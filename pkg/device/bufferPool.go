@@ -0,0 +1,175 @@
+package device
+
+import (
+	"sync"
+)
+
+// SampleBlock is a block of samples read from a stream. The buffers of the block come from a BufferPool: Release must
+// be called once the block is consumed, and the buffers must not be used afterwards.
+//
+// A block is a value: the pool gives its buffers again in new blocks once it is released, and each block carries the
+// generation of the buffers it was given with, so that releasing a block whose buffers were already given again has no
+// effect.
+type SampleBlock[T Sample] struct {
+	// Buffers holds the samples of each channel. Each buffer holds NumElems samples.
+	Buffers [][]T
+	// NumElems is the number of samples of each channel
+	NumElems uint
+	// TimeNs is the timestamp of the first sample in nanoseconds, valid if Flags has StreamFlagHasTime
+	TimeNs uint
+	// Flags are the flags returned by the read for each channel
+	Flags []int
+
+	buffers    *pooledBuffers[T]
+	generation uint64
+}
+
+// pooledBuffers are the buffers of a pool given to successive blocks
+type pooledBuffers[T Sample] struct {
+	pool    *BufferPool[T]
+	buffers [][]T
+	views   [][]T
+	flags   []int
+	// generation is the generation of the block holding the buffers, incremented when the block is released
+	generation uint64
+}
+
+// HasFlag checks if the read of the block returned the given flag on its first channel
+//
+// Params:
+//  - flag: the flag to check
+//
+// Return true if the flag is set
+func (block SampleBlock[T]) HasFlag(flag StreamFlag) bool {
+
+	return len(block.Flags) > 0 && block.Flags[0]&int(flag) != 0
+}
+
+// Release gives the buffers of the block back to its pool. Releasing a block twice has no effect, even when its
+// buffers were given to another block meanwhile.
+func (block SampleBlock[T]) Release() {
+
+	if block.buffers != nil {
+		block.buffers.pool.put(block.buffers, block.generation)
+	}
+}
+
+// BufferPool is a pool of blocks of buffers for reading a receive stream without allocating: each block holds one
+// buffer per channel of the stream, large enough for the MTU of the stream. The blocks are allocated when the pool is
+// empty and reused once released, so that a read loop which releases its blocks allocates nothing once warmed up.
+//
+// A BufferPool is safe for concurrent use, but the stream must be read by one goroutine at a time.
+type BufferPool[T Sample] struct {
+	stream         TypedStream[T]
+	elemsPerSample uint
+	mtu            uint
+	nbChannels     uint
+
+	mutex sync.Mutex
+	free  []*pooledBuffers[T]
+}
+
+// NewBufferPool creates a pool of buffers for a receive stream, sized from the MTU and the number of channels of the
+// stream.
+//
+// Params:
+//  - stream: the receive stream
+//
+// Return the pool or an error if the format of the stream is not supported
+func NewBufferPool[T Sample](stream TypedStream[T]) (*BufferPool[T], error) {
+
+	elemsPerSample, err := FormatElemsPerSample[T](stream.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BufferPool[T]{
+		stream:         stream,
+		elemsPerSample: elemsPerSample,
+		mtu:            uint(stream.GetMTU()),
+		nbChannels:     stream.GetNumChannels(),
+	}, nil
+}
+
+// MTU returns the number of samples of each buffer of the blocks of the pool
+func (pool *BufferPool[T]) MTU() uint {
+
+	return pool.mtu
+}
+
+// Get takes a block from the pool, allocating its buffers if the pool is empty. The buffers of the block hold MTU()
+// samples and its flags are cleared.
+//
+// Return the block, which must be released once consumed
+func (pool *BufferPool[T]) Get() SampleBlock[T] {
+
+	pool.mutex.Lock()
+	var buffers *pooledBuffers[T]
+	if last := len(pool.free) - 1; last >= 0 {
+		buffers = pool.free[last]
+		pool.free[last] = nil
+		pool.free = pool.free[:last]
+	}
+	pool.mutex.Unlock()
+
+	if buffers == nil {
+		buffers = &pooledBuffers[T]{
+			pool:    pool,
+			buffers: makeBuffers[T](pool.nbChannels, pool.mtu*pool.elemsPerSample),
+			views:   make([][]T, pool.nbChannels),
+			flags:   make([]int, pool.nbChannels),
+		}
+	}
+
+	for channelIdx, buffer := range buffers.buffers {
+		buffers.views[channelIdx] = buffer
+		buffers.flags[channelIdx] = 0
+	}
+
+	return SampleBlock[T]{
+		Buffers:    buffers.views,
+		NumElems:   pool.mtu,
+		Flags:      buffers.flags,
+		buffers:    buffers,
+		generation: buffers.generation,
+	}
+}
+
+// ReadPooled reads up to MTU() samples of each channel of the stream into a block of the pool.
+//
+// Params:
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the block, which must be released once consumed, or an error, in which case the block is empty
+func (pool *BufferPool[T]) ReadPooled(timeoutUs uint) (block SampleBlock[T], err error) {
+
+	block = pool.Get()
+
+	timeNs, numElemsRead, err := pool.stream.Read(block.buffers.buffers, pool.mtu, block.Flags, timeoutUs)
+	if err != nil {
+		block.Release()
+		return SampleBlock[T]{}, err
+	}
+
+	for channelIdx, buffer := range block.buffers.buffers {
+		block.Buffers[channelIdx] = buffer[:numElemsRead*pool.elemsPerSample]
+	}
+	block.NumElems = numElemsRead
+	block.TimeNs = timeNs
+
+	return block, nil
+}
+
+// put gives the buffers of a block back to the pool, unless the block is not the last one given with them
+func (pool *BufferPool[T]) put(buffers *pooledBuffers[T], generation uint64) {
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	if buffers.generation != generation {
+		return
+	}
+	buffers.generation++
+
+	pool.free = append(pool.free, buffers)
+}
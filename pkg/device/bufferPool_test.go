@@ -0,0 +1,120 @@
+package device_test
+
+import (
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"testing"
+)
+
+// newPooledStream sets up and activates a simulated receive stream of two channels and creates its buffer pool
+func newPooledStream[T device.Sample](tb testing.TB) (device.TypedStream[T], *device.BufferPool[T]) {
+
+	_, stream := simtest.NewActiveRXStream[T](tb, sim.Config{NumRXChannels: 2})
+
+	pool, err := device.NewBufferPool[T](stream)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return stream, pool
+}
+
+func TestReadPooledAllocations(t *testing.T) {
+
+	_, pool := newPooledStream[device.CS16](t)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		block, err := pool.ReadPooled(100000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Release()
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per read", allocs)
+	}
+
+	block, err := pool.ReadPooled(100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.NumElems != pool.MTU() || len(block.Buffers) != 2 || uint(len(block.Buffers[1])) != pool.MTU() {
+		t.Errorf("%v samples read in %v buffers, %v samples in 2 buffers expected", block.NumElems, len(block.Buffers), pool.MTU())
+	}
+	block.Release()
+	block.Release()
+
+	if first, second := pool.Get(), pool.Get(); sameBuffers(first, second) {
+		t.Error("a block released twice was given twice")
+	}
+}
+
+// sameBuffers checks if two blocks share their buffers
+func sameBuffers[T device.Sample](first device.SampleBlock[T], second device.SampleBlock[T]) bool {
+
+	return &first.Buffers[0][0] == &second.Buffers[0][0]
+}
+
+func TestReleaseAfterReuse(t *testing.T) {
+
+	_, pool := newPooledStream[device.CS16](t)
+
+	stale := pool.Get()
+	stale.Release()
+
+	reused := pool.Get()
+	if !sameBuffers(stale, reused) {
+		t.Fatal("the buffers of a released block were not reused")
+	}
+
+	// Releasing the stale block must not give the buffers of the block in use back to the pool
+	stale.Release()
+	if other := pool.Get(); sameBuffers(other, reused) {
+		t.Error("the buffers of a block in use were given to another block")
+	}
+
+	reused.Release()
+	if other := pool.Get(); !sameBuffers(other, reused) {
+		t.Error("the buffers of a released block were not given back to the pool")
+	}
+}
+
+// benchmarkReadPooled reads a simulated stream with a buffer pool
+func benchmarkReadPooled[T device.Sample](b *testing.B) {
+
+	_, pool := newPooledStream[T](b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		block, err := pool.ReadPooled(100000)
+		if err != nil {
+			b.Fatal(err)
+		}
+		block.Release()
+	}
+}
+
+// benchmarkReadAllocating reads a simulated stream with buffers allocated for each read
+func benchmarkReadAllocating[T device.Sample](b *testing.B) {
+
+	stream, pool := newPooledStream[T](b)
+	_, elemsPerSample, _ := device.StreamFormat[T]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buffers := [][]T{make([]T, pool.MTU()*elemsPerSample), make([]T, pool.MTU()*elemsPerSample)}
+		if _, _, err := stream.Read(buffers, pool.MTU(), make([]int, 2), 100000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadPooledCF32(b *testing.B)     { benchmarkReadPooled[complex64](b) }
+func BenchmarkReadAllocatingCF32(b *testing.B) { benchmarkReadAllocating[complex64](b) }
+func BenchmarkReadPooledCS16(b *testing.B)     { benchmarkReadPooled[device.CS16](b) }
+func BenchmarkReadAllocatingCS16(b *testing.B) { benchmarkReadAllocating[device.CS16](b) }
@@ -0,0 +1,264 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// BurstEventType is the type of the status events reported by a transmit stream during a burst
+type BurstEventType int
+
+const (
+	// BurstEventCompleted reports the end of the burst: all its samples were transmitted
+	BurstEventCompleted BurstEventType = iota
+	// BurstEventLate reports a burst whose time had already passed when it reached the device. The burst is dropped.
+	BurstEventLate
+	// BurstEventUnderflow reports an underflow: the device ran out of samples during the burst
+	BurstEventUnderflow
+	// BurstEventError reports another error returned by the stream
+	BurstEventError
+)
+
+// String returns the name of the type of event
+func (eventType BurstEventType) String() string {
+
+	switch eventType {
+	case BurstEventCompleted:
+		return "completed"
+	case BurstEventLate:
+		return "late"
+	case BurstEventUnderflow:
+		return "underflow"
+	default:
+		return "error"
+	}
+}
+
+// BurstEvent is a status event reported by a transmit stream during a burst
+type BurstEvent struct {
+	// Type is the type of the event
+	Type BurstEventType
+	// TimeNs is the timestamp reported with the event in nanoseconds, valid if Flags has StreamFlagHasTime
+	TimeNs uint
+	// Flags are the flags reported with the event
+	Flags int
+	// Err is the error reported by the stream, nil for BurstEventCompleted
+	Err error
+}
+
+// BurstReport reports the transmission of a burst by TransmitBurst()
+type BurstReport struct {
+	// NumElemsWritten is the number of samples written to each channel
+	NumElemsWritten uint
+	// NumWrites is the number of successful writes the burst was split into
+	NumWrites uint
+	// Events are the status events reported by the stream, in order
+	Events []BurstEvent
+	// Completed is true if the stream reported the end of the burst. It stays false when the stream does not report
+	// the status of the bursts or when the context was done before the end of the burst was reported.
+	Completed bool
+	// Late is true if the burst reached the device after its time
+	Late bool
+	// Underflow is true if the device ran out of samples during the burst
+	Underflow bool
+}
+
+// BurstWriter writes a burst of samples to an activated transmit stream, possibly in several parts, in writes of at
+// most one MTU. The first write carries StreamFlagHasTime when a time is given. StreamFlagEndBurst is only set on the
+// write which completes the burst: the last sample of the burst is held back and written alone with the flag, so that
+// a partial write can never end the burst while samples remain.
+type BurstWriter[T Sample] struct {
+	stream         StreamWriter[T]
+	elemsPerSample uint
+	mtu            uint
+	atTimeNs       uint
+
+	// The buffers and the flags of the current write
+	buffers [][]T
+	flags   []int
+	timeNs  uint
+
+	// NumElemsWritten is the number of samples written to each channel
+	NumElemsWritten uint
+	// NumWrites is the number of successful writes the burst was split into
+	NumWrites uint
+}
+
+// NewBurstWriter creates a writer of a burst on an activated transmit stream.
+//
+// Params:
+//  - stream: the transmit stream
+//  - atTimeNs: the time of the first sample in nanoseconds, 0 to transmit the burst as soon as possible
+//
+// Return the writer and an error if the type of the samples does not match the format of the stream
+func NewBurstWriter[T Sample](stream TypedStream[T], atTimeNs uint) (*BurstWriter[T], error) {
+
+	elemsPerSample, err := FormatElemsPerSample[T](stream.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	nbChannels := stream.GetNumChannels()
+
+	return &BurstWriter[T]{
+		stream:         stream,
+		elemsPerSample: elemsPerSample,
+		mtu:            uint(stream.GetMTU()),
+		atTimeNs:       atTimeNs,
+		buffers:        make([][]T, nbChannels),
+		flags:          make([]int, nbChannels),
+	}, nil
+}
+
+// Write writes the next samples of the burst, until all of them are written or an error occurs.
+//
+// Params:
+//  - ctx: the context of the writes
+//  - samples: the samples of each channel
+//  - numElems: the number of samples of each channel to write
+//  - last: true if these samples end the burst
+//
+// Return the number of samples of each channel written and the error which stopped the writes, if any. A write which
+// accepts no sample without any error is reported as an error matching sdrerror.ErrStream.
+func (w *BurstWriter[T]) Write(ctx context.Context, samples [][]T, numElems uint, last bool) (numElemsWritten uint, err error) {
+
+	if err := CheckBuffers(samples, uint(len(w.buffers)), numElems, w.elemsPerSample); err != nil {
+		return 0, err
+	}
+
+	for numElemsWritten < numElems {
+
+		nbElems := numElems - numElemsWritten
+		var writeFlags StreamFlag
+		w.timeNs = 0
+		if w.NumElemsWritten == 0 && w.atTimeNs != 0 {
+			writeFlags |= StreamFlagHasTime
+			w.timeNs = w.atTimeNs
+		}
+		if last {
+			if nbElems == 1 {
+				writeFlags |= StreamFlagEndBurst
+			} else {
+				nbElems--
+			}
+		}
+		if w.mtu != 0 && nbElems > w.mtu {
+			nbElems = w.mtu
+		}
+
+		for channelIdx := range w.buffers {
+			w.buffers[channelIdx] = samples[channelIdx][numElemsWritten*w.elemsPerSample:]
+			w.flags[channelIdx] = int(writeFlags)
+		}
+
+		numElemsWrittenNow, err := WriteContext[T](ctx, w.stream, w.buffers, nbElems, w.flags, w.timeNs)
+		if err != nil {
+			return numElemsWritten, err
+		}
+		if numElemsWrittenNow == 0 {
+			return numElemsWritten, sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), "the stream accepted no sample", "Write", DirectionTX.String(), -1)
+		}
+
+		numElemsWritten += numElemsWrittenNow
+		w.NumElemsWritten += numElemsWrittenNow
+		w.NumWrites++
+	}
+
+	return numElemsWritten, nil
+}
+
+// TransmitBurst transmits a burst of samples on an activated transmit stream. The burst is written with a BurstWriter:
+// it is split in writes of at most one MTU, the first write carries StreamFlagHasTime when a time is given and only
+// the write which completes the burst carries StreamFlagEndBurst. Once all the samples are written, the status of the
+// stream is read until the end of the burst, a late burst or another error is reported, until the stream reports that
+// it does not support status or until the context is done: give a context with a deadline when the stream may not
+// report the end of the bursts.
+//
+// Params:
+//  - ctx: the context of the burst
+//  - stream: the transmit stream
+//  - samples: the samples of each channel. The number of samples is derived from the length of the shortest buffer.
+//  - atTimeNs: the time of the first sample in nanoseconds, 0 to transmit the burst as soon as possible
+//
+// Return the report of the burst and the error which stopped the writes, if any. The status events, including the
+// errors reported after the writes, are given by the report.
+func TransmitBurst[T Sample](ctx context.Context, stream TypedStream[T], samples [][]T, atTimeNs uint) (report BurstReport, err error) {
+
+	writer, err := NewBurstWriter[T](stream, atTimeNs)
+	if err != nil {
+		return report, err
+	}
+
+	numElems := BuffersNumElems(samples, writer.elemsPerSample)
+	if err := CheckBuffers(samples, stream.GetNumChannels(), numElems, writer.elemsPerSample); err != nil {
+		return report, err
+	}
+
+	_, err = writer.Write(ctx, samples, numElems, true)
+	report.NumElemsWritten = writer.NumElemsWritten
+	report.NumWrites = writer.NumWrites
+	if err != nil {
+		if ctx.Err() == nil {
+			report.addError(err, writer.timeNs, writer.flags[0])
+		}
+		return report, err
+	}
+
+	report.readStatus(ctx, stream, stream.GetNumChannels())
+
+	return report, nil
+}
+
+// TransmitBurst transmits a burst of samples on the stream. See the TransmitBurst function for the details.
+func (stream *Stream[T]) TransmitBurst(ctx context.Context, samples [][]T, atTimeNs uint) (report BurstReport, err error) {
+
+	return TransmitBurst[T](ctx, stream, samples, atTimeNs)
+}
+
+// readStatus reads the status of the stream after the writes of a burst, until the end of the burst or an error is
+// reported or the context is done
+func (report *BurstReport) readStatus(ctx context.Context, stream SDRStream, nbChannels uint) {
+
+	chanMask := make([]uint, nbChannels)
+	flags := make([]int, nbChannels)
+
+	for !report.Completed && !report.Late {
+
+		timeNs, err := ReadStreamStatusContext(ctx, stream, chanMask, flags)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, sdrerror.ErrNotSupported) {
+				return
+			}
+			if report.addError(err, timeNs, flags[0]) == BurstEventError {
+				return
+			}
+			continue
+		}
+
+		if flags[0]&int(StreamFlagEndBurst) != 0 {
+			report.Completed = true
+			report.Events = append(report.Events, BurstEvent{Type: BurstEventCompleted, TimeNs: timeNs, Flags: flags[0]})
+		}
+	}
+}
+
+// addError adds the event matching an error reported by the stream.
+//
+// Return the type of the event
+func (report *BurstReport) addError(err error, timeNs uint, flags int) BurstEventType {
+
+	eventType := BurstEventError
+	switch {
+	case errors.Is(err, sdrerror.ErrTime):
+		eventType = BurstEventLate
+		report.Late = true
+	case errors.Is(err, sdrerror.ErrUnderflow):
+		eventType = BurstEventUnderflow
+		report.Underflow = true
+	}
+
+	report.Events = append(report.Events, BurstEvent{Type: eventType, TimeNs: timeNs, Flags: flags, Err: err})
+
+	return eventType
+}
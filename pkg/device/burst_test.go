@@ -0,0 +1,189 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+	"time"
+)
+
+// transmittedWrite is a write received by a simulated transmit stream
+type transmittedWrite struct {
+	numElems uint
+	timeNs   uint
+	flags    int
+}
+
+// newTXStream sets up and activates a simulated transmit stream of one channel, recording the writes it receives
+func newTXStream(t *testing.T) (*sim.Device, device.TypedStream[complex64], *[]transmittedWrite) {
+
+	writes := &[]transmittedWrite{}
+	dev := simtest.NewDevice(t, sim.Config{
+		MTU: 1000,
+		Transmitted: func(channel uint, samples []complex128, timeNs uint, flags int) {
+			*writes = append(*writes, transmittedWrite{numElems: uint(len(samples)), timeNs: timeNs, flags: flags})
+		},
+	})
+
+	stream, err := sim.SetupStream[complex64](dev, device.DirectionTX, []uint{0}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Activate(0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = stream.Deactivate(0, 0)
+		_ = stream.Close()
+	})
+
+	return dev, stream, writes
+}
+
+// partialStream is a transmit stream accepting at most a given number of samples per write
+type partialStream struct {
+	device.TypedStream[complex64]
+	maxElems uint
+}
+
+func (s *partialStream) Write(buffers [][]complex64, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+
+	if nbElems > s.maxElems {
+		nbElems = s.maxElems
+	}
+	if nbElems == 0 {
+		return 0, nil
+	}
+
+	return s.TypedStream.Write(buffers, nbElems, flags, timeNs, timeoutUs)
+}
+
+// checkWrites checks that only the last write ends the burst, and that the writes hold all the samples of the burst
+func checkWrites(t *testing.T, writes []transmittedWrite, numElems uint) {
+
+	total := uint(0)
+	for i, write := range writes {
+		total += write.numElems
+		endBurst := device.StreamFlag(write.flags).Has(device.StreamFlagEndBurst)
+		if endBurst != (i == len(writes)-1) {
+			t.Errorf("write %v of %v: end of burst %v", i, len(writes), endBurst)
+		}
+	}
+	if total != numElems {
+		t.Errorf("%v samples transmitted, expected %v", total, numElems)
+	}
+}
+
+func TestTransmitBurst(t *testing.T) {
+
+	dev, stream, writes := newTXStream(t)
+	if err := dev.SetHardwareTime(1e9, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	samples := [][]complex64{make([]complex64, 2500)}
+	atTimeNs := uint(2e9)
+	report, err := stream.TransmitBurst(ctx, samples, atTimeNs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Completed || report.Late || report.NumElemsWritten != 2500 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Events) != 1 || report.Events[0].Type != device.BurstEventCompleted {
+		t.Errorf("unexpected events %+v", report.Events)
+	}
+	if report.NumWrites != uint(len(*writes)) {
+		t.Errorf("%v writes reported, %v transmitted", report.NumWrites, len(*writes))
+	}
+
+	// The writes are split by MTU, the last sample being held back for the write ending the burst. Only the first
+	// write carries the time of the burst.
+	expected := []transmittedWrite{
+		{numElems: 1000, timeNs: atTimeNs, flags: int(device.StreamFlagHasTime)},
+		{numElems: 1000},
+		{numElems: 499},
+		{numElems: 1, flags: int(device.StreamFlagEndBurst)},
+	}
+	if len(*writes) != len(expected) {
+		t.Fatalf("writes %+v, expected %+v", *writes, expected)
+	}
+	for i, write := range *writes {
+		if write != expected[i] {
+			t.Errorf("write %v is %+v, expected %+v", i, write, expected[i])
+		}
+	}
+}
+
+func TestTransmitBurstLate(t *testing.T) {
+
+	dev, stream, writes := newTXStream(t)
+	if err := dev.SetHardwareTime(2e9, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report, err := stream.TransmitBurst(ctx, [][]complex64{make([]complex64, 1500)}, 1e9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Late || report.Completed || report.NumElemsWritten != 1500 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Events) != 1 || report.Events[0].Type != device.BurstEventLate || !errors.Is(report.Events[0].Err, sdrerror.ErrTime) {
+		t.Errorf("unexpected events %+v", report.Events)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("%v writes of a late burst transmitted", len(*writes))
+	}
+}
+
+func TestTransmitBurstPartialWrites(t *testing.T) {
+
+	_, stream, writes := newTXStream(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	partial := &partialStream{TypedStream: stream, maxElems: 300}
+	report, err := device.TransmitBurst[complex64](ctx, partial, [][]complex64{make([]complex64, 1000)}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Completed || report.NumElemsWritten != 1000 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	checkWrites(t, *writes, 1000)
+}
+
+func TestTransmitBurstNoProgress(t *testing.T) {
+
+	_, stream, writes := newTXStream(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stalled := &partialStream{TypedStream: stream}
+	report, err := device.TransmitBurst[complex64](ctx, stalled, [][]complex64{make([]complex64, 1000)}, 0)
+	if !errors.Is(err, sdrerror.ErrStream) {
+		t.Fatalf("expected a stream error, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("the burst waited for the context")
+	}
+	if report.NumElemsWritten != 0 || len(*writes) != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -24,10 +25,17 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetFrontendMapping(direction Direction, mapping string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetFrontendMapping", direction.String())
+	}
+
 	cMapping := C.CString(mapping)
 	defer C.free(unsafe.Pointer(cMapping))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setFrontendMapping(dev.device, C.int(direction), cMapping)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setFrontendMapping(dev.device, C.int(direction), cMapping, &status)
+
+	return directionError(&status, "SetFrontendMapping", direction)
 }
 
 // GetFrontendMapping gets the mapping configuration string.
@@ -38,10 +46,26 @@ func (dev *SDRDevice) SetFrontendMapping(direction Direction, mapping string) (e
 // Return the vendor-specific mapping string
 func (dev *SDRDevice) GetFrontendMapping(direction Direction) string {
 
-	val := (*C.char)(C.SoapySDRDevice_getFrontendMapping(dev.device, C.int(direction)))
+	mapping, _ := dev.GetFrontendMappingChecked(direction)
+
+	return mapping
+}
+
+// GetFrontendMappingChecked gets the mapping configuration string, returning the failure reported by the driver. See
+// GetFrontendMapping() for the details.
+//
+// Return the vendor-specific mapping string and an error if the call failed
+func (dev *SDRDevice) GetFrontendMappingChecked(direction Direction) (mapping string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetFrontendMapping", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getFrontendMapping(dev.device, C.int(direction), &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), directionError(&status, "GetFrontendMapping", direction)
 }
 
 // GetNumChannels gets a number of channels given the streaming direction.
@@ -52,7 +76,25 @@ func (dev *SDRDevice) GetFrontendMapping(direction Direction) string {
 // Return the number of channels
 func (dev *SDRDevice) GetNumChannels(direction Direction) uint {
 
-	return uint(C.SoapySDRDevice_getNumChannels(dev.device, C.int(direction)))
+	nbChannels, _ := dev.GetNumChannelsChecked(direction)
+
+	return nbChannels
+}
+
+// GetNumChannelsChecked gets a number of channels given the streaming direction, returning the failure reported by the
+// driver. See GetNumChannels() for the details.
+//
+// Return the number of channels and an error if the call failed
+func (dev *SDRDevice) GetNumChannelsChecked(direction Direction) (nbChannels uint, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetNumChannels", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	nbChannels = uint(C.SoapySDRGo_getNumChannels(dev.device, C.int(direction), &status))
+
+	return nbChannels, directionError(&status, "GetNumChannels", direction)
 }
 
 // GetChannelInfo gets channel info given the streaming direction.
@@ -64,10 +106,26 @@ func (dev *SDRDevice) GetNumChannels(direction Direction) uint {
 // Return channel information
 func (dev *SDRDevice) GetChannelInfo(direction Direction, channel uint) map[string]string {
 
-	info := C.SoapySDRDevice_getChannelInfo(dev.device, C.int(direction), C.size_t(channel))
-	defer argsClear(info)
+	info, _ := dev.GetChannelInfoChecked(direction, channel)
+
+	return info
+}
+
+// GetChannelInfoChecked gets channel info given the streaming direction, returning the failure reported by the driver.
+// See GetChannelInfo() for the details.
+//
+// Return channel information and an error if the call failed
+func (dev *SDRDevice) GetChannelInfoChecked(direction Direction, channel uint) (info map[string]string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetChannelInfo", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	cInfo := C.SoapySDRGo_getChannelInfo(dev.device, C.int(direction), C.size_t(channel), &status)
+	defer argsClear(cInfo)
 
-	return args2Go(info)
+	return args2Go(cInfo), channelError(&status, "GetChannelInfo", direction, channel)
 }
 
 // GetFullDuplex finds out if the specified channel is full or half duplex.
@@ -79,5 +137,23 @@ func (dev *SDRDevice) GetChannelInfo(direction Direction, channel uint) map[stri
 // Return true for full duplex, false for half duplex
 func (dev *SDRDevice) GetFullDuplex(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_getFullDuplex(dev.device, C.int(direction), C.size_t(channel)))
+	fullDuplex, _ := dev.GetFullDuplexChecked(direction, channel)
+
+	return fullDuplex
+}
+
+// GetFullDuplexChecked finds out if the specified channel is full or half duplex, returning the failure reported by the
+// driver. See GetFullDuplex() for the details.
+//
+// Return true for full duplex, false for half duplex, and an error if the call failed
+func (dev *SDRDevice) GetFullDuplexChecked(direction Direction, channel uint) (fullDuplex bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "GetFullDuplex", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	fullDuplex = bool(C.SoapySDRGo_getFullDuplex(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return fullDuplex, channelError(&status, "GetFullDuplex", direction, channel)
 }
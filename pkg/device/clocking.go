@@ -7,13 +7,14 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
 	"unsafe"
 )
 
-// SetMasterClockRate the frontend DC offset correction.
+// SetMasterClockRate sets the master clock rate of the device.
 //
 // Params:
 //  - rate: the clock rate in Hz
@@ -21,7 +22,14 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetMasterClockRate(rate float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setMasterClockRate(dev.device, C.double(rate))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetMasterClockRate", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setMasterClockRate(dev.device, C.double(rate), &status)
+
+	return callError(&status, "SetMasterClockRate")
 }
 
 // GetMasterClockRate gets the master clock rate of the device.
@@ -29,7 +37,25 @@ func (dev *SDRDevice) SetMasterClockRate(rate float64) (err sdrerror.SDRError) {
 // Return the clock rate in Hz
 func (dev *SDRDevice) GetMasterClockRate() float64 {
 
-	return float64(C.SoapySDRDevice_getMasterClockRate(dev.device))
+	rate, _ := dev.GetMasterClockRateChecked()
+
+	return rate
+}
+
+// GetMasterClockRateChecked gets the master clock rate of the device, returning the failure reported by the driver. See
+// GetMasterClockRate() for the details.
+//
+// Return the clock rate in Hz and an error if the call failed
+func (dev *SDRDevice) GetMasterClockRateChecked() (rate float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetMasterClockRate", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	rate = float64(C.SoapySDRGo_getMasterClockRate(dev.device, &status))
+
+	return rate, callError(&status, "GetMasterClockRate")
 }
 
 // GetMasterClockRates gets the range of available master clock rates.
@@ -37,25 +63,57 @@ func (dev *SDRDevice) GetMasterClockRate() float64 {
 // Return a list of clock rate ranges in Hz
 func (dev *SDRDevice) GetMasterClockRates() []SDRRange {
 
+	ranges, _ := dev.GetMasterClockRatesChecked()
+
+	return ranges
+}
+
+// GetMasterClockRatesChecked gets the range of available master clock rates, returning the failure reported by the
+// driver. See GetMasterClockRates() for the details.
+//
+// Return a list of clock rate ranges in Hz and an error if the call failed
+func (dev *SDRDevice) GetMasterClockRatesChecked() (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetMasterClockRates", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getMasterClockRates(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getMasterClockRates(dev.device, &length, &status)
 	defer rangeArrayClear(info)
 
-	return rangeArray2Go(info, length)
+	return rangeArray2Go(info, length), callError(&status, "GetMasterClockRates")
 }
 
 // ListClockSources gets the list of available clock sources.
 //
-// Return a list of available antenna names
+// Return a list of clock source names
 func (dev *SDRDevice) ListClockSources() []string {
 
+	sources, _ := dev.ListClockSourcesChecked()
+
+	return sources
+}
+
+// ListClockSourcesChecked gets the list of available clock sources, returning the failure reported by the driver. See
+// ListClockSources() for the details.
+//
+// Return a list of clock source names and an error if the call failed
+func (dev *SDRDevice) ListClockSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListClockSources", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listClockSources(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listClockSources(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListClockSources")
 }
 
 // SetClockSource set the clock source on the device.
@@ -66,10 +124,17 @@ func (dev *SDRDevice) ListClockSources() []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetClockSource(source string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetClockSource", "")
+	}
+
 	cSource := C.CString(source)
 	defer C.free(unsafe.Pointer(cSource))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setClockSource(dev.device, cSource)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setClockSource(dev.device, cSource, &status)
+
+	return callError(&status, "SetClockSource")
 }
 
 // GetClockSource gets the clock source of the device.
@@ -77,8 +142,24 @@ func (dev *SDRDevice) SetClockSource(source string) (err sdrerror.SDRError) {
 // Return the name of a clock source
 func (dev *SDRDevice) GetClockSource() string {
 
-	val := (*C.char)(C.SoapySDRDevice_getClockSource(dev.device))
+	source, _ := dev.GetClockSourceChecked()
+
+	return source
+}
+
+// GetClockSourceChecked gets the clock source of the device, returning the failure reported by the driver. See
+// GetClockSource() for the details.
+//
+// Return the name of a clock source and an error if the call failed
+func (dev *SDRDevice) GetClockSourceChecked() (source string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetClockSource", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getClockSource(dev.device, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "GetClockSource")
 }
@@ -0,0 +1,186 @@
+package device
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/convert/kernel"
+	"math"
+)
+
+// Complex integer sample types. Each value holds a whole sample, I and Q, so that a stream of such elements uses one
+// element per sample: SetupStream[CS16](...) opens a CS16 stream whose buffers are []CS16 instead of []int16 with I and
+// Q interleaved. The memory layout of the types is the one of the SoapySDR formats.
+type (
+	// CU8 is a sample in CU8 format
+	CU8 struct{ I, Q uint8 }
+	// CS8 is a sample in CS8 format
+	CS8 struct{ I, Q int8 }
+	// CU16 is a sample in CU16 format
+	CU16 struct{ I, Q uint16 }
+	// CS16 is a sample in CS16 format
+	CS16 struct{ I, Q int16 }
+	// CU32 is a sample in CU32 format
+	CU32 struct{ I, Q uint32 }
+	// CS32 is a sample in CS32 format
+	CS32 struct{ I, Q int32 }
+)
+
+// ComplexInt is the constraint gathering the complex integer sample types
+type ComplexInt interface {
+	CU8 | CS8 | CU16 | CS16 | CU32 | CS32
+}
+
+// componentFloat is the constraint gathering the types of the components of the complex float types
+type componentFloat interface {
+	float32 | float64
+}
+
+// conversionChunk is the number of samples converted at once by ConvertComplexInt()
+const conversionChunk = 256
+
+// DefaultFullScale returns the full scale of the complex integer samples of type T when they use all the bits of their
+// components: 128 for CU8 and CS8, 32768 for CU16 and CS16 and 2147483648 for CU32 and CS32, see FormatFullScale().
+// Drivers using fewer bits, such as 12 bits samples stored in CS16, report their full scale with
+// GetNativeStreamFormat().
+func DefaultFullScale[T ComplexInt]() float64 {
+
+	format, _, _ := StreamFormat[T]()
+
+	return FormatFullScale(format)
+}
+
+// ToComplex64 converts complex integer samples to complex64 samples, the full scale being converted to 1.0. The
+// components of the unsigned formats are centered on the middle of their range (128 for CU8...).
+//
+// Params:
+//  - dst: the converted samples
+//  - src: the samples to convert
+//  - fullScale: the full scale of the source samples, as reported by GetNativeStreamFormat() or DefaultFullScale()
+//
+// Return the number of samples converted, limited by the length of both slices
+func ToComplex64[T ComplexInt](dst []complex64, src []T, fullScale float64) int {
+
+	return toComplex[T, complex64, float32](dst, src, fullScale)
+}
+
+// ToComplex128 converts complex integer samples to complex128 samples, the full scale being converted to 1.0. See
+// ToComplex64() for the details.
+//
+// Params:
+//  - dst: the converted samples
+//  - src: the samples to convert
+//  - fullScale: the full scale of the source samples, as reported by GetNativeStreamFormat() or DefaultFullScale()
+//
+// Return the number of samples converted, limited by the length of both slices
+func ToComplex128[T ComplexInt](dst []complex128, src []T, fullScale float64) int {
+
+	return toComplex[T, complex128, float64](dst, src, fullScale)
+}
+
+// FromComplex64 converts complex64 samples to complex integer samples, 1.0 being converted to the full scale. The
+// values are rounded to the nearest integer and clipped to the range of the components.
+//
+// Params:
+//  - dst: the converted samples
+//  - src: the samples to convert
+//  - fullScale: the full scale of the converted samples, as reported by GetNativeStreamFormat() or DefaultFullScale()
+//
+// Return the number of samples converted, limited by the length of both slices
+func FromComplex64[T ComplexInt](dst []T, src []complex64, fullScale float64) int {
+
+	return fromComplex[T, complex64, float32](dst, src, fullScale)
+}
+
+// FromComplex128 converts complex128 samples to complex integer samples, 1.0 being converted to the full scale. See
+// FromComplex64() for the details.
+//
+// Params:
+//  - dst: the converted samples
+//  - src: the samples to convert
+//  - fullScale: the full scale of the converted samples, as reported by GetNativeStreamFormat() or DefaultFullScale()
+//
+// Return the number of samples converted, limited by the length of both slices
+func FromComplex128[T ComplexInt](dst []T, src []complex128, fullScale float64) int {
+
+	return fromComplex[T, complex128, float64](dst, src, fullScale)
+}
+
+// ConvertComplexInt converts complex integer samples from one type to another, the full scale of the source samples
+// being converted to the full scale of the converted samples.
+//
+// Params:
+//  - dst: the converted samples
+//  - src: the samples to convert
+//  - srcFullScale: the full scale of the source samples
+//  - dstFullScale: the full scale of the converted samples
+//
+// Return the number of samples converted, limited by the length of both slices
+func ConvertComplexInt[S ComplexInt, D ComplexInt](dst []D, src []S, srcFullScale float64, dstFullScale float64) int {
+
+	var chunk [conversionChunk]complex128
+
+	nbSamples := minInt(len(dst), len(src))
+
+	for offset := 0; offset < nbSamples; offset += conversionChunk {
+		end := minInt(offset+conversionChunk, nbSamples)
+		ToComplex128(chunk[:end-offset], src[offset:end], srcFullScale)
+		FromComplex128(dst[offset:end], chunk[:end-offset], dstFullScale)
+	}
+
+	return nbSamples
+}
+
+// toComplex converts complex integer samples to complex float samples whose components are of type F
+func toComplex[T ComplexInt, C complex64 | complex128, F componentFloat](dst []C, src []T, fullScale float64) int {
+
+	nbSamples := minInt(len(dst), len(src))
+	if nbSamples == 0 {
+		return 0
+	}
+
+	out := kernel.Interleaved[C, F](dst[:nbSamples])
+	scale := 1 / fullScale
+
+	switch samples := any(src[:nbSamples]).(type) {
+	case []CU8:
+		kernel.IntsToFloats(out, kernel.Interleaved[CU8, uint8](samples), 0x80, scale)
+	case []CS8:
+		kernel.IntsToFloats(out, kernel.Interleaved[CS8, int8](samples), 0, scale)
+	case []CU16:
+		kernel.IntsToFloats(out, kernel.Interleaved[CU16, uint16](samples), 0x8000, scale)
+	case []CS16:
+		kernel.IntsToFloats(out, kernel.Interleaved[CS16, int16](samples), 0, scale)
+	case []CU32:
+		kernel.IntsToFloats(out, kernel.Interleaved[CU32, uint32](samples), 0x80000000, scale)
+	case []CS32:
+		kernel.IntsToFloats(out, kernel.Interleaved[CS32, int32](samples), 0, scale)
+	}
+
+	return nbSamples
+}
+
+// fromComplex converts complex float samples whose components are of type F to complex integer samples
+func fromComplex[T ComplexInt, C complex64 | complex128, F componentFloat](dst []T, src []C, fullScale float64) int {
+
+	nbSamples := minInt(len(dst), len(src))
+	if nbSamples == 0 {
+		return 0
+	}
+
+	in := kernel.Interleaved[C, F](src[:nbSamples])
+
+	switch samples := any(dst[:nbSamples]).(type) {
+	case []CU8:
+		kernel.FloatsToInts(kernel.Interleaved[CU8, uint8](samples), in, 0x80, fullScale, 0, math.MaxUint8)
+	case []CS8:
+		kernel.FloatsToInts(kernel.Interleaved[CS8, int8](samples), in, 0, fullScale, math.MinInt8, math.MaxInt8)
+	case []CU16:
+		kernel.FloatsToInts(kernel.Interleaved[CU16, uint16](samples), in, 0x8000, fullScale, 0, math.MaxUint16)
+	case []CS16:
+		kernel.FloatsToInts(kernel.Interleaved[CS16, int16](samples), in, 0, fullScale, math.MinInt16, math.MaxInt16)
+	case []CU32:
+		kernel.FloatsToInts(kernel.Interleaved[CU32, uint32](samples), in, 0x80000000, fullScale, 0, math.MaxUint32)
+	case []CS32:
+		kernel.FloatsToInts(kernel.Interleaved[CS32, int32](samples), in, 0, fullScale, math.MinInt32, math.MaxInt32)
+	}
+
+	return nbSamples
+}
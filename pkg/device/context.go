@@ -0,0 +1,177 @@
+package device
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// contextSliceUs is the longest timeout in microseconds given to a blocking call by the context-aware functions. It
+// bounds the delay between the cancellation of the context and the return of the function.
+const contextSliceUs = 100000
+
+// contextTimeoutUs returns the timeout to give to the next slice of a blocking call made on behalf of a context
+//
+// Return the timeout in microseconds, or the error of the context if it is done
+func contextTimeoutUs(ctx context.Context) (uint, error) {
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	timeoutUs := uint(contextSliceUs)
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, context.DeadlineExceeded
+		}
+		if remainingUs := uint(remaining / time.Microsecond); remainingUs < timeoutUs {
+			timeoutUs = remainingUs
+		}
+	}
+
+	return timeoutUs, nil
+}
+
+// ReadContext reads elements from a stream for reception, until elements are received or the context is done. The
+// read is made of successive reads with a bounded timeout, so that the cancellation of the context is noticed
+// promptly.
+//
+// Params:
+//  - ctx: the context of the read
+//  - stream: the stream to read
+//  - buffers: the buffers of each channel. See Stream.Read() for the details.
+//  - nbElems: the number of elements to read
+//  - outputFlags: the flag indicators of the result by channel
+//
+// Return the buffer's timestamp in nanoseconds, the number of elements read per buffer and an error, which is the
+// error of the context if it is done before any element is read
+func ReadContext[T Sample](ctx context.Context, stream StreamReader[T], buffers [][]T, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error) {
+
+	for {
+		timeoutUs, err := contextTimeoutUs(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		timeNs, numElemsRead, err = stream.Read(buffers, nbElems, outputFlags, timeoutUs)
+		if err == nil || !isTimeout(err) {
+			return timeNs, numElemsRead, err
+		}
+	}
+}
+
+// WriteContext writes elements to a stream for transmission, until elements are sent or the context is done. The
+// write is made of successive writes with a bounded timeout, so that the cancellation of the context is noticed
+// promptly.
+//
+// Params:
+//  - ctx: the context of the write
+//  - stream: the stream to write
+//  - buffers: the buffers of each channel. See Stream.Write() for the details.
+//  - nbElems: the number of elements to write
+//  - flags: input flags by channel
+//  - timeNs: the buffer's timestamp in nanoseconds
+//
+// Return the number of elements written per buffer and an error, which is the error of the context if it is done
+// before any element is written
+func WriteContext[T Sample](ctx context.Context, stream StreamWriter[T], buffers [][]T, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	for {
+		timeoutUs, err := contextTimeoutUs(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		numElemsWritten, err = stream.Write(buffers, nbElems, flags, timeNs, timeoutUs)
+		if err == nil || !isTimeout(err) {
+			return numElemsWritten, err
+		}
+	}
+}
+
+// ReadStreamStatusContext reads status information about a stream, until a status is available or the context is
+// done. The read is made of successive reads with a bounded timeout, so that the cancellation of the context is
+// noticed promptly.
+//
+// Params:
+//  - ctx: the context of the read
+//  - stream: the stream whose status is read
+//  - chanMask: to which channels this status applies
+//  - flags: optional input flags and output flags
+//
+// Return the buffer's timestamp in nanoseconds and an error, which is the error of the context if it is done before a
+// status is read
+func ReadStreamStatusContext(ctx context.Context, stream SDRStream, chanMask []uint, flags []int) (timeNs uint, err error) {
+
+	for {
+		timeoutUs, err := contextTimeoutUs(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		timeNs, err = stream.ReadStreamStatus(chanMask, flags, timeoutUs)
+		if err == nil || !isTimeout(err) {
+			return timeNs, err
+		}
+	}
+}
+
+// ReadUARTContext reads bytes from a UART until newline or until the context is done. The read is made of successive
+// reads with a bounded timeout, so that the cancellation of the context is noticed promptly.
+//
+// Params:
+//  - ctx: the context of the read
+//  - dev: the device owning the UART
+//  - which: the name of an available UART
+//
+// Return the bytes read, ending with a newline in case of success, and the error of the context if it is done before a
+// newline is read, or the error of the driver if a read failed with another error than a timeout
+func ReadUARTContext(ctx context.Context, dev UARTAPI, which string) (data string, err error) {
+
+	var builder strings.Builder
+
+	for {
+		timeoutUs, err := contextTimeoutUs(ctx)
+		if err != nil {
+			return builder.String(), err
+		}
+
+		data, readErr := dev.ReadUARTChecked(which, timeoutUs)
+		builder.WriteString(data)
+		if readErr != nil && !isTimeout(readErr) {
+			return builder.String(), readErr
+		}
+		if strings.HasSuffix(builder.String(), "\n") {
+			return builder.String(), nil
+		}
+	}
+}
+
+// ReadContext reads elements from the stream until elements are received or the context is done. See the ReadContext
+// function for the details.
+func (stream *Stream[T]) ReadContext(ctx context.Context, buffers [][]T, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error) {
+
+	return ReadContext[T](ctx, stream, buffers, nbElems, outputFlags)
+}
+
+// WriteContext writes elements to the stream until elements are sent or the context is done. See the WriteContext
+// function for the details.
+func (stream *Stream[T]) WriteContext(ctx context.Context, buffers [][]T, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	return WriteContext[T](ctx, stream, buffers, nbElems, flags, timeNs)
+}
+
+// ReadStreamStatusContext reads status information about the stream until a status is available or the context is
+// done. See the ReadStreamStatusContext function for the details.
+func (stream *Stream[T]) ReadStreamStatusContext(ctx context.Context, chanMask []uint, flags []int) (timeNs uint, err error) {
+
+	return ReadStreamStatusContext(ctx, stream, chanMask, flags)
+}
+
+// ReadUARTContext reads bytes from a UART until newline or until the context is done. See the ReadUARTContext function
+// for the details.
+func (dev *SDRDevice) ReadUARTContext(ctx context.Context, which string) (data string, err error) {
+
+	return ReadUARTContext(ctx, dev, which)
+}
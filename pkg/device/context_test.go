@@ -0,0 +1,160 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+	"time"
+)
+
+// promptDelay is the longest delay accepted between the end of a context and the return of a context-aware call
+const promptDelay = time.Second
+
+// silentStream is a receive stream whose reads time out after their timeout
+type silentStream struct {
+	device.TypedStream[complex64]
+	reads        int
+	maxTimeoutUs uint
+}
+
+func (s *silentStream) Read(buffers [][]complex64, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	s.reads++
+	if timeoutUs > s.maxTimeoutUs {
+		s.maxTimeoutUs = timeoutUs
+	}
+	time.Sleep(time.Duration(timeoutUs) * time.Microsecond)
+
+	return 0, 0, sdrerror.ErrTimeout
+}
+
+func TestReadContext(t *testing.T) {
+
+	stream := &silentStream{}
+	buffers := [][]complex64{make([]complex64, 100)}
+	flags := make([]int, 1)
+
+	// The read is made of slices of at most 100ms, ending with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(150*time.Millisecond, cancel)
+	start := time.Now()
+	if _, _, err := device.ReadContext[complex64](ctx, stream, buffers, 100, flags); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled read returned %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > promptDelay {
+		t.Errorf("cancelled read returned after %v", elapsed)
+	}
+	if stream.reads < 2 || stream.maxTimeoutUs > 100000 {
+		t.Errorf("%v reads with a timeout up to %vus, expected slices of at most 100ms", stream.reads, stream.maxTimeoutUs)
+	}
+
+	// The slices do not exceed the deadline of the context
+	stream = &silentStream{}
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if _, _, err := device.ReadContext[complex64](ctx, stream, buffers, 100, flags); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("read past its deadline returned %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > promptDelay {
+		t.Errorf("read past its deadline returned after %v", elapsed)
+	}
+	if stream.maxTimeoutUs > 50000 {
+		t.Errorf("read with a timeout of %vus past the deadline", stream.maxTimeoutUs)
+	}
+
+	// A read with a done context does not read
+	stream = &silentStream{}
+	if _, _, err := device.ReadContext[complex64](ctx, stream, buffers, 100, flags); !errors.Is(err, context.DeadlineExceeded) || stream.reads != 0 {
+		t.Errorf("read with a done context made %v reads and returned %v", stream.reads, err)
+	}
+}
+
+func TestReadStreamStatusContext(t *testing.T) {
+
+	_, stream, _ := newTXStream(t)
+	flags := make([]int, 1)
+
+	// No status is available: the read ends with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := stream.ReadStreamStatusContext(ctx, nil, flags); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled status read returned %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > promptDelay {
+		t.Errorf("cancelled status read returned after %v", elapsed)
+	}
+
+	writeBurst(t, stream, 10e9)
+	if _, err := stream.ReadStreamStatusContext(context.Background(), nil, flags); err != nil || !device.StreamFlag(flags[0]).Has(device.StreamFlagEndBurst) {
+		t.Errorf("status with flags %v: %v, expected the end of the burst", device.StreamFlag(flags[0]), err)
+	}
+}
+
+func TestReadUARTContext(t *testing.T) {
+
+	dev := simtest.NewDevice(t, sim.Config{UARTs: []string{"UART0"}})
+
+	// A line written before the read is returned with its newline
+	if err := dev.WriteUART("UART0", "hello\n"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := dev.ReadUARTContext(context.Background(), "UART0"); data != "hello\n" || err != nil {
+		t.Errorf("read %q: %v", data, err)
+	}
+
+	// The bytes read before the end of the context are returned with the error of the context
+	if err := dev.WriteUART("UART0", "partial"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if data, err := dev.ReadUARTContext(ctx, "UART0"); data != "partial" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("read %q: %v", data, err)
+	}
+	if elapsed := time.Since(start); elapsed > promptDelay {
+		t.Errorf("read past its deadline returned after %v", elapsed)
+	}
+
+	// The errors of the driver other than timeouts stop the read without waiting for the context
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := dev.ReadUARTContext(ctx, "UART1"); err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("read of an unknown UART returned %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("the read of an unknown UART waited for the context")
+	}
+}
+
+// timeoutUART is a UART whose reads time out, returning the bytes received so far
+type timeoutUART struct {
+	device.UARTAPI
+	chunks []string
+}
+
+func (uart *timeoutUART) ReadUARTChecked(which string, timeoutUs uint) (data string, err sdrerror.SDRError) {
+
+	if len(uart.chunks) == 0 {
+		return "", sdrerror.ErrTimeout
+	}
+	data = uart.chunks[0]
+	uart.chunks = uart.chunks[1:]
+
+	return data, sdrerror.ErrTimeout
+}
+
+func TestReadUARTContextTimeouts(t *testing.T) {
+
+	// The timeouts of the driver are retried, the bytes they return are kept
+	uart := &timeoutUART{chunks: []string{"he", "llo", "\n"}}
+	if data, err := device.ReadUARTContext(context.Background(), uart, "UART0"); data != "hello\n" || err != nil {
+		t.Errorf("read %q: %v", data, err)
+	}
+}
@@ -5,8 +5,10 @@ package device
 // #include <SoapySDR/Types.h>
 import "C"
 import (
+	"context"
 	"fmt"
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"strings"
 )
 
 // Direction is the direction of the Data in the device TX and RX
@@ -19,6 +21,19 @@ const (
 	DirectionRX Direction = 1
 )
 
+// String returns the name of the direction, "TX" or "RX"
+func (direction Direction) String() string {
+
+	switch direction {
+	case DirectionTX:
+		return "TX"
+	case DirectionRX:
+		return "RX"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(direction))
+	}
+}
+
 // SDRArgInfoType is the type of data of an ArgInfo structure
 type SDRArgInfoType int
 
@@ -72,12 +87,79 @@ const (
 	StreamFlagWaitTrigger StreamFlag = 1 << 6
 )
 
+// streamFlagNames gives the names of the stream flags, in increasing order of value
+var streamFlagNames = []struct {
+	flag StreamFlag
+	name string
+}{
+	{StreamFlagEndBurst, "EndBurst"},
+	{StreamFlagHasTime, "HasTime"},
+	{StreamFlagEndAbrupt, "EndAbrupt"},
+	{StreamFlagOnePacket, "OnePacket"},
+	{StreamFlagMoreFragments, "MoreFragments"},
+	{StreamFlagWaitTrigger, "WaitTrigger"},
+}
+
+// Has checks if the given flag is set
+//
+// Params:
+//  - flag: the flag to check
+//
+// Return true if the flag is set
+func (flags StreamFlag) Has(flag StreamFlag) bool {
+
+	return flags&flag == flag
+}
+
+// Split decodes the flags into the individual flags which are set. The bits which do not match a known flag are
+// returned as a single value, last.
+//
+// Return the flags which are set, in increasing order of value
+func (flags StreamFlag) Split() []StreamFlag {
+
+	var split []StreamFlag
+	for _, known := range streamFlagNames {
+		if flags.Has(known.flag) {
+			split = append(split, known.flag)
+			flags &^= known.flag
+		}
+	}
+	if flags != 0 {
+		split = append(split, flags)
+	}
+
+	return split
+}
+
+// String returns the names of the flags which are set, separated by "|"
+func (flags StreamFlag) String() string {
+
+	names := make([]string, 0, len(streamFlagNames))
+	for _, flag := range flags.Split() {
+		name := fmt.Sprintf("0x%x", int(flag))
+		for _, known := range streamFlagNames {
+			if known.flag == flag {
+				name = known.name
+			}
+		}
+		names = append(names, name)
+	}
+
+	return strings.Join(names, "|")
+}
+
 // SDRDevice is the opaque structure allowing to access device functions
 type SDRDevice struct {
 	device *C.SoapySDRDevice
+	handle uint64
+	// streams are the open streams of the device, closed when the device is unmade
+	streams *streamSet
 }
 
-// SDRStream is the opaque structure allowing to access stream functions
+// SDRStream is the opaque structure allowing to access stream functions.
+//
+// SDRStream only holds the functions which do not depend on the format of the stream. The typed functions are
+// available through the typed stream interfaces, such as TypedStreamCF32.
 type SDRStream interface {
 	// Close closes an open stream created by setupStream
 	//
@@ -95,6 +177,16 @@ type SDRStream interface {
 	// Return the MTU in number of stream elements (never zero)
 	GetMTU() int
 
+	// GetNumChannels returns the number of channels of the stream.
+	//
+	// Return the number of channels given when the stream was set up
+	GetNumChannels() uint
+
+	// GetFormat returns the format of the elements of the stream, such as "CF32".
+	//
+	// Return the format of the stream
+	GetFormat() string
+
 	// Activate activates a stream.
 	//
 	// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
@@ -140,6 +232,12 @@ type SDRStream interface {
 	// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
 	ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error)
 
+	// ReadStreamStatusContext reads status information about a stream, until a status is available or the context is
+	// done.
+	//
+	// See the ReadStreamStatusContext function for the details.
+	ReadStreamStatusContext(ctx context.Context, chanMask []uint, flags []int) (timeNs uint, err error)
+
 	// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
 	//
 	// This is the number of times the user can call acquire() on a stream without making subsequent calls to
@@ -148,12 +246,40 @@ type SDRStream interface {
 	// Return the number of direct access buffers or 0
 	GetNumDirectAccessBuffers() uint
 
+	// ReleaseReadBuffer releases buffers acquired with AcquireReadBuffer() back to the receive stream.
+	//
+	// The slices returned by AcquireReadBuffer() for this handle must not be used after this call.
+	//
+	// Params:
+	//  - handle: the opaque handle returned by AcquireReadBuffer()
+	ReleaseReadBuffer(handle uint)
+
+	// ReleaseWriteBuffer releases buffers acquired with AcquireWriteBuffer() back to the transmit stream, for
+	// transmission.
+	//
+	// The slices returned by AcquireWriteBuffer() for this handle must not be used after this call.
+	//
+	// Params:
+	//  - handle: the opaque handle returned by AcquireWriteBuffer()
+	//  - numElems: the number of elements written to each buffer
+	//  - flags: input flags, updated with the value of the output flags (device specific). The number of flags must
+	//    match the number of channels of the stream.
+	//  - timeNs: the buffer's timestamp in nanoseconds
+	ReleaseWriteBuffer(handle uint, numElems uint, flags []int, timeNs uint)
+}
+
+// cStream is a stream backed by a SoapySDR stream, giving access to the internal C objects
+type cStream interface {
+	SDRStream
+
 	// getDevice returns the internal device
 	getDevice() *C.SoapySDRDevice
 	// getStream returns the internal stream
 	getStream() *C.SoapySDRStream
 	// getNbChannels returns the number of channels used by the stream
 	getNbChannels() uint
+	// getDirection returns the direction of the stream
+	getDirection() Direction
 }
 
 // SDRRange is the definition for a min/max numeric range with a step information
@@ -1,3 +1,5 @@
+// Package device regroups all the functions for accessing
+// devices and streams.
 package device
 
 // #cgo CFLAGS: -g -Wall
@@ -7,10 +9,12 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"errors"
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync"
 	"unsafe"
 )
 
@@ -19,6 +23,10 @@ import (
 // The status code is cleared on entry to each Device call. When an device API call throws, the C bindings catch
 // the exception, and set a non-zero last status code. Use LastStatus() to determine success/failure for
 // Device calls without integer status return codes.
+//
+// The status code is stored per OS thread, and a goroutine can move to another thread between two calls: the status
+// code is only meaningful if the calling goroutine is locked to its thread with runtime.LockOSThread(). The functions
+// of the package returning an error capture the status of the call themselves and do not need it.
 func LastStatus() int {
 
 	return int(C.SoapySDRDevice_lastStatus())
@@ -28,12 +36,113 @@ func LastStatus() int {
 //
 // When an device API call throws, the C bindings catch the exception, store its message in thread-safe storage,
 // and return a non-zero status code to indicate failure. Use lastError() to access the exception's error message.
+//
+// As for LastStatus(), the message is stored per OS thread and is only meaningful if the calling goroutine is locked to
+// its thread with runtime.LockOSThread().
 func LastError() string {
 
 	// Do not free as it is internal string of Soapy
 	return C.GoString(C.SoapySDRDevice_lastError())
 }
 
+// takeStatus reads the status captured by a shim of the device API and releases its message.
+//
+// Params:
+//  - status: the status captured by the shim
+//
+// Return the status code and the error message, empty if none
+func takeStatus(status *C.SoapySDRGoStatus) (code int, message string) {
+
+	if status.message != nil {
+		message = C.GoString(status.message)
+		C.free(unsafe.Pointer(status.message))
+		status.message = nil
+	}
+
+	return int(status.code), message
+}
+
+// callError builds the error of a failed device call, carrying the name of the call and the error message of the
+// call.
+//
+// Params:
+//  - status: the status captured by the shim of the call
+//  - op: the name of the call
+//
+// Return the error or nil if the status code is not an error
+func callError(status *C.SoapySDRGoStatus, op string) sdrerror.SDRError {
+
+	code, message := takeStatus(status)
+
+	return sdrerror.Wrap(code, message, op, "", -1)
+}
+
+// channelError builds the error of a failed call on a channel, carrying the name of the call, the direction and the
+// channel and the error message of the call.
+//
+// Params:
+//  - status: the status captured by the shim of the call
+//  - op: the name of the call
+//  - direction: the direction of the channel
+//  - channel: the channel
+//
+// Return the error or nil if the status code is not an error
+func channelError(status *C.SoapySDRGoStatus, op string, direction Direction, channel uint) sdrerror.SDRError {
+
+	code, message := takeStatus(status)
+
+	return sdrerror.Wrap(code, message, op, direction.String(), int(channel))
+}
+
+// directionError builds the error of a failed call applying to a direction, such as a call on a stream, carrying the
+// name of the call, the direction and the error message of the call.
+//
+// Params:
+//  - status: the status captured by the shim of the call
+//  - op: the name of the call
+//  - direction: the direction
+//
+// Return the error or nil if the status code is not an error
+func directionError(status *C.SoapySDRGoStatus, op string, direction Direction) sdrerror.SDRError {
+
+	code, message := takeStatus(status)
+
+	return sdrerror.Wrap(code, message, op, direction.String(), -1)
+}
+
+// createError builds the error of a call creating an object, such as a device or a stream, which failed by returning
+// NULL. The call may fail without reporting a status, in which case the error is an unknown error.
+//
+// Params:
+//  - status: the status captured by the shim of the call
+//  - op: the name of the call
+//  - direction: the direction of the created object ("RX" or "TX"), or empty
+//
+// Return the error
+func createError(status *C.SoapySDRGoStatus, op string, direction string) sdrerror.SDRError {
+
+	code, message := takeStatus(status)
+	if code == 0 {
+		code = sdrerror.ErrUnknown.SDRErrorCode()
+	}
+
+	return sdrerror.Wrap(code, message, op, direction, -1)
+}
+
+// rejectedError builds the error of a call rejected by the bindings without reaching the driver, such as a call on a
+// closed stream.
+//
+// Params:
+//  - err: the SDR error matching the reason of the rejection
+//  - op: the name of the call
+//  - direction: the direction of the call ("RX" or "TX"), or empty
+//
+// Return the error
+func rejectedError(err sdrerror.SDRError, op string, direction string) sdrerror.SDRError {
+
+	return sdrerror.Wrap(err.SDRErrorCode(), "", op, direction, -1)
+}
+
 // Enumerate returns a list of available devices on the system.
 //
 // Params:
@@ -88,14 +197,15 @@ func Make(args map[string]string) (device *SDRDevice, err error) {
 	cArgs, cArgsLength := go2Args(args)
 	defer argsListClear(cArgs, cArgsLength)
 
-	dev := C.SoapySDRDevice_make(cArgs)
+	var status C.SoapySDRGoStatus
+	dev := C.SoapySDRGo_make(cArgs, &status)
 	if dev == nil {
-		return nil, errors.New(LastError())
+		return nil, createError(&status, "Make", "")
 	}
 
-	return &SDRDevice{
+	return trackDevice(&SDRDevice{
 		device: dev,
-	}, nil
+	}, argsDescription(args)), nil
 }
 
 // MakeStrArgs makes a new Device object given device construction args.
@@ -112,25 +222,101 @@ func MakeStrArgs(args string) (device *SDRDevice, err error) {
 	cArgs := C.CString(args)
 	defer C.free(unsafe.Pointer(cArgs))
 
-	dev := C.SoapySDRDevice_makeStrArgs(cArgs)
+	var status C.SoapySDRGoStatus
+	dev := C.SoapySDRGo_makeStrArgs(cArgs, &status)
 	if dev == nil {
-		return nil, errors.New(LastError())
+		return nil, createError(&status, "MakeStrArgs", "")
 	}
 
-	return &SDRDevice{
+	return trackDevice(&SDRDevice{
 		device: dev,
-	}, nil
+	}, args), nil
 }
 
-// Unmake unmakes or releases a device object handle.
+// Unmake unmakes or releases a device object handle. The streams of the device which are still open are closed first.
+// Any call on the device or on its streams after Unmake() returns an error matching sdrerror.ErrClosed.
 //
 // Params:
 //  - device: a pointer to a device object
 //
-// Return an error or nil in case of success
+// Return an error or nil in case of success, sdrerror.ErrClosed if the device is already released
 func (dev *SDRDevice) Unmake() (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_unmake(dev.device)))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "Unmake", "")
+	}
+
+	closeErr := dev.streams.closeAll()
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_unmake(dev.device, &status)
+	dev.device = nil
+	untrackDevice(dev)
+
+	if err := callError(&status, "Unmake"); err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// streamSet is the set of the open streams of a device, closed when the device is unmade. It holds the cores of the
+// streams, see streamCore.
+type streamSet struct {
+	mutex sync.Mutex
+	cores map[*streamCore]struct{}
+}
+
+// add adds the core of a stream set up on the device
+func (set *streamSet) add(core *streamCore) {
+
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	if set.cores == nil {
+		set.cores = make(map[*streamCore]struct{})
+	}
+	set.cores[core] = struct{}{}
+}
+
+// remove removes the core of a closed stream. A nil set, of a stream not set up on a device, is ignored.
+func (set *streamSet) remove(core *streamCore) {
+
+	if set == nil {
+		return
+	}
+
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	delete(set.cores, core)
+}
+
+// closeAll closes the streams which are still open. Each close waits for the calls in progress on its stream. A nil
+// set, of a device on which no stream was set up, is ignored.
+//
+// Return the first error returned by a close, nil if none
+func (set *streamSet) closeAll() (err sdrerror.SDRError) {
+
+	if set == nil {
+		return nil
+	}
+
+	set.mutex.Lock()
+	cores := make([]*streamCore, 0, len(set.cores))
+	for core := range set.cores {
+		cores = append(cores, core)
+	}
+	set.mutex.Unlock()
+
+	for _, core := range cores {
+		// A stream closed concurrently since the list was taken is already gone
+		if closeErr := core.close(); closeErr != nil && err == nil && !errors.Is(closeErr, sdrerror.ErrClosed) {
+			err = closeErr
+		}
+	}
+
+	return err
 }
 
 // MakeList creates a list of devices from a list of construction arguments.
@@ -147,28 +333,59 @@ func MakeList(argsList []map[string]string) (devices []*SDRDevice, err error) {
 	cArgs, cLength := go2ArgsList(argsList)
 	defer argsListClear(cArgs, cLength)
 
-	dev := C.SoapySDRDevice_make_list(cArgs, cLength)
+	var status C.SoapySDRGoStatus
+	dev := C.SoapySDRGo_make_list(cArgs, cLength, &status)
 	if dev == nil {
-		return nil, errors.New(LastError())
+		return nil, createError(&status, "MakeList", "")
 	}
 	defer devicesClear(dev)
 
-	return devices2Go(dev, cLength), nil
+	devices = devices2Go(dev, cLength)
+	for i, device := range devices {
+		trackDevice(device, argsDescription(argsList[i]))
+	}
+
+	return devices, nil
 }
 
 // UnmakeList unmakes or releases a list of device handles.
 //
 // This is a convenience call to parallelize device destruction,
-// and is fundamentally a parallel for loop of unmake(Device *).
+// and is fundamentally a parallel for loop of unmake(Device *). As with Unmake(), the open streams of the devices are
+// closed first.
 //
 // Params:
 //  - devices: a list of pointer to sdr devices
 //
-// Return an error or nil in case of success
+// Return an error or nil in case of success, sdrerror.ErrClosed if one of the devices is already released
 func UnmakeList(devices []*SDRDevice) (err sdrerror.SDRError) {
 
+	for _, device := range devices {
+		if device.device == nil {
+			return rejectedError(sdrerror.ErrClosed, "UnmakeList", "")
+		}
+	}
+
+	var closeErr sdrerror.SDRError
+	for _, device := range devices {
+		if err := device.streams.closeAll(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+
 	cDevices, cLength := go2Devices(devices)
 	defer devicesClear(cDevices)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_unmake_list(cDevices, cLength)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_unmake_list(cDevices, cLength, &status)
+	for _, device := range devices {
+		device.device = nil
+		untrackDevice(device)
+	}
+
+	if err := callError(&status, "UnmakeList"); err != nil {
+		return err
+	}
+
+	return closeErr
 }
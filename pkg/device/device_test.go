@@ -0,0 +1,53 @@
+package device_test
+
+import (
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+)
+
+func TestClosedDevice(t *testing.T) {
+
+	// A device without C device behaves as an unmade device: no call may reach the driver
+	dev := &device.SDRDevice{}
+
+	calls := map[string]func() error{
+		"GetFrequencyChecked": func() error {
+			_, err := dev.GetFrequencyChecked(device.DirectionRX, 0)
+			return err
+		},
+		"SetGain": func() error {
+			return dev.SetGain(device.DirectionRX, 0, 10)
+		},
+		"GetNumChannelsChecked": func() error {
+			_, err := dev.GetNumChannelsChecked(device.DirectionTX)
+			return err
+		},
+		"GetDCOffset": func() error {
+			_, _, err := dev.GetDCOffset(device.DirectionRX, 0)
+			return err
+		},
+		"GetHardwareTimeChecked": func() error {
+			_, err := dev.GetHardwareTimeChecked("")
+			return err
+		},
+		"SetupSDRStreamCS16": func() error {
+			_, err := dev.SetupSDRStreamCS16(device.DirectionRX, []uint{0}, nil)
+			return err
+		},
+		"Unmake": func() error {
+			return dev.Unmake()
+		},
+	}
+
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, sdrerror.ErrClosed) {
+			t.Errorf("%v on a closed device returned %v, expected a closed error", name, err)
+		}
+	}
+
+	if dev.GetFrequency(device.DirectionRX, 0) != 0 || dev.ListGains(device.DirectionRX, 0) != nil {
+		t.Error("the getters of a closed device returned values")
+	}
+}
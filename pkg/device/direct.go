@@ -7,4 +7,155 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// getDirectAccessBufferAddrs gets the buffer addresses for a scatter/gather table entry.
+//
+// When the underlying DMA implementation uses scatter/gather then this call provides the user addresses for that table.
+//
+// Params:
+//  - stream: the opaque pointer to a stream handle
+//  - handle: an index value between 0 and num direct buffers - 1
+//
+// Return the address of the buffer of each channel or an error
+func getDirectAccessBufferAddrs(stream cStream, handle uint) (addrs []unsafe.Pointer, err error) {
+
+	addrs = make([]unsafe.Pointer, stream.getNbChannels())
+
+	var status C.SoapySDRGoStatus
+	result := int(
+		C.SoapySDRGo_getDirectAccessBufferAddrs(
+			stream.getDevice(),
+			stream.getStream(),
+			C.size_t(handle),
+			(*unsafe.Pointer)(unsafe.Pointer(&addrs[0])),
+			&status))
+	if result < 0 {
+		return nil, directionError(&status, "GetDirectAccessBufferAddrs", stream.getDirection())
+	}
+
+	return addrs, nil
+}
+
+// acquireReadBuffer acquires direct buffers from a receive stream.
+//
+// This call is part of the direct buffer access API. The buffers are filled by the driver and must be released by a
+// call to releaseReadBuffer once consumed.
+//
+// Params:
+//  - stream: the opaque pointer to a stream handle
+//  - outputFlags: the flag indicators of the result. The number of flags must match the number of channels of the
+//    stream.
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the handle of the buffer, the address of the buffer of each channel, the buffer's timestamp in nanoseconds,
+// the number of elements available per buffer and an error
+func acquireReadBuffer(stream cStream, outputFlags []int, timeoutUs uint) (handle uint, addrs []unsafe.Pointer, timeNs uint, numElemsRead uint, err error) {
+
+	if uint(len(outputFlags)) != stream.getNbChannels() {
+		return 0, nil, 0, 0, errors.New("the flags must have the same number of channels as the stream")
+	}
+
+	addrs = make([]unsafe.Pointer, stream.getNbChannels())
+
+	cHandle := C.size_t(0)
+	cFlags := C.int(0)
+	cTimeNs := C.longlong(0)
+
+	var status C.SoapySDRGoStatus
+	result := int(
+		C.SoapySDRGo_acquireReadBuffer(
+			stream.getDevice(),
+			stream.getStream(),
+			&cHandle,
+			(*unsafe.Pointer)(unsafe.Pointer(&addrs[0])),
+			&cFlags,
+			&cTimeNs,
+			C.long(timeoutUs),
+			&status))
+
+	outputFlags[0] = int(cFlags)
+
+	if result < 0 {
+		return 0, nil, uint(cTimeNs), 0, directionError(&status, "AcquireReadBuffer", stream.getDirection())
+	}
+
+	return uint(cHandle), addrs, uint(cTimeNs), uint(result), nil
+}
+
+// releaseReadBuffer releases an acquired buffer back to the receive stream.
+//
+// Params:
+//  - stream: the opaque pointer to a stream handle
+//  - handle: the opaque handle from the acquire() call
+func releaseReadBuffer(stream cStream, handle uint) {
+
+	C.SoapySDRDevice_releaseReadBuffer(stream.getDevice(), stream.getStream(), C.size_t(handle))
+}
+
+// acquireWriteBuffer acquires direct buffers from a transmit stream.
+//
+// This call is part of the direct buffer access API. The buffers must be filled by the caller and then handed back to
+// the driver with a call to releaseWriteBuffer.
+//
+// Params:
+//  - stream: the opaque pointer to a stream handle
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the handle of the buffer, the address of the buffer of each channel, the number of elements available for
+// writing per buffer and an error
+func acquireWriteBuffer(stream cStream, timeoutUs uint) (handle uint, addrs []unsafe.Pointer, numElems uint, err error) {
+
+	addrs = make([]unsafe.Pointer, stream.getNbChannels())
+
+	cHandle := C.size_t(0)
+
+	var status C.SoapySDRGoStatus
+	result := int(
+		C.SoapySDRGo_acquireWriteBuffer(
+			stream.getDevice(),
+			stream.getStream(),
+			&cHandle,
+			(*unsafe.Pointer)(unsafe.Pointer(&addrs[0])),
+			C.long(timeoutUs),
+			&status))
+	if result < 0 {
+		return 0, nil, 0, directionError(&status, "AcquireWriteBuffer", stream.getDirection())
+	}
+
+	return uint(cHandle), addrs, uint(result), nil
+}
+
+// releaseWriteBuffer releases an acquired buffer back to the transmit stream.
+//
+// Params:
+//  - stream: the opaque pointer to a stream handle
+//  - handle: the opaque handle from the acquire() call
+//  - numElems: the number of elements written to each buffer
+//  - flags: input flags, updated with the value of the output flags. The number of flags must match the number of
+//    channels of the stream.
+//  - timeNs: the buffer's timestamp in nanoseconds
+func releaseWriteBuffer(stream cStream, handle uint, numElems uint, flags []int, timeNs uint) {
+
+	cFlags := C.int(0)
+	if len(flags) > 0 {
+		cFlags = C.int(flags[0])
+	}
+
+	C.SoapySDRDevice_releaseWriteBuffer(
+		stream.getDevice(),
+		stream.getStream(),
+		C.size_t(handle),
+		C.size_t(numElems),
+		&cFlags,
+		C.longlong(timeNs))
+
+	if len(flags) > 0 {
+		flags[0] = int(cFlags)
+	}
+}
@@ -0,0 +1,8 @@
+package device
+
+// The internals of the leak detection exposed to the tests of the package
+var (
+	LeakPanic      = leakPanic
+	OpenTestHandle = openHandle
+	LeakedHandle   = leakedHandle
+)
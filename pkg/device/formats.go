@@ -0,0 +1,431 @@
+package device
+
+// #cgo CFLAGS: -g -Wall
+// #cgo LDFLAGS: -lSoapySDR
+// #include <stdlib.h>
+// #include <SoapySDR/Formats.h>
+import "C"
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Stream formats defined by SoapySDR.
+//
+// The first character selects the number type:
+//   - "C" means complex
+//   - "F" means floating point
+//   - "S" means signed integer
+//   - "U" means unsigned integer
+// The type character is followed by the number of bits per number (complex is 2x this size per sample)
+const (
+	// FormatCF64 is complex float64 (16 bytes per element)
+	FormatCF64 = "CF64"
+	// FormatCF32 is complex float32 (8 bytes per element)
+	FormatCF32 = "CF32"
+	// FormatCS32 is complex int32 (8 bytes per element)
+	FormatCS32 = "CS32"
+	// FormatCU32 is complex uint32 (8 bytes per element)
+	FormatCU32 = "CU32"
+	// FormatCS16 is complex int16 (4 bytes per element)
+	FormatCS16 = "CS16"
+	// FormatCU16 is complex uint16 (4 bytes per element)
+	FormatCU16 = "CU16"
+	// FormatCS12 is complex int12 (3 bytes per element)
+	FormatCS12 = "CS12"
+	// FormatCU12 is complex uint12 (3 bytes per element)
+	FormatCU12 = "CU12"
+	// FormatCS8 is complex int8 (2 bytes per element)
+	FormatCS8 = "CS8"
+	// FormatCU8 is complex uint8 (2 bytes per element)
+	FormatCU8 = "CU8"
+	// FormatCS4 is complex int4 (1 byte per element)
+	FormatCS4 = "CS4"
+	// FormatCU4 is complex uint4 (1 byte per element)
+	FormatCU4 = "CU4"
+	// FormatF64 is float64 (8 bytes per element)
+	FormatF64 = "F64"
+	// FormatF32 is float32 (4 bytes per element)
+	FormatF32 = "F32"
+	// FormatS32 is int32 (4 bytes per element)
+	FormatS32 = "S32"
+	// FormatU32 is uint32 (4 bytes per element)
+	FormatU32 = "U32"
+	// FormatS16 is int16 (2 bytes per element)
+	FormatS16 = "S16"
+	// FormatU16 is uint16 (2 bytes per element)
+	FormatU16 = "U16"
+	// FormatS8 is int8 (1 byte per element)
+	FormatS8 = "S8"
+	// FormatU8 is uint8 (1 byte per element)
+	FormatU8 = "U8"
+)
+
+// formatLayout describes how the samples of a format are stored in a Go slice
+type formatLayout struct {
+	// elemType is the name of the Go type of the elements of the slice
+	elemType string
+	// elemsPerSample is the number of elements used by a single sample
+	elemsPerSample uint
+	// complexType is the name of the complex integer type holding a whole sample, empty if there is none
+	complexType string
+	// bits is the number of bits of the integer components of the samples, 0 for the float formats
+	bits uint
+}
+
+// formatLayouts gives the layout of every format defined by SoapySDR. The packed formats (CS12, CU12, CS4, CU4) are
+// exposed as raw bytes, see UnpackCS12() and the other unpacking functions.
+var formatLayouts = map[string]formatLayout{
+	FormatCF64: {"complex128", 1, "", 0},
+	FormatCF32: {"complex64", 1, "", 0},
+	FormatCS32: {"int32", 2, "device.CS32", 32},
+	FormatCU32: {"uint32", 2, "device.CU32", 32},
+	FormatCS16: {"int16", 2, "device.CS16", 16},
+	FormatCU16: {"uint16", 2, "device.CU16", 16},
+	FormatCS12: {"uint8", 3, "", 12},
+	FormatCU12: {"uint8", 3, "", 12},
+	FormatCS8:  {"int8", 2, "device.CS8", 8},
+	FormatCU8:  {"uint8", 2, "device.CU8", 8},
+	FormatCS4:  {"uint8", 1, "", 4},
+	FormatCU4:  {"uint8", 1, "", 4},
+	FormatF64:  {"float64", 1, "", 0},
+	FormatF32:  {"float32", 1, "", 0},
+	FormatS32:  {"int32", 1, "", 32},
+	FormatU32:  {"uint32", 1, "", 32},
+	FormatS16:  {"int16", 1, "", 16},
+	FormatU16:  {"uint16", 1, "", 16},
+	FormatS8:   {"int8", 1, "", 8},
+	FormatU8:   {"uint8", 1, "", 8},
+}
+
+// FormatToSize gets the size of a single element in the specified format.
+//
+// Params:
+//  - format: a format string, such as "CF32"
+//
+// Return the size of an element in bytes
+func FormatToSize(format string) uint {
+
+	cFormat := C.CString(format)
+	defer C.free(unsafe.Pointer(cFormat))
+
+	return uint(C.SoapySDR_formatToSize(cFormat))
+}
+
+// FormatFullScale returns the full scale of the samples of an integer format when they use all the bits of their
+// components: 128 for CU8 and CS8, 2048 for CU12 and CS12, 32768 for CU16 and CS16... The unsigned formats are centered
+// on their full scale. Drivers using fewer bits, such as 12 bits samples stored in CS16, report their full scale with
+// GetNativeStreamFormat().
+//
+// Params:
+//  - format: a format string, such as "CS16"
+//
+// Return the full scale, 0 for the float formats and the unknown formats
+func FormatFullScale(format string) float64 {
+
+	layout, found := formatLayouts[format]
+	if !found || layout.bits == 0 {
+		return 0
+	}
+
+	return math.Ldexp(1, int(layout.bits)-1)
+}
+
+// elemTypeName returns the name of the Go type T, as used by formatLayouts, or an empty string if T is not a type of
+// stream elements
+func elemTypeName[T Sample]() string {
+
+	var elem T
+
+	switch any(elem).(type) {
+	case uint8:
+		return "uint8"
+	case int8:
+		return "int8"
+	case uint16:
+		return "uint16"
+	case int16:
+		return "int16"
+	case uint32:
+		return "uint32"
+	case int32:
+		return "int32"
+	case float32:
+		return "float32"
+	case float64:
+		return "float64"
+	case complex64:
+		return "complex64"
+	case complex128:
+		return "complex128"
+	case CU8:
+		return "device.CU8"
+	case CS8:
+		return "device.CS8"
+	case CU16:
+		return "device.CU16"
+	case CS16:
+		return "device.CS16"
+	case CU32:
+		return "device.CU32"
+	case CS32:
+		return "device.CS32"
+	default:
+		return ""
+	}
+}
+
+// StreamFormat returns the default SoapySDR format of the streams whose elements are of type T: the integer types
+// and the complex integer types map to the complex integer formats (int16 and CS16 to CS16...), float32 and float64
+// map to the real F32 and F64 formats and complex64 and complex128 map to CF32 and CF64. Other formats can be selected
+// with SetupStreamFormat().
+//
+// Return the format string, the number of elements of type T used by a single sample and an error if T is not a type
+// of stream elements
+func StreamFormat[T Sample]() (format string, elemsPerSample uint, err error) {
+
+	var elem T
+
+	switch any(elem).(type) {
+	case uint8:
+		format = FormatCU8
+	case int8:
+		format = FormatCS8
+	case uint16:
+		format = FormatCU16
+	case int16:
+		format = FormatCS16
+	case uint32:
+		format = FormatCU32
+	case int32:
+		format = FormatCS32
+	case float32:
+		format = FormatF32
+	case float64:
+		format = FormatF64
+	case complex64:
+		format = FormatCF32
+	case complex128:
+		format = FormatCF64
+	case CU8:
+		format = FormatCU8
+	case CS8:
+		format = FormatCS8
+	case CU16:
+		format = FormatCU16
+	case CS16:
+		format = FormatCS16
+	case CU32:
+		format = FormatCU32
+	case CS32:
+		format = FormatCS32
+	default:
+		return "", 0, fmt.Errorf("no stream format for elements of type %T", elem)
+	}
+
+	elemsPerSample, err = FormatElemsPerSample[T](format)
+
+	return format, elemsPerSample, err
+}
+
+// FormatElemsPerSample returns the number of elements of type T used by a single sample of the given format.
+//
+// Params:
+//  - format: a format string, such as "CF32"
+//
+// Return the number of elements per sample, or an error if the format is unknown or if its samples can not be stored
+// in elements of type T
+func FormatElemsPerSample[T Sample](format string) (elemsPerSample uint, err error) {
+
+	layout, found := formatLayouts[format]
+	if !found {
+		return 0, fmt.Errorf("unknown stream format %v", format)
+	}
+
+	elemType := elemTypeName[T]()
+	if elemType == "" {
+		var elem T
+		return 0, fmt.Errorf("the stream format %v can not be used with elements of type %T", format, elem)
+	}
+	if layout.complexType != "" && elemType == layout.complexType {
+		return 1, nil
+	}
+	if elemType != layout.elemType {
+		return 0, fmt.Errorf("the stream format %v can not be used with elements of type %v, %v is expected", format, elemType, layout.elemType)
+	}
+
+	return layout.elemsPerSample, nil
+}
+
+// UnpackCS12 unpacks samples in CS12 format to interleaved I and Q int16 values. Each sample uses 3 bytes: the 8 low
+// bits of I, the 4 high bits of I and the 4 low bits of Q, then the 8 high bits of Q.
+//
+// Params:
+//  - packed: the samples in CS12 format
+//  - unpacked: the unpacked samples, I and Q interleaved
+//
+// Return the number of samples unpacked, limited by the length of both slices
+func UnpackCS12(packed []uint8, unpacked []int16) int {
+
+	nbSamples := minInt(len(packed)/3, len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		b := packed[3*i : 3*i+3]
+		unpacked[2*i] = int16(uint16(b[1])<<12|uint16(b[0])<<4) >> 4
+		unpacked[2*i+1] = int16(uint16(b[2])<<8|uint16(b[1]&0xf0)) >> 4
+	}
+
+	return nbSamples
+}
+
+// PackCS12 packs interleaved I and Q int16 values to samples in CS12 format. Only the 12 low bits of each value are
+// kept. See UnpackCS12() for the layout.
+//
+// Params:
+//  - unpacked: the samples, I and Q interleaved
+//  - packed: the samples in CS12 format
+//
+// Return the number of samples packed, limited by the length of both slices
+func PackCS12(unpacked []int16, packed []uint8) int {
+
+	nbSamples := minInt(len(packed)/3, len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		sampleI, sampleQ := uint16(unpacked[2*i]), uint16(unpacked[2*i+1])
+		packed[3*i] = uint8(sampleI)
+		packed[3*i+1] = uint8(sampleI>>8)&0x0f | uint8(sampleQ<<4)
+		packed[3*i+2] = uint8(sampleQ >> 4)
+	}
+
+	return nbSamples
+}
+
+// UnpackCU12 unpacks samples in CU12 format to interleaved I and Q uint16 values. The layout is the same as CS12.
+//
+// Params:
+//  - packed: the samples in CU12 format
+//  - unpacked: the unpacked samples, I and Q interleaved
+//
+// Return the number of samples unpacked, limited by the length of both slices
+func UnpackCU12(packed []uint8, unpacked []uint16) int {
+
+	nbSamples := minInt(len(packed)/3, len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		b := packed[3*i : 3*i+3]
+		unpacked[2*i] = uint16(b[1]&0x0f)<<8 | uint16(b[0])
+		unpacked[2*i+1] = uint16(b[2])<<4 | uint16(b[1]>>4)
+	}
+
+	return nbSamples
+}
+
+// PackCU12 packs interleaved I and Q uint16 values to samples in CU12 format. Only the 12 low bits of each value are
+// kept. See UnpackCS12() for the layout.
+//
+// Params:
+//  - unpacked: the samples, I and Q interleaved
+//  - packed: the samples in CU12 format
+//
+// Return the number of samples packed, limited by the length of both slices
+func PackCU12(unpacked []uint16, packed []uint8) int {
+
+	nbSamples := minInt(len(packed)/3, len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		sampleI, sampleQ := unpacked[2*i], unpacked[2*i+1]
+		packed[3*i] = uint8(sampleI)
+		packed[3*i+1] = uint8(sampleI>>8)&0x0f | uint8(sampleQ<<4)
+		packed[3*i+2] = uint8(sampleQ >> 4)
+	}
+
+	return nbSamples
+}
+
+// UnpackCS4 unpacks samples in CS4 format to interleaved I and Q int8 values. Each sample uses 1 byte: I in the 4 low
+// bits, Q in the 4 high bits.
+//
+// Params:
+//  - packed: the samples in CS4 format
+//  - unpacked: the unpacked samples, I and Q interleaved
+//
+// Return the number of samples unpacked, limited by the length of both slices
+func UnpackCS4(packed []uint8, unpacked []int8) int {
+
+	nbSamples := minInt(len(packed), len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		unpacked[2*i] = int8(packed[i]<<4) >> 4
+		unpacked[2*i+1] = int8(packed[i]) >> 4
+	}
+
+	return nbSamples
+}
+
+// PackCS4 packs interleaved I and Q int8 values to samples in CS4 format. Only the 4 low bits of each value are kept.
+// See UnpackCS4() for the layout.
+//
+// Params:
+//  - unpacked: the samples, I and Q interleaved
+//  - packed: the samples in CS4 format
+//
+// Return the number of samples packed, limited by the length of both slices
+func PackCS4(unpacked []int8, packed []uint8) int {
+
+	nbSamples := minInt(len(packed), len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		packed[i] = uint8(unpacked[2*i])&0x0f | uint8(unpacked[2*i+1])<<4
+	}
+
+	return nbSamples
+}
+
+// UnpackCU4 unpacks samples in CU4 format to interleaved I and Q uint8 values. The layout is the same as CS4.
+//
+// Params:
+//  - packed: the samples in CU4 format
+//  - unpacked: the unpacked samples, I and Q interleaved
+//
+// Return the number of samples unpacked, limited by the length of both slices
+func UnpackCU4(packed []uint8, unpacked []uint8) int {
+
+	nbSamples := minInt(len(packed), len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		unpacked[2*i] = packed[i] & 0x0f
+		unpacked[2*i+1] = packed[i] >> 4
+	}
+
+	return nbSamples
+}
+
+// PackCU4 packs interleaved I and Q uint8 values to samples in CU4 format. Only the 4 low bits of each value are
+// kept. See UnpackCS4() for the layout.
+//
+// Params:
+//  - unpacked: the samples, I and Q interleaved
+//  - packed: the samples in CU4 format
+//
+// Return the number of samples packed, limited by the length of both slices
+func PackCU4(unpacked []uint8, packed []uint8) int {
+
+	nbSamples := minInt(len(packed), len(unpacked)/2)
+
+	for i := 0; i < nbSamples; i++ {
+		packed[i] = unpacked[2*i]&0x0f | unpacked[2*i+1]<<4
+	}
+
+	return nbSamples
+}
+
+// minInt returns the minimum of two integers
+func minInt(a int, b int) int {
+
+	if a < b {
+		return a
+	}
+
+	return b
+}
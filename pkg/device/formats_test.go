@@ -0,0 +1,190 @@
+package device
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// elemsPerSample returns the number of elements of type T per sample of a format and the size of the elements
+func elemsPerSample[T Sample](format string) (uint, uintptr, error) {
+
+	var elem T
+
+	elems, err := FormatElemsPerSample[T](format)
+
+	return elems, unsafe.Sizeof(elem), err
+}
+
+func TestFormatSizes(t *testing.T) {
+
+	tests := []struct {
+		format string
+		layout func(format string) (uint, uintptr, error)
+	}{
+		{FormatCF64, elemsPerSample[complex128]},
+		{FormatCF32, elemsPerSample[complex64]},
+		{FormatCS32, elemsPerSample[int32]},
+		{FormatCU32, elemsPerSample[uint32]},
+		{FormatCS16, elemsPerSample[int16]},
+		{FormatCU16, elemsPerSample[uint16]},
+		{FormatCS12, elemsPerSample[uint8]},
+		{FormatCU12, elemsPerSample[uint8]},
+		{FormatCS8, elemsPerSample[int8]},
+		{FormatCU8, elemsPerSample[uint8]},
+		{FormatCS4, elemsPerSample[uint8]},
+		{FormatCU4, elemsPerSample[uint8]},
+		{FormatF64, elemsPerSample[float64]},
+		{FormatF32, elemsPerSample[float32]},
+		{FormatS32, elemsPerSample[int32]},
+		{FormatU32, elemsPerSample[uint32]},
+		{FormatS16, elemsPerSample[int16]},
+		{FormatU16, elemsPerSample[uint16]},
+		{FormatS8, elemsPerSample[int8]},
+		{FormatU8, elemsPerSample[uint8]},
+	}
+
+	if len(tests) != len(formatLayouts) {
+		t.Fatalf("%v formats tested, %v formats defined", len(tests), len(formatLayouts))
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			elems, elemSize, err := test.layout(test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if size := uint(elemSize) * elems; size != FormatToSize(test.format) {
+				t.Errorf("a sample uses %v bytes, SoapySDR expects %v bytes", size, FormatToSize(test.format))
+			}
+		})
+	}
+}
+
+func TestFormatElemsPerSampleMismatch(t *testing.T) {
+
+	if _, err := FormatElemsPerSample[uint8](FormatCS16); err == nil {
+		t.Error("CS16 accepted with uint8 elements")
+	}
+
+	if _, err := FormatElemsPerSample[int16]("CS24"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestPackedFormats(t *testing.T) {
+
+	cs12 := []int16{-2048, 2047, 0, -1, 1234, -567}
+	packed := make([]uint8, 9)
+	unpacked := make([]int16, len(cs12))
+	if n := PackCS12(cs12, packed); n != 3 {
+		t.Fatalf("%v samples packed in CS12, 3 expected", n)
+	}
+	UnpackCS12(packed, unpacked)
+	for i := range cs12 {
+		if unpacked[i] != cs12[i] {
+			t.Errorf("CS12 value %v unpacked as %v", cs12[i], unpacked[i])
+		}
+	}
+
+	cu12 := []uint16{0, 4095, 1234, 2048}
+	packedCU12 := make([]uint8, 6)
+	unpackedCU12 := make([]uint16, len(cu12))
+	PackCU12(cu12, packedCU12)
+	UnpackCU12(packedCU12, unpackedCU12)
+	for i := range cu12 {
+		if unpackedCU12[i] != cu12[i] {
+			t.Errorf("CU12 value %v unpacked as %v", cu12[i], unpackedCU12[i])
+		}
+	}
+
+	cs4 := []int8{-8, 7, 0, -1}
+	packedCS4 := make([]uint8, 2)
+	unpackedCS4 := make([]int8, len(cs4))
+	PackCS4(cs4, packedCS4)
+	UnpackCS4(packedCS4, unpackedCS4)
+	for i := range cs4 {
+		if unpackedCS4[i] != cs4[i] {
+			t.Errorf("CS4 value %v unpacked as %v", cs4[i], unpackedCS4[i])
+		}
+	}
+
+	cu4 := []uint8{0, 15, 9, 6}
+	packedCU4 := make([]uint8, 2)
+	unpackedCU4 := make([]uint8, len(cu4))
+	PackCU4(cu4, packedCU4)
+	UnpackCU4(packedCU4, unpackedCU4)
+	for i := range cu4 {
+		if unpackedCU4[i] != cu4[i] {
+			t.Errorf("CU4 value %v unpacked as %v", cu4[i], unpackedCU4[i])
+		}
+	}
+}
+
+func TestComplexIntFormats(t *testing.T) {
+
+	tests := []struct {
+		format string
+		layout func(format string) (uint, uintptr, error)
+	}{
+		{FormatCS32, elemsPerSample[CS32]},
+		{FormatCU32, elemsPerSample[CU32]},
+		{FormatCS16, elemsPerSample[CS16]},
+		{FormatCU16, elemsPerSample[CU16]},
+		{FormatCS8, elemsPerSample[CS8]},
+		{FormatCU8, elemsPerSample[CU8]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			elems, elemSize, err := test.layout(test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if elems != 1 || uint(elemSize) != FormatToSize(test.format) {
+				t.Errorf("a sample uses %v elements of %v bytes, SoapySDR expects 1 element of %v bytes", elems, elemSize, FormatToSize(test.format))
+			}
+		})
+	}
+
+	if format, elems, err := StreamFormat[CS16](); err != nil || format != FormatCS16 || elems != 1 {
+		t.Errorf("CS16 elements map to %v with %v elements per sample", format, elems)
+	}
+
+	if _, err := FormatElemsPerSample[CS16](FormatCS8); err == nil {
+		t.Error("CS8 accepted with CS16 elements")
+	}
+}
+
+func TestComplexIntConversions(t *testing.T) {
+
+	cs16 := []CS16{{0, 0}, {2047, -2048}, {-1024, 512}}
+	cf32 := make([]complex64, len(cs16))
+	if n := ToComplex64(cf32, cs16, 2048); n != len(cs16) {
+		t.Fatalf("%v samples converted, %v expected", n, len(cs16))
+	}
+	if cf32[2] != complex(-0.5, 0.25) {
+		t.Errorf("CS16 %v converted to %v", cs16[2], cf32[2])
+	}
+
+	back := make([]CS16, len(cs16))
+	FromComplex64(back, cf32, 2048)
+	for i := range cs16 {
+		if back[i] != cs16[i] {
+			t.Errorf("CS16 %v converted back to %v", cs16[i], back[i])
+		}
+	}
+
+	cu8 := make([]CU8, 2)
+	FromComplex128(cu8, []complex128{complex(2, -2), complex(0, -0.5)}, DefaultFullScale[CU8]())
+	if cu8[0] != (CU8{255, 0}) || cu8[1] != (CU8{128, 64}) {
+		t.Errorf("CU8 conversion gave %v", cu8)
+	}
+
+	cs8 := make([]CS8, 2)
+	ConvertComplexInt(cs8, cu8, DefaultFullScale[CU8](), DefaultFullScale[CS8]())
+	if cs8[0] != (CS8{127, -128}) || cs8[1] != (CS8{0, -64}) {
+		t.Errorf("CU8 %v converted to CS8 %v", cu8, cs8)
+	}
+}
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -43,10 +44,17 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetFrequency(direction Direction, channel uint, frequency float64, args map[string]string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetFrequency", direction.String())
+	}
+
 	cArgs, cArgsLength := go2Args(args)
 	defer argsListClear(cArgs, cArgsLength)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setFrequency(dev.device, C.int(direction), C.size_t(channel), C.double(frequency), cArgs)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setFrequency(dev.device, C.int(direction), C.size_t(channel), C.double(frequency), cArgs, &status)
+
+	return channelError(&status, "SetFrequency", direction, channel)
 }
 
 // SetFrequencyComponent tunes the center frequency of the specified element.
@@ -68,13 +76,20 @@ func (dev *SDRDevice) SetFrequency(direction Direction, channel uint, frequency
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetFrequencyComponent(direction Direction, channel uint, name string, frequency float64, args map[string]string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetFrequencyComponent", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
 	cArgs, cArgsLength := go2Args(args)
 	defer argsListClear(cArgs, cArgsLength)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setFrequencyComponent(dev.device, C.int(direction), C.size_t(channel), cName, C.double(frequency), cArgs)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setFrequencyComponent(dev.device, C.int(direction), C.size_t(channel), cName, C.double(frequency), cArgs, &status)
+
+	return channelError(&status, "SetFrequencyComponent", direction, channel)
 }
 
 // GetFrequency gets the overall center frequency of the chain.
@@ -88,7 +103,25 @@ func (dev *SDRDevice) SetFrequencyComponent(direction Direction, channel uint, n
 // Return the center frequency in Hz
 func (dev *SDRDevice) GetFrequency(direction Direction, channel uint) float64 {
 
-	return float64(C.SoapySDRDevice_getFrequency(dev.device, C.int(direction), C.size_t(channel)))
+	frequency, _ := dev.GetFrequencyChecked(direction, channel)
+
+	return frequency
+}
+
+// GetFrequencyChecked gets the overall center frequency of the chain, returning the failure reported by the driver. See
+// GetFrequency() for the details.
+//
+// Return the center frequency in Hz and an error if the call failed
+func (dev *SDRDevice) GetFrequencyChecked(direction Direction, channel uint) (frequency float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetFrequency", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	frequency = float64(C.SoapySDRGo_getFrequency(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return frequency, channelError(&status, "GetFrequency", direction, channel)
 }
 
 // GetFrequencyComponent gets the frequency of a tunable element in the chain.
@@ -101,10 +134,28 @@ func (dev *SDRDevice) GetFrequency(direction Direction, channel uint) float64 {
 // Return the tunable element's frequency in Hz
 func (dev *SDRDevice) GetFrequencyComponent(direction Direction, channel uint, name string) float64 {
 
+	frequency, _ := dev.GetFrequencyComponentChecked(direction, channel, name)
+
+	return frequency
+}
+
+// GetFrequencyComponentChecked gets the frequency of a tunable element in the chain, returning the failure reported by
+// the driver. See GetFrequencyComponent() for the details.
+//
+// Return the tunable element's frequency in Hz and an error if the call failed
+func (dev *SDRDevice) GetFrequencyComponentChecked(direction Direction, channel uint, name string) (frequency float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetFrequencyComponent", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	return float64(C.SoapySDRDevice_getFrequencyComponent(dev.device, C.int(direction), C.size_t(channel), cName))
+	var status C.SoapySDRGoStatus
+	frequency = float64(C.SoapySDRGo_getFrequencyComponent(dev.device, C.int(direction), C.size_t(channel), cName, &status))
+
+	return frequency, channelError(&status, "GetFrequencyComponent", direction, channel)
 }
 
 // ListFrequencies lists available tunable elements in the chain.
@@ -118,12 +169,28 @@ func (dev *SDRDevice) GetFrequencyComponent(direction Direction, channel uint, n
 // Return a list of tunable elements by name
 func (dev *SDRDevice) ListFrequencies(direction Direction, channel uint) []string {
 
+	names, _ := dev.ListFrequenciesChecked(direction, channel)
+
+	return names
+}
+
+// ListFrequenciesChecked lists available tunable elements in the chain, returning the failure reported by the driver.
+// See ListFrequencies() for the details.
+//
+// Return a list of tunable elements by name and an error if the call failed
+func (dev *SDRDevice) ListFrequenciesChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListFrequencies", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listFrequencies(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listFrequencies(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), channelError(&status, "ListFrequencies", direction, channel)
 }
 
 // GetFrequencyRange gets the range of overall frequency values.
@@ -135,12 +202,28 @@ func (dev *SDRDevice) ListFrequencies(direction Direction, channel uint) []strin
 // Return a list of frequency ranges in Hz
 func (dev *SDRDevice) GetFrequencyRange(direction Direction, channel uint) []SDRRange {
 
+	ranges, _ := dev.GetFrequencyRangeChecked(direction, channel)
+
+	return ranges
+}
+
+// GetFrequencyRangeChecked gets the range of overall frequency values, returning the failure reported by the driver.
+// See GetFrequencyRange() for the details.
+//
+// Return a list of frequency ranges in Hz and an error if the call failed
+func (dev *SDRDevice) GetFrequencyRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetFrequencyRange", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getFrequencyRange(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getFrequencyRange(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer rangeArrayClear(info)
 
-	return rangeArray2Go(info, length)
+	return rangeArray2Go(info, length), channelError(&status, "GetFrequencyRange", direction, channel)
 }
 
 // GetFrequencyRangeComponent gets the range of tunable values for the specified element.
@@ -153,15 +236,31 @@ func (dev *SDRDevice) GetFrequencyRange(direction Direction, channel uint) []SDR
 // Return a list of frequency ranges in Hz
 func (dev *SDRDevice) GetFrequencyRangeComponent(direction Direction, channel uint, name string) []SDRRange {
 
+	ranges, _ := dev.GetFrequencyRangeComponentChecked(direction, channel, name)
+
+	return ranges
+}
+
+// GetFrequencyRangeComponentChecked gets the range of tunable values for the specified element, returning the failure
+// reported by the driver. See GetFrequencyRangeComponent() for the details.
+//
+// Return a list of frequency ranges in Hz and an error if the call failed
+func (dev *SDRDevice) GetFrequencyRangeComponentChecked(direction Direction, channel uint, name string) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetFrequencyRangeComponent", direction.String())
+	}
+
 	length := C.size_t(0)
 
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	info := C.SoapySDRDevice_getFrequencyRangeComponent(dev.device, C.int(direction), C.size_t(channel), cName, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getFrequencyRangeComponent(dev.device, C.int(direction), C.size_t(channel), cName, &length, &status)
 	defer rangeArrayClear(info)
 
-	return rangeArray2Go(info, length)
+	return rangeArray2Go(info, length), channelError(&status, "GetFrequencyRangeComponent", direction, channel)
 }
 
 // GetFrequencyArgsInfo queries the argument info description for tune args.
@@ -173,10 +272,26 @@ func (dev *SDRDevice) GetFrequencyRangeComponent(direction Direction, channel ui
 // Return a list of argument info structures
 func (dev *SDRDevice) GetFrequencyArgsInfo(direction Direction, channel uint) []SDRArgInfo {
 
+	infos, _ := dev.GetFrequencyArgsInfoChecked(direction, channel)
+
+	return infos
+}
+
+// GetFrequencyArgsInfoChecked queries the argument info description for tune args, returning the failure reported by
+// the driver. See GetFrequencyArgsInfo() for the details.
+//
+// Return a list of argument info structures and an error if the call failed
+func (dev *SDRDevice) GetFrequencyArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetFrequencyArgsInfo", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getFrequencyArgsInfo(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getFrequencyArgsInfo(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer argInfoListClear(info, length)
 
-	return argInfoList2Go(info, length)
+	return argInfoList2Go(info, length), channelError(&status, "GetFrequencyArgsInfo", direction, channel)
 }
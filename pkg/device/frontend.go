@@ -7,8 +7,11 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
-import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
 
 // HasDCOffsetMode returns if the device support automatic DC offset corrections
 //
@@ -19,7 +22,25 @@ import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
 // Return true if the device has automatic DC offset corrections, false otherwise
 func (dev *SDRDevice) HasDCOffsetMode(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasDCOffsetMode(dev.device, C.int(direction), C.size_t(channel)))
+	supported, _ := dev.HasDCOffsetModeChecked(direction, channel)
+
+	return supported
+}
+
+// HasDCOffsetModeChecked returns if the device support automatic DC offset corrections, returning the failure reported
+// by the driver. See HasDCOffsetMode() for the details.
+//
+// Return true if the device has automatic DC offset corrections, false otherwise, and an error if the call failed
+func (dev *SDRDevice) HasDCOffsetModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasDCOffsetMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasDCOffsetMode(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return supported, channelError(&status, "HasDCOffsetMode", direction, channel)
 }
 
 // SetDCOffsetMode sets the automatic DC offset corrections mode.
@@ -32,7 +53,14 @@ func (dev *SDRDevice) HasDCOffsetMode(direction Direction, channel uint) bool {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetDCOffsetMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setDCOffsetMode(dev.device, C.int(direction), C.size_t(channel), C.bool(automatic))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetDCOffsetMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setDCOffsetMode(dev.device, C.int(direction), C.size_t(channel), C.bool(automatic), &status)
+
+	return channelError(&status, "SetDCOffsetMode", direction, channel)
 }
 
 // GetDCOffsetMode gets the automatic DC offset corrections mode.
@@ -44,7 +72,25 @@ func (dev *SDRDevice) SetDCOffsetMode(direction Direction, channel uint, automat
 // Return true for automatic offset correction
 func (dev *SDRDevice) GetDCOffsetMode(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasDCOffsetMode(dev.device, C.int(direction), C.size_t(channel)))
+	automatic, _ := dev.GetDCOffsetModeChecked(direction, channel)
+
+	return automatic
+}
+
+// GetDCOffsetModeChecked gets the automatic DC offset corrections mode, returning the failure reported by the driver.
+// See GetDCOffsetMode() for the details.
+//
+// Return true for automatic offset correction and an error if the call failed
+func (dev *SDRDevice) GetDCOffsetModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "GetDCOffsetMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	automatic = bool(C.SoapySDRGo_getDCOffsetMode(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return automatic, channelError(&status, "GetDCOffsetMode", direction, channel)
 }
 
 // HasDCOffset returns if the device support frontend DC offset correction
@@ -56,10 +102,28 @@ func (dev *SDRDevice) GetDCOffsetMode(direction Direction, channel uint) bool {
 // Return true if the device supports frontend DC offset correction, false otherwise
 func (dev *SDRDevice) HasDCOffset(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasDCOffset(dev.device, C.int(direction), C.size_t(channel)))
+	supported, _ := dev.HasDCOffsetChecked(direction, channel)
+
+	return supported
+}
+
+// HasDCOffsetChecked returns if the device support frontend DC offset correction, returning the failure reported by the
+// driver. See HasDCOffset() for the details.
+//
+// Return true if the device supports frontend DC offset correction, false otherwise, and an error if the call failed
+func (dev *SDRDevice) HasDCOffsetChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasDCOffset", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasDCOffset(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return supported, channelError(&status, "HasDCOffset", direction, channel)
 }
 
-// SetDCOffset the frontend DC offset correction.
+// SetDCOffset sets the frontend DC offset correction.
 //
 // Params:
 //  - direction: the channel direction RX or TX
@@ -70,7 +134,14 @@ func (dev *SDRDevice) HasDCOffset(direction Direction, channel uint) bool {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetDCOffset(direction Direction, channel uint, offsetI float64, offsetQ float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setDCOffset(dev.device, C.int(direction), C.size_t(channel), C.double(offsetI), C.double(offsetQ))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetDCOffset", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setDCOffset(dev.device, C.int(direction), C.size_t(channel), C.double(offsetI), C.double(offsetQ), &status)
+
+	return channelError(&status, "SetDCOffset", direction, channel)
 }
 
 // GetDCOffset gets frontend DC offset correction.
@@ -82,13 +153,18 @@ func (dev *SDRDevice) SetDCOffset(direction Direction, channel uint, offsetI flo
 // Return offsetI and offsetQ the relative correction (1.0 max) and an optional error
 func (dev *SDRDevice) GetDCOffset(direction Direction, channel uint) (offsetI float64, offsetQ float64, err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return 0, 0, rejectedError(sdrerror.ErrClosed, "GetDCOffset", direction.String())
+	}
+
 	cOffsetI := C.double(0)
 	cOffsetQ := C.double(0)
 
-	result := int(C.SoapySDRDevice_getDCOffset(dev.device, C.int(direction), C.size_t(channel), &cOffsetI, &cOffsetQ))
+	var status C.SoapySDRGoStatus
+	result := int(C.SoapySDRGo_getDCOffset(dev.device, C.int(direction), C.size_t(channel), &cOffsetI, &cOffsetQ, &status))
 
 	if result < 0 {
-		return 0.0, 0.0, sdrerror.Err(result)
+		return 0.0, 0.0, channelError(&status, "GetDCOffset", direction, channel)
 	}
 
 	return float64(cOffsetI), float64(cOffsetQ), nil
@@ -103,7 +179,25 @@ func (dev *SDRDevice) GetDCOffset(direction Direction, channel uint) (offsetI fl
 // Return true if the device supports frontend IQ balance correction, false otherwise
 func (dev *SDRDevice) HasIQBalance(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasIQBalance(dev.device, C.int(direction), C.size_t(channel)))
+	supported, _ := dev.HasIQBalanceChecked(direction, channel)
+
+	return supported
+}
+
+// HasIQBalanceChecked returns if the device support frontend IQ balance correction, returning the failure reported by
+// the driver. See HasIQBalance() for the details.
+//
+// Return true if the device supports frontend IQ balance correction, false otherwise, and an error if the call failed
+func (dev *SDRDevice) HasIQBalanceChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasIQBalance", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasIQBalance(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return supported, channelError(&status, "HasIQBalance", direction, channel)
 }
 
 // SetIQBalance sets the frontend IQ balance correction.
@@ -117,7 +211,14 @@ func (dev *SDRDevice) HasIQBalance(direction Direction, channel uint) bool {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetIQBalance(direction Direction, channel uint, balanceI float64, balanceQ float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setIQBalance(dev.device, C.int(direction), C.size_t(channel), C.double(balanceI), C.double(balanceQ))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetIQBalance", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setIQBalance(dev.device, C.int(direction), C.size_t(channel), C.double(balanceI), C.double(balanceQ), &status)
+
+	return channelError(&status, "SetIQBalance", direction, channel)
 }
 
 // GetIQBalance gets the IQ balance correction.
@@ -129,13 +230,18 @@ func (dev *SDRDevice) SetIQBalance(direction Direction, channel uint, balanceI f
 // Return balanceI and balanceQ the relative correction (1.0 max) and an optional error
 func (dev *SDRDevice) GetIQBalance(direction Direction, channel uint) (balanceI float64, balanceQ float64, err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return 0, 0, rejectedError(sdrerror.ErrClosed, "GetIQBalance", direction.String())
+	}
+
 	cBalanceI := C.double(0)
 	cBalanceQ := C.double(0)
 
-	result := int(C.SoapySDRDevice_getIQBalance(dev.device, C.int(direction), C.size_t(channel), &cBalanceI, &cBalanceQ))
+	var status C.SoapySDRGoStatus
+	result := int(C.SoapySDRGo_getIQBalance(dev.device, C.int(direction), C.size_t(channel), &cBalanceI, &cBalanceQ, &status))
 
 	if result < 0 {
-		return 0.0, 0.0, sdrerror.Err(result)
+		return 0.0, 0.0, channelError(&status, "GetIQBalance", direction, channel)
 	}
 
 	return float64(cBalanceI), float64(cBalanceQ), nil
@@ -150,7 +256,25 @@ func (dev *SDRDevice) GetIQBalance(direction Direction, channel uint) (balanceI
 // Return true if the device supports frontend frequency correction, false otherwise
 func (dev *SDRDevice) HasFrequencyCorrection(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel)))
+	supported, _ := dev.HasFrequencyCorrectionChecked(direction, channel)
+
+	return supported
+}
+
+// HasFrequencyCorrectionChecked returns if the device support frontend frequency correction, returning the failure
+// reported by the driver. See HasFrequencyCorrection() for the details.
+//
+// Return true if the device supports frontend frequency correction, false otherwise, and an error if the call failed
+func (dev *SDRDevice) HasFrequencyCorrectionChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasFrequencyCorrection", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return supported, channelError(&status, "HasFrequencyCorrection", direction, channel)
 }
 
 // SetFrequencyCorrection fine tunes the frontend frequency correction.
@@ -163,7 +287,14 @@ func (dev *SDRDevice) HasFrequencyCorrection(direction Direction, channel uint)
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetFrequencyCorrection(direction Direction, channel uint, value float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel), C.double(value))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetFrequencyCorrection", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel), C.double(value), &status)
+
+	return channelError(&status, "SetFrequencyCorrection", direction, channel)
 }
 
 // GetFrequencyCorrection gets the frontend frequency correction value.
@@ -172,8 +303,26 @@ func (dev *SDRDevice) SetFrequencyCorrection(direction Direction, channel uint,
 //  - direction: the channel direction RX or TX
 //  - channel: an available channel
 //
-// Return the correction value in PPM and an optional error
+// Return the correction value in PPM
 func (dev *SDRDevice) GetFrequencyCorrection(direction Direction, channel uint) (value float64) {
 
-	return float64(C.SoapySDRDevice_getFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel)))
+	value, _ = dev.GetFrequencyCorrectionChecked(direction, channel)
+
+	return value
+}
+
+// GetFrequencyCorrectionChecked gets the frontend frequency correction value, returning the failure reported by the
+// driver. See GetFrequencyCorrection() for the details.
+//
+// Return the correction value in PPM and an error if the call failed
+func (dev *SDRDevice) GetFrequencyCorrectionChecked(direction Direction, channel uint) (value float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetFrequencyCorrection", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	value = float64(C.SoapySDRGo_getFrequencyCorrection(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return value, channelError(&status, "GetFrequencyCorrection", direction, channel)
 }
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -24,12 +25,28 @@ import (
 // Return a list of gain string names
 func (dev *SDRDevice) ListGains(direction Direction, channel uint) []string {
 
+	names, _ := dev.ListGainsChecked(direction, channel)
+
+	return names
+}
+
+// ListGainsChecked lists available amplification elements, returning the failure reported by the driver. See
+// ListGains() for the details.
+//
+// Return a list of gain string names and an error if the call failed
+func (dev *SDRDevice) ListGainsChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListGains", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listGains(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listGains(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), channelError(&status, "ListGains", direction, channel)
 }
 
 // HasGainMode returns if the device support automatic gain control
@@ -41,7 +58,25 @@ func (dev *SDRDevice) ListGains(direction Direction, channel uint) []string {
 // Return true for automatic gain control
 func (dev *SDRDevice) HasGainMode(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_hasGainMode(dev.device, C.int(direction), C.size_t(channel)))
+	supported, _ := dev.HasGainModeChecked(direction, channel)
+
+	return supported
+}
+
+// HasGainModeChecked returns if the device support automatic gain control, returning the failure reported by the
+// driver. See HasGainMode() for the details.
+//
+// Return true for automatic gain control and an error if the call failed
+func (dev *SDRDevice) HasGainModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasGainMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasGainMode(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return supported, channelError(&status, "HasGainMode", direction, channel)
 }
 
 // SetGainMode sets the automatic gain mode on the chain.
@@ -54,7 +89,14 @@ func (dev *SDRDevice) HasGainMode(direction Direction, channel uint) bool {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetGainMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setGainMode(dev.device, C.int(direction), C.size_t(channel), C.bool(automatic))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetGainMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setGainMode(dev.device, C.int(direction), C.size_t(channel), C.bool(automatic), &status)
+
+	return channelError(&status, "SetGainMode", direction, channel)
 }
 
 // GetGainMode gets the automatic gain mode on the chain.
@@ -66,7 +108,25 @@ func (dev *SDRDevice) SetGainMode(direction Direction, channel uint, automatic b
 // Return true for automatic gain setting
 func (dev *SDRDevice) GetGainMode(direction Direction, channel uint) bool {
 
-	return bool(C.SoapySDRDevice_getGainMode(dev.device, C.int(direction), C.size_t(channel)))
+	automatic, _ := dev.GetGainModeChecked(direction, channel)
+
+	return automatic
+}
+
+// GetGainModeChecked gets the automatic gain mode on the chain, returning the failure reported by the driver. See
+// GetGainMode() for the details.
+//
+// Return true for automatic gain setting and an error if the call failed
+func (dev *SDRDevice) GetGainModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "GetGainMode", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	automatic = bool(C.SoapySDRGo_getGainMode(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return automatic, channelError(&status, "GetGainMode", direction, channel)
 }
 
 // SetGain sets the overall amplification in a chain.
@@ -81,7 +141,14 @@ func (dev *SDRDevice) GetGainMode(direction Direction, channel uint) bool {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetGain(direction Direction, channel uint, gain float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setGain(dev.device, C.int(direction), C.size_t(channel), C.double(gain))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetGain", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setGain(dev.device, C.int(direction), C.size_t(channel), C.double(gain), &status)
+
+	return channelError(&status, "SetGain", direction, channel)
 }
 
 // SetGainElement sets the value of a amplification element in a chain.
@@ -95,10 +162,17 @@ func (dev *SDRDevice) SetGain(direction Direction, channel uint, gain float64) (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetGainElement(direction Direction, channel uint, name string, gain float64) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetGainElement", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setGainElement(dev.device, C.int(direction), C.size_t(channel), cName, C.double(gain))))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setGainElement(dev.device, C.int(direction), C.size_t(channel), cName, C.double(gain), &status)
+
+	return channelError(&status, "SetGainElement", direction, channel)
 }
 
 // GetGain gets the overall value of the gain elements in a chain.
@@ -110,7 +184,25 @@ func (dev *SDRDevice) SetGainElement(direction Direction, channel uint, name str
 // Return the value of the gain in dB
 func (dev *SDRDevice) GetGain(direction Direction, channel uint) float64 {
 
-	return float64(C.SoapySDRDevice_getGain(dev.device, C.int(direction), C.size_t(channel)))
+	gain, _ := dev.GetGainChecked(direction, channel)
+
+	return gain
+}
+
+// GetGainChecked gets the overall value of the gain elements in a chain, returning the failure reported by the driver.
+// See GetGain() for the details.
+//
+// Return the value of the gain in dB and an error if the call failed
+func (dev *SDRDevice) GetGainChecked(direction Direction, channel uint) (gain float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetGain", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	gain = float64(C.SoapySDRGo_getGain(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return gain, channelError(&status, "GetGain", direction, channel)
 }
 
 // GetGainElement gets the value of an individual amplification element in a chain.
@@ -123,10 +215,28 @@ func (dev *SDRDevice) GetGain(direction Direction, channel uint) float64 {
 // Return the value of the gain in dB
 func (dev *SDRDevice) GetGainElement(direction Direction, channel uint, name string) float64 {
 
+	gain, _ := dev.GetGainElementChecked(direction, channel, name)
+
+	return gain
+}
+
+// GetGainElementChecked gets the value of an individual amplification element in a chain, returning the failure
+// reported by the driver. See GetGainElement() for the details.
+//
+// Return the value of the gain in dB and an error if the call failed
+func (dev *SDRDevice) GetGainElementChecked(direction Direction, channel uint, name string) (gain float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetGainElement", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	return float64(C.SoapySDRDevice_getGainElement(dev.device, C.int(direction), C.size_t(channel), cName))
+	var status C.SoapySDRGoStatus
+	gain = float64(C.SoapySDRGo_getGainElement(dev.device, C.int(direction), C.size_t(channel), cName, &status))
+
+	return gain, channelError(&status, "GetGainElement", direction, channel)
 }
 
 // GetGainRange gets the overall range of possible gain values.
@@ -138,13 +248,29 @@ func (dev *SDRDevice) GetGainElement(direction Direction, channel uint, name str
 // Return a list of gain ranges in dB
 func (dev *SDRDevice) GetGainRange(direction Direction, channel uint) SDRRange {
 
-	cRange := C.SoapySDRDevice_getGainRange(dev.device, C.int(direction), C.size_t(channel))
+	gainRange, _ := dev.GetGainRangeChecked(direction, channel)
+
+	return gainRange
+}
+
+// GetGainRangeChecked gets the overall range of possible gain values, returning the failure reported by the driver. See
+// GetGainRange() for the details.
+//
+// Return a list of gain ranges in dB and an error if the call failed
+func (dev *SDRDevice) GetGainRangeChecked(direction Direction, channel uint) (gainRange SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return SDRRange{}, rejectedError(sdrerror.ErrClosed, "GetGainRange", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	cRange := C.SoapySDRGo_getGainRange(dev.device, C.int(direction), C.size_t(channel), &status)
 
 	return SDRRange{
 		Minimum: float64(cRange.minimum),
 		Maximum: float64(cRange.maximum),
 		Step:    float64(cRange.step),
-	}
+	}, channelError(&status, "GetGainRange", direction, channel)
 }
 
 // GetGainElementRange gets the range of possible gain values for a specific element.
@@ -157,14 +283,30 @@ func (dev *SDRDevice) GetGainRange(direction Direction, channel uint) SDRRange {
 // Return a list of gain ranges in dB
 func (dev *SDRDevice) GetGainElementRange(direction Direction, channel uint, name string) SDRRange {
 
+	gainRange, _ := dev.GetGainElementRangeChecked(direction, channel, name)
+
+	return gainRange
+}
+
+// GetGainElementRangeChecked gets the range of possible gain values for a specific element, returning the failure
+// reported by the driver. See GetGainElementRange() for the details.
+//
+// Return a list of gain ranges in dB and an error if the call failed
+func (dev *SDRDevice) GetGainElementRangeChecked(direction Direction, channel uint, name string) (gainRange SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return SDRRange{}, rejectedError(sdrerror.ErrClosed, "GetGainElementRange", direction.String())
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	cRange := C.SoapySDRDevice_getGainElementRange(dev.device, C.int(direction), C.size_t(channel), cName)
+	var status C.SoapySDRGoStatus
+	cRange := C.SoapySDRGo_getGainElementRange(dev.device, C.int(direction), C.size_t(channel), cName, &status)
 
 	return SDRRange{
 		Minimum: float64(cRange.minimum),
 		Maximum: float64(cRange.maximum),
 		Step:    float64(cRange.step),
-	}
+	}, channelError(&status, "GetGainElementRange", direction, channel)
 }
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -18,12 +19,28 @@ import (
 // Return a list of available GPIO banks
 func (dev *SDRDevice) ListGPIOBanks() []string {
 
+	banks, _ := dev.ListGPIOBanksChecked()
+
+	return banks
+}
+
+// ListGPIOBanksChecked a list of available GPIO banks by name, returning the failure reported by the driver. See
+// ListGPIOBanks() for the details.
+//
+// Return a list of available GPIO banks and an error if the call failed
+func (dev *SDRDevice) ListGPIOBanksChecked() (banks []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListGPIOBanks", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listGPIOBanks(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listGPIOBanks(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListGPIOBanks")
 }
 
 // WriteGPIO writes the value of a GPIO bank.
@@ -35,12 +52,19 @@ func (dev *SDRDevice) ListGPIOBanks() []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteGPIO(bank string, value uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteGPIO", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
 	cValue := C.uint(value)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeGPIO(dev.device, cBank, cValue)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeGPIO(dev.device, cBank, cValue, &status)
+
+	return callError(&status, "WriteGPIO")
 }
 
 // WriteGPIOMasked writes the value of a GPIO bank with modification mask.
@@ -53,13 +77,20 @@ func (dev *SDRDevice) WriteGPIO(bank string, value uint32) (err sdrerror.SDRErro
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteGPIOMasked(bank string, value uint32, mask uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteGPIOMasked", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
 	cValue := C.uint(value)
 	cMask := C.uint(mask)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeGPIOMasked(dev.device, cBank, cValue, cMask)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeGPIOMasked(dev.device, cBank, cValue, cMask, &status)
+
+	return callError(&status, "WriteGPIOMasked")
 }
 
 // ReadGPIO reads the value of a GPIO bank.
@@ -70,10 +101,28 @@ func (dev *SDRDevice) WriteGPIOMasked(bank string, value uint32, mask uint32) (e
 // Return an integer representing GPIO bits
 func (dev *SDRDevice) ReadGPIO(bank string) uint32 {
 
+	value, _ := dev.ReadGPIOChecked(bank)
+
+	return value
+}
+
+// ReadGPIOChecked reads the value of a GPIO bank, returning the failure reported by the driver. See ReadGPIO() for the
+// details.
+//
+// Return an integer representing GPIO bits and an error if the call failed
+func (dev *SDRDevice) ReadGPIOChecked(bank string) (value uint32, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "ReadGPIO", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
-	return uint32(C.SoapySDRDevice_readGPIO(dev.device, cBank))
+	var status C.SoapySDRGoStatus
+	value = uint32(C.SoapySDRGo_readGPIO(dev.device, cBank, &status))
+
+	return value, callError(&status, "ReadGPIO")
 }
 
 // WriteGPIODir writes the data direction of a GPIO bank. 1 bits represent outputs, 0 bits represent inputs.
@@ -85,12 +134,19 @@ func (dev *SDRDevice) ReadGPIO(bank string) uint32 {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteGPIODir(bank string, dir uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteGPIODir", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
 	cDir := C.uint(dir)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeGPIODir(dev.device, cBank, cDir)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeGPIODir(dev.device, cBank, cDir, &status)
+
+	return callError(&status, "WriteGPIODir")
 }
 
 // WriteGPIODirMasked writes the data direction of a GPIO bank with modification mask.  1 bits represent outputs,
@@ -104,13 +160,20 @@ func (dev *SDRDevice) WriteGPIODir(bank string, dir uint32) (err sdrerror.SDRErr
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteGPIODirMasked(bank string, dir uint32, mask uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteGPIODirMasked", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
 	cDir := C.uint(dir)
 	cMask := C.uint(mask)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeGPIODirMasked(dev.device, cBank, cDir, cMask)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeGPIODirMasked(dev.device, cBank, cDir, cMask, &status)
+
+	return callError(&status, "WriteGPIODirMasked")
 }
 
 // ReadGPIODir read the data direction of a GPIO bank. 1 bits represent outputs, 0 bits represent inputs.
@@ -121,8 +184,26 @@ func (dev *SDRDevice) WriteGPIODirMasked(bank string, dir uint32, mask uint32) (
 // Return an integer representing data direction bits
 func (dev *SDRDevice) ReadGPIODir(bank string) uint32 {
 
+	dir, _ := dev.ReadGPIODirChecked(bank)
+
+	return dir
+}
+
+// ReadGPIODirChecked read the data direction of a GPIO bank, returning the failure reported by the driver. See
+// ReadGPIODir() for the details.
+//
+// Return an integer representing data direction bits and an error if the call failed
+func (dev *SDRDevice) ReadGPIODirChecked(bank string) (dir uint32, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "ReadGPIODir", "")
+	}
+
 	cBank := C.CString(bank)
 	defer C.free(unsafe.Pointer(cBank))
 
-	return uint32(C.SoapySDRDevice_readGPIODir(dev.device, cBank))
+	var status C.SoapySDRGoStatus
+	dir = uint32(C.SoapySDRGo_readGPIODir(dev.device, cBank, &status))
+
+	return dir, callError(&status, "ReadGPIODir")
 }
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -24,11 +25,18 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteI2C(addr int32, data []uint8) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteI2C", "")
+	}
+
 	cAddr := C.int(addr)
 	cData := (*C.char)(unsafe.Pointer(&data[0]))
 	cNumBytes := C.size_t(len(data))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeI2C(dev.device, cAddr, cData, cNumBytes)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeI2C(dev.device, cAddr, cData, cNumBytes, &status)
+
+	return callError(&status, "WriteI2C")
 }
 
 // ReadI2C reads from an available I2C slave.
@@ -42,10 +50,26 @@ func (dev *SDRDevice) WriteI2C(addr int32, data []uint8) (err sdrerror.SDRError)
 // Return the bytes actually read.
 func (dev *SDRDevice) ReadI2C(addr int32, numBytes uint) (data []uint8) {
 
+	data, _ = dev.ReadI2CChecked(addr, numBytes)
+
+	return data
+}
+
+// ReadI2CChecked reads from an available I2C slave, returning the failure reported by the driver. See ReadI2C() for the
+// details.
+//
+// Return the bytes actually read and an error if the call failed
+func (dev *SDRDevice) ReadI2CChecked(addr int32, numBytes uint) (data []uint8, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ReadI2C", "")
+	}
+
 	cAddr := C.int(addr)
-	cNumBytes := C.size_t(len(data))
+	cNumBytes := C.size_t(numBytes)
 
-	cData := C.SoapySDRDevice_readI2C(dev.device, cAddr, &cNumBytes)
+	var status C.SoapySDRGoStatus
+	cData := C.SoapySDRGo_readI2C(dev.device, cAddr, &cNumBytes, &status)
 	defer C.free(unsafe.Pointer(cData))
 
 	data = make([]uint8, int(cNumBytes))
@@ -60,5 +84,5 @@ func (dev *SDRDevice) ReadI2C(addr int32, numBytes uint) (data []uint8) {
 		data[i] = val
 	}
 
-	return data
+	return data, callError(&status, "ReadI2C")
 }
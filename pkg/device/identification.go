@@ -6,18 +6,38 @@ package device
 // #include <stddef.h>
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
-import "unsafe"
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"unsafe"
+)
 
 // GetDriverKey returns a key that uniquely identifies the device driver.
 //
 // This key identifies the underlying implementation. Several variants of a product may share a driver.
 func (dev *SDRDevice) GetDriverKey() (driverKey string) {
 
-	val := (*C.char)(C.SoapySDRDevice_getDriverKey(dev.device))
+	driverKey, _ = dev.GetDriverKeyChecked()
+
+	return driverKey
+}
+
+// GetDriverKeyChecked returns a key that uniquely identifies the device driver, returning the failure reported by the
+// driver. See GetDriverKey() for the details.
+//
+// Return the key of the driver and an error if the call failed
+func (dev *SDRDevice) GetDriverKeyChecked() (driverKey string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetDriverKey", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getDriverKey(dev.device, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "GetDriverKey")
 }
 
 // GetHardwareKey returns a key that uniquely identifies the hardware.
@@ -25,10 +45,26 @@ func (dev *SDRDevice) GetDriverKey() (driverKey string) {
 // This key should be meaningful to the user to optimize for the underlying hardware.
 func (dev *SDRDevice) GetHardwareKey() (hardwareKey string) {
 
-	val := (*C.char)(C.SoapySDRDevice_getHardwareKey(dev.device))
+	hardwareKey, _ = dev.GetHardwareKeyChecked()
+
+	return hardwareKey
+}
+
+// GetHardwareKeyChecked returns a key that uniquely identifies the hardware, returning the failure reported by the
+// driver. See GetHardwareKey() for the details.
+//
+// Return the key of the hardware and an error if the call failed
+func (dev *SDRDevice) GetHardwareKeyChecked() (hardwareKey string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetHardwareKey", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getHardwareKey(dev.device, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "GetHardwareKey")
 }
 
 // GetHardwareInfo queries a dictionary of available device information.
@@ -37,8 +73,24 @@ func (dev *SDRDevice) GetHardwareKey() (hardwareKey string) {
 // This information can be displayed to the user to help identify the instantiated device.
 func (dev *SDRDevice) GetHardwareInfo() (hardwareInfo map[string]string) {
 
-	info := (C.SoapySDRKwargs)(C.SoapySDRDevice_getHardwareInfo(dev.device))
+	hardwareInfo, _ = dev.GetHardwareInfoChecked()
+
+	return hardwareInfo
+}
+
+// GetHardwareInfoChecked queries a dictionary of available device information, returning the failure reported by the
+// driver. See GetHardwareInfo() for the details.
+//
+// Return the information about the device and an error if the call failed
+func (dev *SDRDevice) GetHardwareInfoChecked() (hardwareInfo map[string]string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetHardwareInfo", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	info := (C.SoapySDRKwargs)(C.SoapySDRGo_getHardwareInfo(dev.device, &status))
 	defer argsClear(info)
 
-	return args2Go(info)
+	return args2Go(info), callError(&status, "GetHardwareInfo")
 }
@@ -0,0 +1,9 @@
+//go:build soapysdr_debug
+
+package device
+
+// leakPanic makes a leaked device or stream panic: the debug builds fail loudly on leaks
+const leakPanic = true
+
+// leakDetectionDefault enables the leak detection by default in debug builds
+const leakDetectionDefault int32 = 1
@@ -0,0 +1,9 @@
+//go:build !soapysdr_debug
+
+package device
+
+// leakPanic makes a leaked device or stream panic: the release builds only log the leaks
+const leakPanic = false
+
+// leakDetectionDefault disables the leak detection by default in release builds
+const leakDetectionDefault int32 = 0
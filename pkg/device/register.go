@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -18,12 +19,28 @@ import (
 // Return a list of available register interfaces
 func (dev *SDRDevice) ListRegisterInterfaces() []string {
 
+	names, _ := dev.ListRegisterInterfacesChecked()
+
+	return names
+}
+
+// ListRegisterInterfacesChecked gets a list of available register interfaces by name, returning the failure reported by
+// the driver. See ListRegisterInterfaces() for the details.
+//
+// Return a list of available register interfaces and an error if the call failed
+func (dev *SDRDevice) ListRegisterInterfacesChecked() (names []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListRegisterInterfaces", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listRegisterInterfaces(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listRegisterInterfaces(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListRegisterInterfaces")
 }
 
 // WriteRegister writes a register on the device given the interface name. This can represent a register on a soft CPU,
@@ -37,13 +54,20 @@ func (dev *SDRDevice) ListRegisterInterfaces() []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteRegister(name string, addr uint32, value uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteRegister", "")
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
 	cAddr := C.uint(addr)
 	cValue := C.uint(value)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeRegister(dev.device, cName, cAddr, cValue)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeRegister(dev.device, cName, cAddr, cValue, &status)
+
+	return callError(&status, "WriteRegister")
 }
 
 // ReadRegister reads a register on the device given the interface name.
@@ -52,15 +76,33 @@ func (dev *SDRDevice) WriteRegister(name string, addr uint32, value uint32) (err
 //  - name: the name of a available register interface
 //  - addr: the register address
 //
-// Return an error or nil in case of success
+// Return the register value
 func (dev *SDRDevice) ReadRegister(name string, addr uint32) uint32 {
 
+	value, _ := dev.ReadRegisterChecked(name, addr)
+
+	return value
+}
+
+// ReadRegisterChecked reads a register on the device given the interface name, returning the failure reported by the
+// driver. See ReadRegister() for the details.
+//
+// Return the register value and an error if the call failed
+func (dev *SDRDevice) ReadRegisterChecked(name string, addr uint32) (value uint32, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "ReadRegister", "")
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
 	cAddr := C.uint(addr)
 
-	return uint32(C.SoapySDRDevice_readRegister(dev.device, cName, cAddr))
+	var status C.SoapySDRGoStatus
+	value = uint32(C.SoapySDRGo_readRegister(dev.device, cName, cAddr, &status))
+
+	return value, callError(&status, "ReadRegister")
 }
 
 // WriteRegisters writes a memory block on the device given the interface name. This can represent a memory block on a
@@ -74,6 +116,10 @@ func (dev *SDRDevice) ReadRegister(name string, addr uint32) uint32 {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteRegisters(name string, addr uint32, value []uint32) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteRegisters", "")
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
@@ -81,29 +127,48 @@ func (dev *SDRDevice) WriteRegisters(name string, addr uint32, value []uint32) (
 	cValue := (*C.uint)(unsafe.Pointer(&value[0]))
 	cLength := C.size_t(len(value))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeRegisters(dev.device, cName, cAddr, cValue, cLength)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeRegisters(dev.device, cName, cAddr, cValue, cLength, &status)
+
+	return callError(&status, "WriteRegisters")
 }
 
-// ReadRegisters reads a a memory block on the device given the interface name. Pass the number of words to be read
+// ReadRegisters reads a memory block on the device given the interface name. Pass the number of words to be read
 // in via length;
 //
 // Params:
 //  - name: the name of a available register interface
 //  - addr: the register address
 //
-// Return an error or nil in case of success
+// Return the memory block content
 func (dev *SDRDevice) ReadRegisters(name string, addr uint32, length uint) []uint32 {
 
+	values, _ := dev.ReadRegistersChecked(name, addr, length)
+
+	return values
+}
+
+// ReadRegistersChecked reads a memory block on the device given the interface name, returning the failure reported by
+// the driver. See ReadRegisters() for the details.
+//
+// Return the memory block content and an error if the call failed
+func (dev *SDRDevice) ReadRegistersChecked(name string, addr uint32, length uint) (values []uint32, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ReadRegisters", "")
+	}
+
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
 	cAddr := C.uint(addr)
 	cLength := C.size_t(length)
 
-	cValue := C.SoapySDRDevice_readRegisters(dev.device, cName, cAddr, &cLength)
+	var status C.SoapySDRGoStatus
+	cValue := C.SoapySDRGo_readRegisters(dev.device, cName, cAddr, &cLength, &status)
 	defer C.free(unsafe.Pointer(cValue))
 
-	var uintTemplate *C.uint
+	var uintTemplate C.uint
 
 	results := make([]uint32, int(cLength))
 
@@ -113,5 +178,5 @@ func (dev *SDRDevice) ReadRegisters(name string, addr uint32, length uint) []uin
 		results[i] = uint32(*val)
 	}
 
-	return results
+	return results, callError(&status, "ReadRegisters")
 }
@@ -0,0 +1,251 @@
+package device
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrlogger"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// HandleKind is the kind of a handle of the registry of open devices and streams
+type HandleKind int
+
+const (
+	// HandleDevice is a device created by Make(), MakeStrArgs() or MakeList()
+	HandleDevice HandleKind = iota
+	// HandleStream is a stream created by SetupStream() or one of its variants
+	HandleStream
+)
+
+// String returns the name of the kind of handle
+func (kind HandleKind) String() string {
+
+	if kind == HandleStream {
+		return "stream"
+	}
+
+	return "device"
+}
+
+// OpenHandle describes a device or a stream which was created and not released yet, that is a device on which
+// Unmake() was not called or a stream which was not closed.
+type OpenHandle struct {
+	// ID is the identifier of the handle, unique for the life of the program
+	ID uint64
+	// Kind is the kind of the handle
+	Kind HandleKind
+	// Description describes the handle: the construction arguments of a device, the direction, format and channels of a
+	// stream
+	Description string
+	// CreatedAt is the location of the call which created the handle, empty if the leak detection was disabled when
+	// the handle was created
+	CreatedAt string
+	// Leaked is true if the handle was garbage collected without being released. Its C resources will never be freed.
+	Leaked bool
+}
+
+// registry holds the open devices and streams. It does not reference the devices and the streams themselves, so that
+// they can be garbage collected and their leak detected.
+var registry = struct {
+	sync.Mutex
+	handles map[uint64]*OpenHandle
+	lastID  uint64
+}{
+	handles: make(map[uint64]*OpenHandle),
+}
+
+// leakDetection is 1 if the leak detection is enabled, 0 otherwise
+var leakDetection = leakDetectionDefault
+
+// SetLeakDetection enables or disables the leak detection. When enabled, the devices and streams created afterwards
+// have a finalizer which reports them through sdrlogger if they are garbage collected without Unmake() or Close()
+// having been called, and their creation location is recorded in the registry (see ListOpenHandles()). In debug
+// builds (built with the soapysdr_debug tag), the leak detection is enabled by default and a leak panics.
+//
+// Params:
+//  - enabled: true to enable the leak detection
+func SetLeakDetection(enabled bool) {
+
+	if enabled {
+		atomic.StoreInt32(&leakDetection, 1)
+	} else {
+		atomic.StoreInt32(&leakDetection, 0)
+	}
+}
+
+// LeakDetection returns if the leak detection is enabled
+func LeakDetection() bool {
+
+	return atomic.LoadInt32(&leakDetection) != 0
+}
+
+// ListOpenHandles lists the devices and the streams which are currently open, including the leaked ones.
+//
+// Return the open handles, in creation order
+func ListOpenHandles() []OpenHandle {
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	handles := make([]OpenHandle, 0, len(registry.handles))
+	for _, handle := range registry.handles {
+		handles = append(handles, *handle)
+	}
+
+	sort.Slice(handles, func(i, j int) bool {
+		return handles[i].ID < handles[j].ID
+	})
+
+	return handles
+}
+
+// trackDevice registers a new device in the registry and sets its leak finalizer if the leak detection is enabled.
+//
+// Params:
+//  - dev: the new device
+//  - description: the description of the device
+//
+// Return the device
+func trackDevice(dev *SDRDevice, description string) *SDRDevice {
+
+	dev.handle = openHandle(HandleDevice, description)
+
+	if LeakDetection() {
+		runtime.SetFinalizer(dev, func(dev *SDRDevice) {
+			leakedHandle(dev.handle)
+		})
+	}
+
+	return dev
+}
+
+// untrackDevice removes a released device from the registry and clears its leak finalizer
+func untrackDevice(dev *SDRDevice) {
+
+	runtime.SetFinalizer(dev, nil)
+	closeHandle(dev.handle)
+	dev.handle = 0
+}
+
+// trackStream registers a new stream in the registry and sets its leak finalizer if the leak detection is enabled.
+//
+// Params:
+//  - stream: the new stream
+//  - channels: the channels of the stream
+//
+// Return the stream
+func trackStream[T Sample](stream *Stream[T], channels []uint) *Stream[T] {
+
+	stream.handle = openHandle(HandleStream, fmt.Sprintf("%v %v channels %v", stream.direction, stream.format, channels))
+
+	if LeakDetection() {
+		runtime.SetFinalizer(stream, func(stream *Stream[T]) {
+			leakedHandle(stream.handle)
+		})
+	}
+
+	return stream
+}
+
+// untrackStream clears the leak finalizer of a stream being closed. Its handle is removed from the registry when its
+// C stream is closed, which the Unmake() of its device may also do.
+func untrackStream[T Sample](stream *Stream[T]) {
+
+	runtime.SetFinalizer(stream, nil)
+}
+
+// openHandle adds a handle to the registry.
+//
+// Params:
+//  - kind: the kind of the handle
+//  - description: the description of the handle
+//
+// Return the identifier of the handle
+func openHandle(kind HandleKind, description string) uint64 {
+
+	handle := &OpenHandle{
+		Kind:        kind,
+		Description: description,
+	}
+	if LeakDetection() {
+		handle.CreatedAt = callerLocation()
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.lastID++
+	handle.ID = registry.lastID
+	registry.handles[handle.ID] = handle
+
+	return handle.ID
+}
+
+// closeHandle removes a handle from the registry. The identifier 0, given to the objects which were not created by
+// the package, is ignored.
+func closeHandle(id uint64) {
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	delete(registry.handles, id)
+}
+
+// leakedHandle reports a handle garbage collected without being released. The handle is kept in the registry as its C
+// resources are still allocated. In debug builds, it panics.
+func leakedHandle(id uint64) {
+
+	registry.Lock()
+	handle, found := registry.handles[id]
+	if found {
+		handle.Leaked = true
+	}
+	registry.Unlock()
+
+	if !found {
+		return
+	}
+
+	message := fmt.Sprintf("go-soapy-sdr: %v %v (%v) was garbage collected without being released", handle.Kind, handle.ID, handle.Description)
+	if handle.CreatedAt != "" {
+		message += ", created at " + handle.CreatedAt
+	}
+
+	if leakPanic {
+		panic(message)
+	}
+
+	sdrlogger.Log(sdrlogger.Error, message)
+}
+
+// callerLocation returns the location of the first caller outside of the package
+func callerLocation() string {
+
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/pothosware/go-soapy-sdr/pkg/device.") {
+			return fmt.Sprintf("%v:%v", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// argsDescription describes the construction arguments of a device
+func argsDescription(args map[string]string) string {
+
+	pairs := make([]string, 0, len(args))
+	for key, value := range args {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,233 @@
+package device_test
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrlogger"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// makeNullDevice makes a device of the "null" driver of SoapySDR, skipping the test if the driver is not available
+func makeNullDevice(t *testing.T) *device.SDRDevice {
+
+	dev, err := device.Make(map[string]string{"driver": "null"})
+	if err != nil {
+		t.Skipf("no null device: %v", err)
+	}
+
+	return dev
+}
+
+// newHandles returns the open handles created after the given handle identifier
+func newHandles(after uint64) []device.OpenHandle {
+
+	var handles []device.OpenHandle
+	for _, handle := range device.ListOpenHandles() {
+		if handle.ID > after {
+			handles = append(handles, handle)
+		}
+	}
+
+	return handles
+}
+
+// lastHandleID returns the identifier of the last open handle, 0 if none
+func lastHandleID() uint64 {
+
+	handles := device.ListOpenHandles()
+	if len(handles) == 0 {
+		return 0
+	}
+
+	return handles[len(handles)-1].ID
+}
+
+// withLeakDetection enables or disables the leak detection for the duration of a test
+func withLeakDetection(t *testing.T, enabled bool) {
+
+	previous := device.LeakDetection()
+	device.SetLeakDetection(enabled)
+	t.Cleanup(func() {
+		device.SetLeakDetection(previous)
+	})
+}
+
+func TestListOpenHandles(t *testing.T) {
+
+	withLeakDetection(t, false)
+	before := lastHandleID()
+
+	dev := makeNullDevice(t)
+	stream, err := dev.SetupSDRStreamCS16(device.DirectionRX, []uint{0}, nil)
+	if err != nil {
+		_ = dev.Unmake()
+		t.Skipf("no stream on the null device: %v", err)
+	}
+
+	handles := newHandles(before)
+	if len(handles) != 2 {
+		t.Fatalf("open handles %+v, expected a device and a stream", handles)
+	}
+	if handles[0].Kind != device.HandleDevice || handles[0].Description != "driver=null" {
+		t.Errorf("unexpected device handle %+v", handles[0])
+	}
+	if handles[1].Kind != device.HandleStream || handles[1].Description != "RX CS16 channels [0]" {
+		t.Errorf("unexpected stream handle %+v", handles[1])
+	}
+	for _, handle := range handles {
+		if handle.CreatedAt != "" || handle.Leaked {
+			t.Errorf("handle %+v created without leak detection", handle)
+		}
+	}
+
+	// Closing and unmaking untrack the handles
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if handles := newHandles(before); len(handles) != 1 || handles[0].Kind != device.HandleDevice {
+		t.Errorf("open handles %+v after Close, expected the device", handles)
+	}
+	if err := dev.Unmake(); err != nil {
+		t.Fatal(err)
+	}
+	if handles := newHandles(before); len(handles) != 0 {
+		t.Errorf("open handles %+v after Unmake", handles)
+	}
+}
+
+func TestSetLeakDetection(t *testing.T) {
+
+	withLeakDetection(t, true)
+	if !device.LeakDetection() {
+		t.Fatal("the leak detection is not enabled")
+	}
+	before := lastHandleID()
+
+	// The handles created with the leak detection record their creation location
+	dev := makeNullDevice(t)
+	defer dev.Unmake()
+
+	handles := newHandles(before)
+	if len(handles) != 1 || !strings.Contains(handles[0].CreatedAt, "registry_test.go:") {
+		t.Errorf("open handles %+v, expected a device created in registry_test.go", handles)
+	}
+
+	device.SetLeakDetection(false)
+	if device.LeakDetection() {
+		t.Fatal("the leak detection is not disabled")
+	}
+}
+
+// leakDevice makes a device and drops it without unmaking it
+func leakDevice(t *testing.T) {
+
+	makeNullDevice(t)
+}
+
+func TestLeakReport(t *testing.T) {
+
+	withLeakDetection(t, true)
+	if device.LeakPanic {
+		t.Skip("the leaks panic in debug builds")
+	}
+
+	var mutex sync.Mutex
+	var messages []string
+	sdrlogger.RegisterLogHandler(func(level sdrlogger.SDRLogLevel, message string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		messages = append(messages, message)
+	})
+	defer sdrlogger.RegisterLogHandler(nil)
+
+	before := lastHandleID()
+	leakDevice(t)
+
+	// The finalizer of the device marks its handle as leaked, which is kept as its C resources are never freed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		handles := newHandles(before)
+		if len(handles) == 1 && handles[0].Leaked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("open handles %+v, expected a leaked device", handles)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(messages) != 1 || !strings.Contains(messages[0], "garbage collected without being released") ||
+		!strings.Contains(messages[0], "registry_test.go:") {
+		t.Errorf("leak reported as %q", messages)
+	}
+}
+
+func TestLeakedHandle(t *testing.T) {
+
+	var messages []string
+	sdrlogger.RegisterLogHandler(func(level sdrlogger.SDRLogLevel, message string) {
+		messages = append(messages, message)
+	})
+	defer sdrlogger.RegisterLogHandler(nil)
+
+	id := device.OpenTestHandle(device.HandleStream, "leaked stream")
+	defer func() {
+		recovered := recover()
+		if device.LeakPanic {
+			// The debug builds panic on a leak
+			if message, ok := recovered.(string); !ok || !strings.Contains(message, "leaked stream") {
+				t.Errorf("the leak panicked with %v", recovered)
+			}
+		} else if recovered != nil || len(messages) != 1 || !strings.Contains(messages[0], "leaked stream") {
+			t.Errorf("the leak panicked with %v and was logged as %q", recovered, messages)
+		}
+
+		handles := newHandles(id - 1)
+		if len(handles) != 1 || !handles[0].Leaked {
+			t.Errorf("open handles %+v, expected the leaked stream", handles)
+		}
+	}()
+
+	device.LeakedHandle(id)
+}
+
+// leakStream makes a device, sets up a stream on it and drops both without releasing them
+func leakStream(t *testing.T) {
+
+	dev := makeNullDevice(t)
+	if _, err := dev.SetupSDRStreamCS16(device.DirectionRX, []uint{0}, nil); err != nil {
+		_ = dev.Unmake()
+		t.Skipf("no stream on the null device: %v", err)
+	}
+}
+
+func TestStreamLeakReport(t *testing.T) {
+
+	withLeakDetection(t, true)
+	if device.LeakPanic {
+		t.Skip("the leaks panic in debug builds")
+	}
+
+	before := lastHandleID()
+	leakStream(t)
+
+	// The device references its open streams: both must still be collected and reported
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		handles := newHandles(before)
+		if len(handles) == 2 && handles[0].Leaked && handles[1].Leaked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("open handles %+v, expected a leaked device and a leaked stream", handles)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
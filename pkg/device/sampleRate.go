@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -22,7 +23,14 @@ import (
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetSampleRate(direction Direction, channel uint, rate float64) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setSampleRate(dev.device, C.int(direction), C.size_t(channel), C.double(rate))))
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetSampleRate", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setSampleRate(dev.device, C.int(direction), C.size_t(channel), C.double(rate), &status)
+
+	return channelError(&status, "SetSampleRate", direction, channel)
 }
 
 // GetSampleRate gets the baseband sample rate of the chain.
@@ -34,7 +42,25 @@ func (dev *SDRDevice) SetSampleRate(direction Direction, channel uint, rate floa
 // Return the sample rate in samples per second
 func (dev *SDRDevice) GetSampleRate(direction Direction, channel uint) float64 {
 
-	return float64(C.SoapySDRDevice_getSampleRate(dev.device, C.int(direction), C.size_t(channel)))
+	rate, _ := dev.GetSampleRateChecked(direction, channel)
+
+	return rate
+}
+
+// GetSampleRateChecked gets the baseband sample rate of the chain, returning the failure reported by the driver. See
+// GetSampleRate() for the details.
+//
+// Return the sample rate in samples per second and an error if the call failed
+func (dev *SDRDevice) GetSampleRateChecked(direction Direction, channel uint) (rate float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetSampleRate", direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	rate = float64(C.SoapySDRGo_getSampleRate(dev.device, C.int(direction), C.size_t(channel), &status))
+
+	return rate, channelError(&status, "GetSampleRate", direction, channel)
 }
 
 // GetSampleRateRange gets the range of possible baseband sample rates.
@@ -46,10 +72,26 @@ func (dev *SDRDevice) GetSampleRate(direction Direction, channel uint) float64 {
 // Return a list of sample rate ranges in samples per second
 func (dev *SDRDevice) GetSampleRateRange(direction Direction, channel uint) []SDRRange {
 
+	ranges, _ := dev.GetSampleRateRangeChecked(direction, channel)
+
+	return ranges
+}
+
+// GetSampleRateRangeChecked gets the range of possible baseband sample rates, returning the failure reported by the
+// driver. See GetSampleRateRange() for the details.
+//
+// Return a list of sample rate ranges in samples per second and an error if the call failed
+func (dev *SDRDevice) GetSampleRateRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetSampleRateRange", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getSampleRateRange(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getSampleRateRange(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer rangeArrayClear(info)
 
-	return rangeArray2Go(info, length)
+	return rangeArray2Go(info, length), channelError(&status, "GetSampleRateRange", direction, channel)
 }
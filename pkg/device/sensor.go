@@ -7,20 +7,40 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
-import "unsafe"
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"unsafe"
+)
 
 // ListSensors gets a list of the available global readable sensors.
 //
 // Return a list of available sensor string names
 func (dev *SDRDevice) ListSensors() []string {
 
+	keys, _ := dev.ListSensorsChecked()
+
+	return keys
+}
+
+// ListSensorsChecked gets a list of the available global readable sensors, returning the failure reported by the
+// driver. See ListSensors() for the details.
+//
+// Return a list of available sensor string names and an error if the call failed
+func (dev *SDRDevice) ListSensorsChecked() (keys []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListSensors", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listSensors(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listSensors(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListSensors")
 }
 
 // GetSensorInfo gets meta-information about a sensor.
@@ -31,13 +51,29 @@ func (dev *SDRDevice) ListSensors() []string {
 // Return meta-information about a sensor
 func (dev *SDRDevice) GetSensorInfo(key string) SDRArgInfo {
 
+	info, _ := dev.GetSensorInfoChecked(key)
+
+	return info
+}
+
+// GetSensorInfoChecked gets meta-information about a sensor, returning the failure reported by the driver. See
+// GetSensorInfo() for the details.
+//
+// Return meta-information about a sensor and an error if the call failed
+func (dev *SDRDevice) GetSensorInfoChecked(key string) (info SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return SDRArgInfo{}, rejectedError(sdrerror.ErrClosed, "GetSensorInfo", "")
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	info := C.SoapySDRDevice_getSensorInfo(dev.device, cKey)
-	defer argInfoClear(info)
+	var status C.SoapySDRGoStatus
+	cInfo := C.SoapySDRGo_getSensorInfo(dev.device, cKey, &status)
+	defer argInfoClear(cInfo)
 
-	return argInfo2Go(&info)
+	return argInfo2Go(&cInfo), callError(&status, "GetSensorInfo")
 }
 
 // ReadSensor reads a global sensor given the name. The value returned is a string which can represent
@@ -49,13 +85,29 @@ func (dev *SDRDevice) GetSensorInfo(key string) SDRArgInfo {
 // Return the current value of the sensor
 func (dev *SDRDevice) ReadSensor(key string) string {
 
+	value, _ := dev.ReadSensorChecked(key)
+
+	return value
+}
+
+// ReadSensorChecked reads a global sensor given the name, returning the failure reported by the driver. See
+// ReadSensor() for the details.
+//
+// Return the current value of the sensor and an error if the call failed
+func (dev *SDRDevice) ReadSensorChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "ReadSensor", "")
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	val := (*C.char)(C.SoapySDRDevice_readSensor(dev.device, cKey))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_readSensor(dev.device, cKey, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "ReadSensor")
 }
 
 // ListChannelSensors gets a list of the available channel readable sensors.
@@ -67,12 +119,28 @@ func (dev *SDRDevice) ReadSensor(key string) string {
 // Return a list of available sensor string names
 func (dev *SDRDevice) ListChannelSensors(direction Direction, channel uint) []string {
 
+	keys, _ := dev.ListChannelSensorsChecked(direction, channel)
+
+	return keys
+}
+
+// ListChannelSensorsChecked gets a list of the available channel readable sensors, returning the failure reported by
+// the driver. See ListChannelSensors() for the details.
+//
+// Return a list of available sensor string names and an error if the call failed
+func (dev *SDRDevice) ListChannelSensorsChecked(direction Direction, channel uint) (keys []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListChannelSensors", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listChannelSensors(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listChannelSensors(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), channelError(&status, "ListChannelSensors", direction, channel)
 }
 
 // GetChannelSensorInfo gets meta-information about a channel sensor.
@@ -85,13 +153,29 @@ func (dev *SDRDevice) ListChannelSensors(direction Direction, channel uint) []st
 // Return meta-information about a sensor
 func (dev *SDRDevice) GetChannelSensorInfo(direction Direction, channel uint, key string) SDRArgInfo {
 
+	info, _ := dev.GetChannelSensorInfoChecked(direction, channel, key)
+
+	return info
+}
+
+// GetChannelSensorInfoChecked gets meta-information about a channel sensor, returning the failure reported by the
+// driver. See GetChannelSensorInfo() for the details.
+//
+// Return meta-information about a sensor and an error if the call failed
+func (dev *SDRDevice) GetChannelSensorInfoChecked(direction Direction, channel uint, key string) (info SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return SDRArgInfo{}, rejectedError(sdrerror.ErrClosed, "GetChannelSensorInfo", direction.String())
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	info := C.SoapySDRDevice_getChannelSensorInfo(dev.device, C.int(direction), C.size_t(channel), cKey)
-	defer argInfoClear(info)
+	var status C.SoapySDRGoStatus
+	cInfo := C.SoapySDRGo_getChannelSensorInfo(dev.device, C.int(direction), C.size_t(channel), cKey, &status)
+	defer argInfoClear(cInfo)
 
-	return argInfo2Go(&info)
+	return argInfo2Go(&cInfo), channelError(&status, "GetChannelSensorInfo", direction, channel)
 }
 
 // ReadChannelSensor reads a channel sensor given the name. The value returned is a string which can represent
@@ -105,11 +189,27 @@ func (dev *SDRDevice) GetChannelSensorInfo(direction Direction, channel uint, ke
 // Return the current value of the sensor
 func (dev *SDRDevice) ReadChannelSensor(direction Direction, channel uint, key string) string {
 
+	value, _ := dev.ReadChannelSensorChecked(direction, channel, key)
+
+	return value
+}
+
+// ReadChannelSensorChecked reads a channel sensor given the name, returning the failure reported by the driver. See
+// ReadChannelSensor() for the details.
+//
+// Return the current value of the sensor and an error if the call failed
+func (dev *SDRDevice) ReadChannelSensorChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "ReadChannelSensor", direction.String())
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	val := (*C.char)(C.SoapySDRDevice_readChannelSensor(dev.device, C.int(direction), C.size_t(channel), cKey))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_readChannelSensor(dev.device, C.int(direction), C.size_t(channel), cKey, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), channelError(&status, "ReadChannelSensor", direction, channel)
 }
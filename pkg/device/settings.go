@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -18,12 +19,28 @@ import (
 // Return a list of argument info structures
 func (dev *SDRDevice) GetSettingInfo() []SDRArgInfo {
 
+	infos, _ := dev.GetSettingInfoChecked()
+
+	return infos
+}
+
+// GetSettingInfoChecked describes the allowed keys and values used for settings, returning the failure reported by the
+// driver. See GetSettingInfo() for the details.
+//
+// Return a list of argument info structures and an error if the call failed
+func (dev *SDRDevice) GetSettingInfoChecked() (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetSettingInfo", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getSettingInfo(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getSettingInfo(dev.device, &length, &status)
 	defer argInfoListClear(info, length)
 
-	return argInfoList2Go(info, length)
+	return argInfoList2Go(info, length), callError(&status, "GetSettingInfo")
 }
 
 // WriteSetting writes an arbitrary setting on the device.
@@ -37,13 +54,20 @@ func (dev *SDRDevice) GetSettingInfo() []SDRArgInfo {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteSetting(key string, value string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteSetting", "")
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
 	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(cValue))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeSetting(dev.device, cKey, cValue)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeSetting(dev.device, cKey, cValue, &status)
+
+	return callError(&status, "WriteSetting")
 }
 
 // Read an arbitrary setting on the device.
@@ -62,13 +86,29 @@ func (dev *SDRDevice) WriteSetting(key string, value string) (err sdrerror.SDREr
 // Return the setting value
 func (dev *SDRDevice) ReadSetting(key string) string {
 
+	value, _ := dev.ReadSettingChecked(key)
+
+	return value
+}
+
+// ReadSettingChecked reads an arbitrary setting on the device, returning the failure reported by the driver. See
+// ReadSetting() for the details.
+//
+// Return the setting value and an error if the call failed
+func (dev *SDRDevice) ReadSettingChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "ReadSetting", "")
+	}
+
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	val := (*C.char)(C.SoapySDRDevice_readSetting(dev.device, cKey))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_readSetting(dev.device, cKey, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "ReadSetting")
 }
 
 // GetChannelSettingInfo describes the allowed keys and values used for channel settings.
@@ -80,14 +120,30 @@ func (dev *SDRDevice) ReadSetting(key string) string {
 // Return a list of argument info structures
 func (dev *SDRDevice) GetChannelSettingInfo(direction Direction, channel uint) []SDRArgInfo {
 
+	infos, _ := dev.GetChannelSettingInfoChecked(direction, channel)
+
+	return infos
+}
+
+// GetChannelSettingInfoChecked describes the allowed keys and values used for channel settings, returning the failure
+// reported by the driver. See GetChannelSettingInfo() for the details.
+//
+// Return a list of argument info structures and an error if the call failed
+func (dev *SDRDevice) GetChannelSettingInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetChannelSettingInfo", direction.String())
+	}
+
 	cDirection := C.int(direction)
 	cChannel := C.size_t(channel)
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getChannelSettingInfo(dev.device, cDirection, cChannel, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getChannelSettingInfo(dev.device, cDirection, cChannel, &length, &status)
 	defer argInfoListClear(info, length)
 
-	return argInfoList2Go(info, length)
+	return argInfoList2Go(info, length), channelError(&status, "GetChannelSettingInfo", direction, channel)
 }
 
 // WriteChannelSetting writes an arbitrary channel setting on the device.
@@ -103,6 +159,10 @@ func (dev *SDRDevice) GetChannelSettingInfo(direction Direction, channel uint) [
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteChannelSetting(direction Direction, channel uint, key string, value string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteChannelSetting", direction.String())
+	}
+
 	cDirection := C.int(direction)
 	cChannel := C.size_t(channel)
 
@@ -112,7 +172,10 @@ func (dev *SDRDevice) WriteChannelSetting(direction Direction, channel uint, key
 	cValue := C.CString(value)
 	defer C.free(unsafe.Pointer(cValue))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeChannelSetting(dev.device, cDirection, cChannel, cKey, cValue)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeChannelSetting(dev.device, cDirection, cChannel, cKey, cValue, &status)
+
+	return channelError(&status, "WriteChannelSetting", direction, channel)
 }
 
 // ReadChannelSetting an arbitrary channel setting on the device.
@@ -127,14 +190,30 @@ func (dev *SDRDevice) WriteChannelSetting(direction Direction, channel uint, key
 // Return the setting value
 func (dev *SDRDevice) ReadChannelSetting(direction Direction, channel uint, key string) string {
 
+	value, _ := dev.ReadChannelSettingChecked(direction, channel, key)
+
+	return value
+}
+
+// ReadChannelSettingChecked an arbitrary channel setting on the device, returning the failure reported by the driver.
+// See ReadChannelSetting() for the details.
+//
+// Return the setting value and an error if the call failed
+func (dev *SDRDevice) ReadChannelSettingChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "ReadChannelSetting", direction.String())
+	}
+
 	cDirection := C.int(direction)
 	cChannel := C.size_t(channel)
 
 	cKey := C.CString(key)
 	defer C.free(unsafe.Pointer(cKey))
 
-	val := (*C.char)(C.SoapySDRDevice_readChannelSetting(dev.device, cDirection, cChannel, cKey))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_readChannelSetting(dev.device, cDirection, cChannel, cKey, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), channelError(&status, "ReadChannelSetting", direction, channel)
 }
@@ -0,0 +1,373 @@
+/*
+ * Shims of the SoapySDR device API used by the Go bindings.
+ *
+ * The status and the error message of SoapySDR are stored per OS thread, and a goroutine can move to another thread
+ * between two cgo calls. Each shim makes the device call and captures its status and its error message within the
+ * same cgo call, so that they are those of the call.
+ */
+#ifndef SOAPY_SDR_GO_SHIMS_H
+#define SOAPY_SDR_GO_SHIMS_H
+
+#include <stdlib.h>
+#include <string.h>
+#include <SoapySDR/Device.h>
+
+/* The status of a device call: the status code and a copy of the error message, NULL if none. The message must be
+ * released with free(). */
+typedef struct {
+    int code;
+    char *message;
+} SoapySDRGoStatus;
+
+/* Capture the status of the last device call of the current thread */
+static inline void SoapySDRGo_capture(SoapySDRGoStatus *status, int code) {
+    status->code = code;
+    status->message = NULL;
+    if (code != 0) {
+        const char *message = SoapySDRDevice_lastError();
+        if (message != NULL && message[0] != '\0') {
+            status->message = strdup(message);
+        }
+    }
+}
+
+/* Shim of a call returning a status code, negative in case of error */
+#define SOAPY_SDR_GO_STATUS_SHIM(name, params, args) \
+    static inline int SoapySDRGo_##name params { \
+        int result = SoapySDRDevice_##name args; \
+        SoapySDRGo_capture(status, result < 0 ? result : 0); \
+        return result; \
+    }
+
+/* Shim of a call returning a value, the status being given by SoapySDRDevice_lastStatus() */
+#define SOAPY_SDR_GO_VALUE_SHIM(type, name, params, args) \
+    static inline type SoapySDRGo_##name params { \
+        type value = SoapySDRDevice_##name args; \
+        SoapySDRGo_capture(status, SoapySDRDevice_lastStatus()); \
+        return value; \
+    }
+
+SOAPY_SDR_GO_STATUS_SHIM(unmake,
+	(SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_STATUS_SHIM(unmake_list,
+	(SoapySDRDevice **devices, const size_t length, SoapySDRGoStatus *status),
+	(devices, length))
+SOAPY_SDR_GO_STATUS_SHIM(setFrontendMapping,
+	(SoapySDRDevice *device, const int direction, const char *mapping, SoapySDRGoStatus *status),
+	(device, direction, mapping))
+SOAPY_SDR_GO_STATUS_SHIM(closeStream,
+	(SoapySDRDevice *device, SoapySDRStream *stream, SoapySDRGoStatus *status),
+	(device, stream))
+SOAPY_SDR_GO_STATUS_SHIM(activateStream,
+	(SoapySDRDevice *device, SoapySDRStream *stream, const int flags, const long long timeNs, const size_t numElems, SoapySDRGoStatus *status),
+	(device, stream, flags, timeNs, numElems))
+SOAPY_SDR_GO_STATUS_SHIM(deactivateStream,
+	(SoapySDRDevice *device, SoapySDRStream *stream, const int flags, const long long timeNs, SoapySDRGoStatus *status),
+	(device, stream, flags, timeNs))
+SOAPY_SDR_GO_STATUS_SHIM(readStream,
+	(SoapySDRDevice *device, SoapySDRStream *stream, void * const *buffs, const size_t numElems, int *flags, long long *timeNs, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, stream, buffs, numElems, flags, timeNs, timeoutUs))
+SOAPY_SDR_GO_STATUS_SHIM(writeStream,
+	(SoapySDRDevice *device, SoapySDRStream *stream, const void * const *buffs, const size_t numElems, int *flags, const long long timeNs, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, stream, buffs, numElems, flags, timeNs, timeoutUs))
+SOAPY_SDR_GO_STATUS_SHIM(readStreamStatus,
+	(SoapySDRDevice *device, SoapySDRStream *stream, size_t *chanMask, int *flags, long long *timeNs, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, stream, chanMask, flags, timeNs, timeoutUs))
+SOAPY_SDR_GO_STATUS_SHIM(getDirectAccessBufferAddrs,
+	(SoapySDRDevice *device, SoapySDRStream *stream, const size_t handle, void **buffs, SoapySDRGoStatus *status),
+	(device, stream, handle, buffs))
+SOAPY_SDR_GO_STATUS_SHIM(acquireReadBuffer,
+	(SoapySDRDevice *device, SoapySDRStream *stream, size_t *handle, const void **buffs, int *flags, long long *timeNs, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, stream, handle, buffs, flags, timeNs, timeoutUs))
+SOAPY_SDR_GO_STATUS_SHIM(acquireWriteBuffer,
+	(SoapySDRDevice *device, SoapySDRStream *stream, size_t *handle, void **buffs, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, stream, handle, buffs, timeoutUs))
+SOAPY_SDR_GO_STATUS_SHIM(setAntenna,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const char *name, SoapySDRGoStatus *status),
+	(device, direction, channel, name))
+SOAPY_SDR_GO_STATUS_SHIM(setDCOffsetMode,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const bool automatic, SoapySDRGoStatus *status),
+	(device, direction, channel, automatic))
+SOAPY_SDR_GO_STATUS_SHIM(setDCOffset,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double offsetI, const double offsetQ, SoapySDRGoStatus *status),
+	(device, direction, channel, offsetI, offsetQ))
+SOAPY_SDR_GO_STATUS_SHIM(getDCOffset,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, double *offsetI, double *offsetQ, SoapySDRGoStatus *status),
+	(device, direction, channel, offsetI, offsetQ))
+SOAPY_SDR_GO_STATUS_SHIM(setIQBalance,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double balanceI, const double balanceQ, SoapySDRGoStatus *status),
+	(device, direction, channel, balanceI, balanceQ))
+SOAPY_SDR_GO_STATUS_SHIM(getIQBalance,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, double *balanceI, double *balanceQ, SoapySDRGoStatus *status),
+	(device, direction, channel, balanceI, balanceQ))
+SOAPY_SDR_GO_STATUS_SHIM(setFrequencyCorrection,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double value, SoapySDRGoStatus *status),
+	(device, direction, channel, value))
+SOAPY_SDR_GO_STATUS_SHIM(setGainMode,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const bool automatic, SoapySDRGoStatus *status),
+	(device, direction, channel, automatic))
+SOAPY_SDR_GO_STATUS_SHIM(setGain,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double value, SoapySDRGoStatus *status),
+	(device, direction, channel, value))
+SOAPY_SDR_GO_STATUS_SHIM(setGainElement,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const char *name, const double value, SoapySDRGoStatus *status),
+	(device, direction, channel, name, value))
+SOAPY_SDR_GO_STATUS_SHIM(setFrequency,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double frequency, const SoapySDRKwargs *args, SoapySDRGoStatus *status),
+	(device, direction, channel, frequency, args))
+SOAPY_SDR_GO_STATUS_SHIM(setFrequencyComponent,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const char *name, const double frequency, const SoapySDRKwargs *args, SoapySDRGoStatus *status),
+	(device, direction, channel, name, frequency, args))
+SOAPY_SDR_GO_STATUS_SHIM(setSampleRate,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double rate, SoapySDRGoStatus *status),
+	(device, direction, channel, rate))
+SOAPY_SDR_GO_STATUS_SHIM(setBandwidth,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const double bw, SoapySDRGoStatus *status),
+	(device, direction, channel, bw))
+SOAPY_SDR_GO_STATUS_SHIM(setMasterClockRate,
+	(SoapySDRDevice *device, const double rate, SoapySDRGoStatus *status),
+	(device, rate))
+SOAPY_SDR_GO_STATUS_SHIM(setClockSource,
+	(SoapySDRDevice *device, const char *source, SoapySDRGoStatus *status),
+	(device, source))
+SOAPY_SDR_GO_STATUS_SHIM(setTimeSource,
+	(SoapySDRDevice *device, const char *source, SoapySDRGoStatus *status),
+	(device, source))
+SOAPY_SDR_GO_STATUS_SHIM(setHardwareTime,
+	(SoapySDRDevice *device, const long long timeNs, const char *what, SoapySDRGoStatus *status),
+	(device, timeNs, what))
+SOAPY_SDR_GO_STATUS_SHIM(writeRegister,
+	(SoapySDRDevice *device, const char *name, const unsigned addr, const unsigned value, SoapySDRGoStatus *status),
+	(device, name, addr, value))
+SOAPY_SDR_GO_STATUS_SHIM(writeRegisters,
+	(SoapySDRDevice *device, const char *name, const unsigned addr, const unsigned *value, const size_t length, SoapySDRGoStatus *status),
+	(device, name, addr, value, length))
+SOAPY_SDR_GO_STATUS_SHIM(writeSetting,
+	(SoapySDRDevice *device, const char *key, const char *value, SoapySDRGoStatus *status),
+	(device, key, value))
+SOAPY_SDR_GO_STATUS_SHIM(writeChannelSetting,
+	(SoapySDRDevice *device, const int direction, const size_t channel, const char *key, const char *value, SoapySDRGoStatus *status),
+	(device, direction, channel, key, value))
+SOAPY_SDR_GO_STATUS_SHIM(writeGPIO,
+	(SoapySDRDevice *device, const char *bank, const unsigned value, SoapySDRGoStatus *status),
+	(device, bank, value))
+SOAPY_SDR_GO_STATUS_SHIM(writeGPIOMasked,
+	(SoapySDRDevice *device, const char *bank, const unsigned value, const unsigned mask, SoapySDRGoStatus *status),
+	(device, bank, value, mask))
+SOAPY_SDR_GO_STATUS_SHIM(writeGPIODir,
+	(SoapySDRDevice *device, const char *bank, const unsigned dir, SoapySDRGoStatus *status),
+	(device, bank, dir))
+SOAPY_SDR_GO_STATUS_SHIM(writeGPIODirMasked,
+	(SoapySDRDevice *device, const char *bank, const unsigned dir, const unsigned mask, SoapySDRGoStatus *status),
+	(device, bank, dir, mask))
+SOAPY_SDR_GO_STATUS_SHIM(writeI2C,
+	(SoapySDRDevice *device, const int addr, const char *data, const size_t numBytes, SoapySDRGoStatus *status),
+	(device, addr, data, numBytes))
+SOAPY_SDR_GO_STATUS_SHIM(writeUART,
+	(SoapySDRDevice *device, const char *which, const char *data, SoapySDRGoStatus *status),
+	(device, which, data))
+
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRDevice *, make,
+	(const SoapySDRKwargs *args, SoapySDRGoStatus *status),
+	(args))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRDevice *, makeStrArgs,
+	(const char *args, SoapySDRGoStatus *status),
+	(args))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRDevice **, make_list,
+	(const SoapySDRKwargs *argsList, const size_t length, SoapySDRGoStatus *status),
+	(argsList, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getDriverKey,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getHardwareKey,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRKwargs, getHardwareInfo,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getFrontendMapping,
+	(const SoapySDRDevice *device, const int direction, SoapySDRGoStatus *status),
+	(device, direction))
+SOAPY_SDR_GO_VALUE_SHIM(size_t, getNumChannels,
+	(const SoapySDRDevice *device, const int direction, SoapySDRGoStatus *status),
+	(device, direction))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRKwargs, getChannelInfo,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, getFullDuplex,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(char **, getStreamFormats,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getNativeStreamFormat,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, double *fullScale, SoapySDRGoStatus *status),
+	(device, direction, channel, fullScale))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo *, getStreamArgsInfo,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRStream *, setupStream,
+	(SoapySDRDevice *device, const int direction, const char *format, const size_t *channels, const size_t numChans, const SoapySDRKwargs *args, SoapySDRGoStatus *status),
+	(device, direction, format, channels, numChans, args))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listAntennas,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getAntenna,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasDCOffsetMode,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, getDCOffsetMode,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasDCOffset,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasIQBalance,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasFrequencyCorrection,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(double, getFrequencyCorrection,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listGains,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasGainMode,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(bool, getGainMode,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(double, getGain,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(double, getGainElement,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *name, SoapySDRGoStatus *status),
+	(device, direction, channel, name))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange, getGainRange,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange, getGainElementRange,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *name, SoapySDRGoStatus *status),
+	(device, direction, channel, name))
+SOAPY_SDR_GO_VALUE_SHIM(double, getFrequency,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(double, getFrequencyComponent,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *name, SoapySDRGoStatus *status),
+	(device, direction, channel, name))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listFrequencies,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange *, getFrequencyRange,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange *, getFrequencyRangeComponent,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *name, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, name, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo *, getFrequencyArgsInfo,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(double, getSampleRate,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange *, getSampleRateRange,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(double, getBandwidth,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, SoapySDRGoStatus *status),
+	(device, direction, channel))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange *, getBandwidthRange,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(double, getMasterClockRate,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRRange *, getMasterClockRates,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listClockSources,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getClockSource,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listTimeSources,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, getTimeSource,
+	(const SoapySDRDevice *device, SoapySDRGoStatus *status),
+	(device))
+SOAPY_SDR_GO_VALUE_SHIM(bool, hasHardwareTime,
+	(const SoapySDRDevice *device, const char *what, SoapySDRGoStatus *status),
+	(device, what))
+SOAPY_SDR_GO_VALUE_SHIM(long long, getHardwareTime,
+	(const SoapySDRDevice *device, const char *what, SoapySDRGoStatus *status),
+	(device, what))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listSensors,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo, getSensorInfo,
+	(const SoapySDRDevice *device, const char *key, SoapySDRGoStatus *status),
+	(device, key))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readSensor,
+	(const SoapySDRDevice *device, const char *key, SoapySDRGoStatus *status),
+	(device, key))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listChannelSensors,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo, getChannelSensorInfo,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *key, SoapySDRGoStatus *status),
+	(device, direction, channel, key))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readChannelSensor,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *key, SoapySDRGoStatus *status),
+	(device, direction, channel, key))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listRegisterInterfaces,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(unsigned, readRegister,
+	(const SoapySDRDevice *device, const char *name, const unsigned addr, SoapySDRGoStatus *status),
+	(device, name, addr))
+SOAPY_SDR_GO_VALUE_SHIM(unsigned *, readRegisters,
+	(const SoapySDRDevice *device, const char *name, const unsigned addr, size_t *length, SoapySDRGoStatus *status),
+	(device, name, addr, length))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo *, getSettingInfo,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readSetting,
+	(const SoapySDRDevice *device, const char *key, SoapySDRGoStatus *status),
+	(device, key))
+SOAPY_SDR_GO_VALUE_SHIM(SoapySDRArgInfo *, getChannelSettingInfo,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, size_t *length, SoapySDRGoStatus *status),
+	(device, direction, channel, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readChannelSetting,
+	(const SoapySDRDevice *device, const int direction, const size_t channel, const char *key, SoapySDRGoStatus *status),
+	(device, direction, channel, key))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listGPIOBanks,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(unsigned, readGPIO,
+	(const SoapySDRDevice *device, const char *bank, SoapySDRGoStatus *status),
+	(device, bank))
+SOAPY_SDR_GO_VALUE_SHIM(unsigned, readGPIODir,
+	(const SoapySDRDevice *device, const char *bank, SoapySDRGoStatus *status),
+	(device, bank))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readI2C,
+	(SoapySDRDevice *device, const int addr, size_t *numBytes, SoapySDRGoStatus *status),
+	(device, addr, numBytes))
+SOAPY_SDR_GO_VALUE_SHIM(unsigned, transactSPI,
+	(SoapySDRDevice *device, const int addr, const unsigned data, const size_t numBits, SoapySDRGoStatus *status),
+	(device, addr, data, numBits))
+SOAPY_SDR_GO_VALUE_SHIM(char **, listUARTs,
+	(const SoapySDRDevice *device, size_t *length, SoapySDRGoStatus *status),
+	(device, length))
+SOAPY_SDR_GO_VALUE_SHIM(char *, readUART,
+	(const SoapySDRDevice *device, const char *which, const long timeoutUs, SoapySDRGoStatus *status),
+	(device, which, timeoutUs))
+
+#endif
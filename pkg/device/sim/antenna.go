@@ -0,0 +1,100 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// ListAntennas gets a list of available antennas to select on a given chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel:  an available channel on the device
+//
+// Return a list of available antenna names
+func (dev *Device) ListAntennas(direction device.Direction, channel uint) []string {
+
+	if len(dev.config.Antennas) > 0 {
+		return append([]string(nil), dev.config.Antennas...)
+	}
+
+	if direction == device.DirectionTX {
+		return []string{"TX"}
+	}
+
+	return []string{"RX"}
+}
+
+// ListAntennasChecked gets a list of available antennas to select on a given chain, reporting an invalid channel. See
+// ListAntennas() for the details.
+//
+// Return a list of available antenna names and an error if the channel does not exist
+func (dev *Device) ListAntennasChecked(direction device.Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("ListAntennas", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.ListAntennas(direction, channel), nil
+}
+
+// SetAntennas sets the selected antenna on a chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of an available antenna
+//
+// Return an error or nil in case of success
+func (dev *Device) SetAntennas(direction device.Direction, channel uint, name string) (err sdrerror.SDRError) {
+
+	if !contains(dev.ListAntennas(direction, channel), name) {
+		return notSupported()
+	}
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.antenna = name
+	})
+}
+
+// GetAntennas gets the selected antenna on a chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the name of an available antenna
+func (dev *Device) GetAntennas(direction device.Direction, channel uint) string {
+
+	antenna := ""
+	dev.readChannel(direction, channel, func(state *channelState) {
+		antenna = state.antenna
+	})
+
+	return antenna
+}
+
+// GetAntennasChecked gets the selected antenna on a chain, reporting an invalid channel. See GetAntennas() for the
+// details.
+//
+// Return the name of an available antenna and an error if the channel does not exist
+func (dev *Device) GetAntennasChecked(direction device.Direction, channel uint) (antenna string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetAntennas", direction, channel); err != nil {
+		return "", err
+	}
+
+	return dev.GetAntennas(direction, channel), nil
+}
+
+// contains returns if a list of strings contains a value
+func contains(values []string, value string) bool {
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetBandwidth sets the baseband filter width of the chain. The width is clipped to the range given by
+// GetBandwidthRanges().
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - bw: the baseband filter width in Hz
+//
+// Return an error or nil in case of success
+func (dev *Device) SetBandwidth(direction device.Direction, channel uint, bw float64) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.bandwidth = clip(bw, dev.config.BandwidthRange)
+	})
+}
+
+// GetBandwidth gets the baseband filter width of the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the baseband filter width in Hz
+func (dev *Device) GetBandwidth(direction device.Direction, channel uint) float64 {
+
+	bw := 0.0
+	dev.readChannel(direction, channel, func(state *channelState) {
+		bw = state.bandwidth
+	})
+
+	return bw
+}
+
+// GetBandwidthChecked gets the baseband filter width of the chain, reporting an invalid channel. See GetBandwidth() for
+// the details.
+//
+// Return the baseband filter width in Hz and an error if the channel does not exist
+func (dev *Device) GetBandwidthChecked(direction device.Direction, channel uint) (bw float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetBandwidth", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return dev.GetBandwidth(direction, channel), nil
+}
+
+// GetBandwidthRanges gets the range of possible baseband filter widths.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of bandwidth ranges in Hz
+func (dev *Device) GetBandwidthRanges(direction device.Direction, channel uint) []device.SDRRange {
+
+	return append([]device.SDRRange(nil), dev.config.BandwidthRange...)
+}
+
+// GetBandwidthRangesChecked gets the range of possible baseband filter widths, reporting an invalid channel. See
+// GetBandwidthRanges() for the details.
+//
+// Return a list of bandwidth ranges in Hz and an error if the channel does not exist
+func (dev *Device) GetBandwidthRangesChecked(direction device.Direction, channel uint) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetBandwidthRanges", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetBandwidthRanges(direction, channel), nil
+}
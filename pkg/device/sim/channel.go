@@ -0,0 +1,121 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetFrontendMapping sets the frontend mapping of available DSP units to RF frontends. The simulated device only
+// stores the mapping.
+//
+// Params:
+//  - direction: the channel direction DirectionRX or DirectionTX
+//  - mapping: a vendor-specific mapping string
+//
+// Return an error or nil in case of success
+func (dev *Device) SetFrontendMapping(direction device.Direction, mapping string) (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.frontendMapping[direction] = mapping
+
+	return nil
+}
+
+// GetFrontendMapping gets the mapping configuration string.
+//
+// Params:
+//  - direction: the channel direction DirectionRX or DirectionTX
+//
+// Return the vendor-specific mapping string
+func (dev *Device) GetFrontendMapping(direction device.Direction) string {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.frontendMapping[direction]
+}
+
+// GetFrontendMappingChecked gets the mapping configuration string, which never fails on the simulated device. See
+// GetFrontendMapping() for the details.
+//
+// Return the vendor-specific mapping string and a nil error
+func (dev *Device) GetFrontendMappingChecked(direction device.Direction) (mapping string, err sdrerror.SDRError) {
+
+	return dev.GetFrontendMapping(direction), nil
+}
+
+// GetNumChannels gets a number of channels given the streaming direction.
+//
+// Params:
+//  - direction: the channel direction DirectionRX or DirectionTX
+//
+// Return the number of channels
+func (dev *Device) GetNumChannels(direction device.Direction) uint {
+
+	if direction == device.DirectionTX {
+		return dev.config.NumTXChannels
+	}
+
+	return dev.config.NumRXChannels
+}
+
+// GetNumChannelsChecked gets a number of channels given the streaming direction, which never fails on the simulated
+// device. See GetNumChannels() for the details.
+//
+// Return the number of channels and a nil error
+func (dev *Device) GetNumChannelsChecked(direction device.Direction) (nbChannels uint, err sdrerror.SDRError) {
+
+	return dev.GetNumChannels(direction), nil
+}
+
+// GetChannelInfo gets channel info given the streaming direction.
+//
+// Params:
+//  - direction: the channel direction DirectionRX or DirectionTX
+//  - channel: the channel number to get info for
+//
+// Return channel information
+func (dev *Device) GetChannelInfo(direction device.Direction, channel uint) map[string]string {
+
+	return map[string]string{}
+}
+
+// GetChannelInfoChecked gets channel info given the streaming direction, reporting an invalid channel. See
+// GetChannelInfo() for the details.
+//
+// Return channel information and an error if the channel does not exist
+func (dev *Device) GetChannelInfoChecked(direction device.Direction, channel uint) (info map[string]string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetChannelInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetChannelInfo(direction, channel), nil
+}
+
+// GetFullDuplex finds out if the specified channel is full or half duplex. The simulated channels are full duplex.
+//
+// Params:
+//  - direction the channel direction DirectionRX or DirectionTX
+//  - channel an available channel on the device
+//
+// Return true for full duplex, false for half duplex
+func (dev *Device) GetFullDuplex(direction device.Direction, channel uint) bool {
+
+	return true
+}
+
+// GetFullDuplexChecked finds out if the specified channel is full or half duplex, reporting an invalid channel. See
+// GetFullDuplex() for the details.
+//
+// Return true for full duplex, false for half duplex, and an error if the channel does not exist
+func (dev *Device) GetFullDuplexChecked(direction device.Direction, channel uint) (fullDuplex bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetFullDuplex", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.GetFullDuplex(direction, channel), nil
+}
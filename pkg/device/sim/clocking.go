@@ -0,0 +1,117 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetMasterClockRate sets the master clock rate of the device. The rate is clipped to the range given by
+// GetMasterClockRates().
+//
+// Params:
+//  - rate: the clock rate in Hz
+//
+// Return an error or nil in case of success
+func (dev *Device) SetMasterClockRate(rate float64) (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.masterClockRate = clip(rate, dev.config.MasterClockRates)
+
+	return nil
+}
+
+// GetMasterClockRate gets the master clock rate of the device.
+//
+// Return the clock rate in Hz
+func (dev *Device) GetMasterClockRate() float64 {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.masterClockRate
+}
+
+// GetMasterClockRateChecked gets the master clock rate of the device, which never fails on the simulated device. See
+// GetMasterClockRate() for the details.
+//
+// Return the clock rate in Hz and a nil error
+func (dev *Device) GetMasterClockRateChecked() (rate float64, err sdrerror.SDRError) {
+
+	return dev.GetMasterClockRate(), nil
+}
+
+// GetMasterClockRates gets the range of available master clock rates.
+//
+// Return a list of clock rate ranges in Hz
+func (dev *Device) GetMasterClockRates() []device.SDRRange {
+
+	return append([]device.SDRRange(nil), dev.config.MasterClockRates...)
+}
+
+// GetMasterClockRatesChecked gets the range of available master clock rates, which never fails on the simulated device.
+// See GetMasterClockRates() for the details.
+//
+// Return a list of clock rate ranges in Hz and a nil error
+func (dev *Device) GetMasterClockRatesChecked() (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	return dev.GetMasterClockRates(), nil
+}
+
+// ListClockSources gets the list of available clock sources.
+//
+// Return a list of available clock source names
+func (dev *Device) ListClockSources() []string {
+
+	return append([]string(nil), dev.config.ClockSources...)
+}
+
+// ListClockSourcesChecked gets the list of available clock sources, which never fails on the simulated device. See
+// ListClockSources() for the details.
+//
+// Return a list of available clock source names and a nil error
+func (dev *Device) ListClockSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	return dev.ListClockSources(), nil
+}
+
+// SetClockSource set the clock source on the device.
+//
+// Params:
+//  - source: the name of a clock source
+//
+// Return an error or nil in case of success
+func (dev *Device) SetClockSource(source string) (err sdrerror.SDRError) {
+
+	if !contains(dev.config.ClockSources, source) {
+		return notSupported()
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.clockSource = source
+
+	return nil
+}
+
+// GetClockSource gets the clock source of the device.
+//
+// Return the name of a clock source
+func (dev *Device) GetClockSource() string {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.clockSource
+}
+
+// GetClockSourceChecked gets the clock source of the device, which never fails on the simulated device. See
+// GetClockSource() for the details.
+//
+// Return the name of a clock source and a nil error
+func (dev *Device) GetClockSourceChecked() (source string, err sdrerror.SDRError) {
+
+	return dev.GetClockSource(), nil
+}
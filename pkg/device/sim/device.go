@@ -0,0 +1,450 @@
+// Package sim provides a simulated device, implemented in pure Go, honouring the same API as device.SDRDevice.
+//
+// The simulated device does not require any radio nor any SoapySDR module. It keeps its state in memory (frequencies,
+// gains, settings, registers...), reports the ranges given in its configuration and produces synthetic IQ samples
+// (tones, noise, bursts) on its receive streams. Overflows and timeouts can be simulated on the streams, and are
+// reported with the same sdrerror types as a real device.
+//
+// The simulated device is intended to test code depending on the device.Device interface.
+package sim
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync"
+	"time"
+)
+
+// Sensor is a simulated sensor
+type Sensor struct {
+	// Info is the meta-information about the sensor, its key identifies the sensor
+	Info device.SDRArgInfo
+	// Read returns the current value of the sensor. If nil, the value of the sensor is Info.Value.
+	Read func() string
+}
+
+// GainElement is a simulated amplification element
+type GainElement struct {
+	// Name is the name of the element
+	Name string
+	// Range is the range of possible gain values in dB
+	Range device.SDRRange
+}
+
+// Faults describes the faults simulated on the receive streams
+type Faults struct {
+	// OverflowEvery makes every OverflowEvery-th read fail with an Overflow error, the samples of the read being
+	// dropped. 0 disables the simulation of overflows.
+	OverflowEvery uint
+	// TimeoutEvery makes every TimeoutEvery-th read fail with a Timeout error. 0 disables the simulation of timeouts.
+	TimeoutEvery uint
+}
+
+// Config is the configuration of a simulated device. All the fields are optional, zero values being replaced by
+// defaults when the device is created.
+type Config struct {
+	// DriverKey is the key of the driver. Default is "sim".
+	DriverKey string
+	// HardwareKey is the key of the hardware. Default is "sim".
+	HardwareKey string
+	// HardwareInfo is the information about the hardware
+	HardwareInfo map[string]string
+
+	// NumRXChannels is the number of receive channels. Default is 1.
+	NumRXChannels uint
+	// NumTXChannels is the number of transmit channels. Default is 1.
+	NumTXChannels uint
+
+	// Antennas is the list of antennas of every channel. Default is "RX" and "TX" in the respective direction.
+	Antennas []string
+	// FrequencyRange is the range of the "RF" tunable element in Hz. Default is 1MHz to 6GHz.
+	FrequencyRange []device.SDRRange
+	// SampleRateRange is the range of sample rates in samples per second. Default is 250kS/s to 20MS/s.
+	SampleRateRange []device.SDRRange
+	// BandwidthRange is the range of baseband filter widths in Hz. Default is 200kHz to 20MHz.
+	BandwidthRange []device.SDRRange
+	// GainElements is the list of amplification elements, in order RF to baseband. Default is a "LNA" from 0dB to
+	// 40dB and a "VGA" from 0dB to 30dB.
+	GainElements []GainElement
+	// MasterClockRates is the range of master clock rates in Hz. Default is 40MHz.
+	MasterClockRates []device.SDRRange
+	// ClockSources is the list of clock sources. Default is "internal" and "external".
+	ClockSources []string
+	// TimeSources is the list of time sources. Default is "internal" and "external".
+	TimeSources []string
+
+	// Settings describes the global settings. Their value is initialized with the value of the argument info.
+	Settings []device.SDRArgInfo
+	// ChannelSettings describes the settings of every channel. Their value is initialized with the value of the
+	// argument info.
+	ChannelSettings []device.SDRArgInfo
+	// Sensors is the list of global sensors
+	Sensors []Sensor
+	// ChannelSensors is the list of sensors of every channel
+	ChannelSensors []Sensor
+
+	// RegisterInterfaces is the list of register interfaces. Default is "RFIC".
+	RegisterInterfaces []string
+	// GPIOBanks is the list of GPIO banks. Default is "MAIN".
+	GPIOBanks []string
+	// UARTs is the list of UART devices. The UART are loop-backs: the data written can be read back. Default is
+	// "UART0".
+	UARTs []string
+
+	// NativeStreamFormat is the native format of the streams. Default is CF32 with a full scale of 1.0.
+	NativeStreamFormat string
+	// NativeStreamFullScale is the maximum possible value of the native stream format.
+	NativeStreamFullScale float64
+	// MTU is the MTU of the streams in number of elements. Default is 4096.
+	MTU int
+
+	// Source is the signal received on the receive streams. Default is silence.
+	Source Source
+	// Realtime paces the receive streams at the sample rate. When false, the samples are produced as fast as they
+	// are read.
+	Realtime bool
+	// Faults describes the faults simulated on the receive streams.
+	Faults Faults
+	// Transmitted is called with the samples written to a transmit stream, converted to complex128, except the samples
+	// of the bursts dropped because their time had passed. It can be nil.
+	Transmitted func(channel uint, samples []complex128, timeNs uint, flags int)
+}
+
+// channelState is the state of a simulated channel
+type channelState struct {
+	antenna        string
+	frequency      float64
+	sampleRate     float64
+	bandwidth      float64
+	gainMode       bool
+	gains          []float64
+	dcOffsetMode   bool
+	dcOffsetI      float64
+	dcOffsetQ      float64
+	iqBalanceI     float64
+	iqBalanceQ     float64
+	freqCorrection float64
+	settings       map[string]string
+}
+
+// Device is a simulated device. It implements device.Device and can be used concurrently.
+type Device struct {
+	config Config
+
+	mutex           sync.Mutex
+	rxChannels      []*channelState
+	txChannels      []*channelState
+	frontendMapping map[device.Direction]string
+	masterClockRate float64
+	clockSource     string
+	timeSource      string
+	timeOffset      int64
+	timeReference   time.Time
+	settings        map[string]string
+	registers       map[string]map[uint32]uint32
+	gpio            map[string]uint32
+	gpioDir         map[string]uint32
+	i2c             map[int32][]uint8
+	uarts           map[string][]byte
+	unmade          bool
+}
+
+// Ensure Device implements the full Device API
+var _ device.Device = (*Device)(nil)
+
+// New creates a new simulated device. Zero values of the configuration are replaced by defaults.
+//
+// Params:
+//  - config: the configuration of the device
+//
+// Return the simulated device
+func New(config Config) *Device {
+
+	config = withDefaults(config)
+
+	dev := &Device{
+		config:          config,
+		frontendMapping: make(map[device.Direction]string),
+		masterClockRate: config.MasterClockRates[0].Minimum,
+		clockSource:     config.ClockSources[0],
+		timeSource:      config.TimeSources[0],
+		timeReference:   time.Now(),
+		settings:        make(map[string]string),
+		registers:       make(map[string]map[uint32]uint32),
+		gpio:            make(map[string]uint32),
+		gpioDir:         make(map[string]uint32),
+		i2c:             make(map[int32][]uint8),
+		uarts:           make(map[string][]byte),
+	}
+
+	for _, info := range config.Settings {
+		dev.settings[info.Key] = info.Value
+	}
+	for _, name := range config.RegisterInterfaces {
+		dev.registers[name] = make(map[uint32]uint32)
+	}
+
+	dev.rxChannels = make([]*channelState, config.NumRXChannels)
+	for i := range dev.rxChannels {
+		dev.rxChannels[i] = newChannelState(config, "RX")
+	}
+	dev.txChannels = make([]*channelState, config.NumTXChannels)
+	for i := range dev.txChannels {
+		dev.txChannels[i] = newChannelState(config, "TX")
+	}
+
+	return dev
+}
+
+// withDefaults replaces the zero values of a configuration by defaults
+func withDefaults(config Config) Config {
+
+	if config.DriverKey == "" {
+		config.DriverKey = "sim"
+	}
+	if config.HardwareKey == "" {
+		config.HardwareKey = "sim"
+	}
+	if config.HardwareInfo == nil {
+		config.HardwareInfo = map[string]string{"origin": "github.com/pothosware/go-soapy-sdr/pkg/device/sim"}
+	}
+	if config.NumRXChannels == 0 {
+		config.NumRXChannels = 1
+	}
+	if config.NumTXChannels == 0 {
+		config.NumTXChannels = 1
+	}
+	if len(config.FrequencyRange) == 0 {
+		config.FrequencyRange = []device.SDRRange{{Minimum: 1e6, Maximum: 6e9}}
+	}
+	if len(config.SampleRateRange) == 0 {
+		config.SampleRateRange = []device.SDRRange{{Minimum: 250e3, Maximum: 20e6}}
+	}
+	if len(config.BandwidthRange) == 0 {
+		config.BandwidthRange = []device.SDRRange{{Minimum: 200e3, Maximum: 20e6}}
+	}
+	if len(config.GainElements) == 0 {
+		config.GainElements = []GainElement{
+			{Name: "LNA", Range: device.SDRRange{Minimum: 0, Maximum: 40, Step: 1}},
+			{Name: "VGA", Range: device.SDRRange{Minimum: 0, Maximum: 30, Step: 1}},
+		}
+	}
+	if len(config.MasterClockRates) == 0 {
+		config.MasterClockRates = []device.SDRRange{{Minimum: 40e6, Maximum: 40e6}}
+	}
+	if len(config.ClockSources) == 0 {
+		config.ClockSources = []string{"internal", "external"}
+	}
+	if len(config.TimeSources) == 0 {
+		config.TimeSources = []string{"internal", "external"}
+	}
+	if len(config.RegisterInterfaces) == 0 {
+		config.RegisterInterfaces = []string{"RFIC"}
+	}
+	if len(config.GPIOBanks) == 0 {
+		config.GPIOBanks = []string{"MAIN"}
+	}
+	if len(config.UARTs) == 0 {
+		config.UARTs = []string{"UART0"}
+	}
+	if config.NativeStreamFormat == "" {
+		config.NativeStreamFormat = "CF32"
+		config.NativeStreamFullScale = 1.0
+	}
+	if config.MTU <= 0 {
+		config.MTU = 4096
+	}
+	if config.Source == nil {
+		config.Source = Silence{}
+	}
+
+	return config
+}
+
+// newChannelState creates the initial state of a channel
+func newChannelState(config Config, defaultAntenna string) *channelState {
+
+	state := &channelState{
+		antenna:    defaultAntenna,
+		frequency:  clip(100e6, config.FrequencyRange),
+		sampleRate: clip(1e6, config.SampleRateRange),
+		bandwidth:  clip(1e6, config.BandwidthRange),
+		gains:      make([]float64, len(config.GainElements)),
+		iqBalanceI: 1.0,
+		iqBalanceQ: 1.0,
+		settings:   make(map[string]string),
+	}
+	if len(config.Antennas) > 0 {
+		state.antenna = config.Antennas[0]
+	}
+	for i, element := range config.GainElements {
+		state.gains[i] = element.Range.Minimum
+	}
+	for _, info := range config.ChannelSettings {
+		state.settings[info.Key] = info.Value
+	}
+
+	return state
+}
+
+// channel returns the state of a channel, or nil if the channel does not exist. The mutex of the device must be held.
+func (dev *Device) channel(direction device.Direction, channel uint) *channelState {
+
+	channels := dev.rxChannels
+	if direction == device.DirectionTX {
+		channels = dev.txChannels
+	}
+
+	if channel >= uint(len(channels)) {
+		return nil
+	}
+
+	return channels[channel]
+}
+
+// Unmake releases the simulated device. No stream can be set up afterwards.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the device is already released
+func (dev *Device) Unmake() (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	if dev.unmade {
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", "Unmake", "", -1)
+	}
+	dev.unmade = true
+
+	return nil
+}
+
+// clip returns the value in the given ranges closest to value
+func clip(value float64, ranges []device.SDRRange) float64 {
+
+	if len(ranges) == 0 {
+		return value
+	}
+
+	best := ranges[0].Minimum
+	for _, r := range ranges {
+		candidate := value
+		if candidate < r.Minimum {
+			candidate = r.Minimum
+		} else if candidate > r.Maximum {
+			candidate = r.Maximum
+		}
+		if abs(candidate-value) < abs(best-value) {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+// abs returns the absolute value of a float
+func abs(value float64) float64 {
+
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
+
+// notSupported returns the error reported for invalid channels, names or operations
+func notSupported() sdrerror.SDRError {
+
+	return sdrerror.Err(sdrerror.ErrNotSupported.SDRErrorCode())
+}
+
+// checkChannel returns the error reported by a call on a channel which does not exist, or nil if the channel exists
+//
+// Params:
+//  - op: the name of the call
+//  - direction: the channel direction RX or TX
+//  - channel: the channel
+//
+// Return the error or nil
+func (dev *Device) checkChannel(op string, direction device.Direction, channel uint) sdrerror.SDRError {
+
+	if channel < dev.GetNumChannels(direction) {
+		return nil
+	}
+
+	return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("no %v channel %v", direction, channel), op, direction.String(), int(channel))
+}
+
+// checkChannelName returns the error reported by a call on a channel which does not exist, or naming an element which
+// is not available on the channel
+//
+// Params:
+//  - op: the name of the call
+//  - direction: the channel direction RX or TX
+//  - channel: the channel
+//  - names: the names of the available elements
+//  - name: the name given to the call
+//
+// Return the error or nil
+func (dev *Device) checkChannelName(op string, direction device.Direction, channel uint, names []string, name string) sdrerror.SDRError {
+
+	if err := dev.checkChannel(op, direction, channel); err != nil {
+		return err
+	}
+
+	if contains(names, name) {
+		return nil
+	}
+
+	return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("unknown name %q", name), op, direction.String(), int(channel))
+}
+
+// checkName returns the error reported by a call naming an element which is not available
+//
+// Params:
+//  - op: the name of the call
+//  - names: the names of the available elements
+//  - name: the name given to the call
+//
+// Return the error or nil
+func checkName(op string, names []string, name string) sdrerror.SDRError {
+
+	if contains(names, name) {
+		return nil
+	}
+
+	return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("unknown name %q", name), op, "", -1)
+}
+
+// argInfoKeys returns the keys of a list of argument info structures
+func argInfoKeys(infos []device.SDRArgInfo) []string {
+
+	keys := make([]string, len(infos))
+	for i, info := range infos {
+		keys[i] = info.Key
+	}
+
+	return keys
+}
+
+// updateChannel applies a modification to the state of a channel while holding the mutex of the device
+func (dev *Device) updateChannel(direction device.Direction, channel uint, update func(state *channelState)) sdrerror.SDRError {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	state := dev.channel(direction, channel)
+	if state == nil {
+		return notSupported()
+	}
+
+	update(state)
+
+	return nil
+}
+
+// readChannel reads the state of a channel while holding the mutex of the device
+func (dev *Device) readChannel(direction device.Direction, channel uint, read func(state *channelState)) sdrerror.SDRError {
+
+	return dev.updateChannel(direction, channel, read)
+}
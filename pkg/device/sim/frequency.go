@@ -0,0 +1,212 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// frequencyComponentRF is the name of the only tunable element of the simulated channels
+const frequencyComponentRF = "RF"
+
+// SetFrequency sets the center frequency of the chain.
+//
+// The frequency is tuned as close as possible to the requested frequency, within the range given by
+// GetFrequencyRange(). The args are ignored.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - frequency: the center frequency in Hz
+//  - args: optional tuner arguments
+//
+// Return an error or nil in case of success
+func (dev *Device) SetFrequency(direction device.Direction, channel uint, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.frequency = clip(frequency, dev.config.FrequencyRange)
+	})
+}
+
+// SetFrequencyComponent tunes the center frequency of the specified element. Only the "RF" element is available.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of a tunable element
+//  - frequency: the center frequency in Hz
+//  - args: optional tuner arguments
+//
+// Return an error or nil in case of success
+func (dev *Device) SetFrequencyComponent(direction device.Direction, channel uint, name string, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	if name != frequencyComponentRF {
+		return notSupported()
+	}
+
+	return dev.SetFrequency(direction, channel, frequency, args)
+}
+
+// GetFrequency gets the overall center frequency of the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the center frequency in Hz
+func (dev *Device) GetFrequency(direction device.Direction, channel uint) float64 {
+
+	frequency := 0.0
+	dev.readChannel(direction, channel, func(state *channelState) {
+		frequency = state.frequency
+	})
+
+	return frequency
+}
+
+// GetFrequencyChecked gets the overall center frequency of the chain, reporting an invalid channel. See GetFrequency()
+// for the details.
+//
+// Return the center frequency in Hz and an error if the channel does not exist
+func (dev *Device) GetFrequencyChecked(direction device.Direction, channel uint) (frequency float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetFrequency", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return dev.GetFrequency(direction, channel), nil
+}
+
+// GetFrequencyComponent gets the frequency of a tunable element in the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of a tunable element
+//
+// Return the tunable element's frequency in Hz
+func (dev *Device) GetFrequencyComponent(direction device.Direction, channel uint, name string) float64 {
+
+	if name != frequencyComponentRF {
+		return 0
+	}
+
+	return dev.GetFrequency(direction, channel)
+}
+
+// GetFrequencyComponentChecked gets the frequency of a tunable element in the chain, reporting an invalid channel or an
+// unknown name. See GetFrequencyComponent() for the details.
+//
+// Return the tunable element's frequency in Hz and an error if the channel or the name is not available
+func (dev *Device) GetFrequencyComponentChecked(direction device.Direction, channel uint, name string) (frequency float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("GetFrequencyComponent", direction, channel, dev.ListFrequencies(direction, channel), name); err != nil {
+		return 0, err
+	}
+
+	return dev.GetFrequencyComponent(direction, channel, name), nil
+}
+
+// ListFrequencies lists available tunable elements in the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return a list of tunable elements by name
+func (dev *Device) ListFrequencies(direction device.Direction, channel uint) []string {
+
+	return []string{frequencyComponentRF}
+}
+
+// ListFrequenciesChecked lists available tunable elements in the chain, reporting an invalid channel. See
+// ListFrequencies() for the details.
+//
+// Return a list of tunable elements by name and an error if the channel does not exist
+func (dev *Device) ListFrequenciesChecked(direction device.Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("ListFrequencies", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.ListFrequencies(direction, channel), nil
+}
+
+// GetFrequencyRange gets the range of overall frequency values.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return a list of frequency ranges in Hz
+func (dev *Device) GetFrequencyRange(direction device.Direction, channel uint) []device.SDRRange {
+
+	return append([]device.SDRRange(nil), dev.config.FrequencyRange...)
+}
+
+// GetFrequencyRangeChecked gets the range of overall frequency values, reporting an invalid channel. See
+// GetFrequencyRange() for the details.
+//
+// Return a list of frequency ranges in Hz and an error if the channel does not exist
+func (dev *Device) GetFrequencyRangeChecked(direction device.Direction, channel uint) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetFrequencyRange", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetFrequencyRange(direction, channel), nil
+}
+
+// GetFrequencyRangeComponent gets the range of tunable values for the specified element.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of a tunable element
+//
+// Return a list of frequency ranges in Hz
+func (dev *Device) GetFrequencyRangeComponent(direction device.Direction, channel uint, name string) []device.SDRRange {
+
+	if name != frequencyComponentRF {
+		return []device.SDRRange{}
+	}
+
+	return dev.GetFrequencyRange(direction, channel)
+}
+
+// GetFrequencyRangeComponentChecked gets the range of tunable values for the specified element, reporting an invalid
+// channel or an unknown name. See GetFrequencyRangeComponent() for the details.
+//
+// Return a list of frequency ranges in Hz and an error if the channel or the name is not available
+func (dev *Device) GetFrequencyRangeComponentChecked(direction device.Direction, channel uint, name string) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("GetFrequencyRangeComponent", direction, channel, dev.ListFrequencies(direction, channel), name); err != nil {
+		return nil, err
+	}
+
+	return dev.GetFrequencyRangeComponent(direction, channel, name), nil
+}
+
+// GetFrequencyArgsInfo queries the argument info description for tune args. The simulated device has no tune args.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of argument info structures
+func (dev *Device) GetFrequencyArgsInfo(direction device.Direction, channel uint) []device.SDRArgInfo {
+
+	return []device.SDRArgInfo{}
+}
+
+// GetFrequencyArgsInfoChecked queries the argument info description for tune args, reporting an invalid channel. See
+// GetFrequencyArgsInfo() for the details.
+//
+// Return a list of argument info structures and an error if the channel does not exist
+func (dev *Device) GetFrequencyArgsInfoChecked(direction device.Direction, channel uint) (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetFrequencyArgsInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetFrequencyArgsInfo(direction, channel), nil
+}
@@ -0,0 +1,268 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// HasDCOffsetMode returns if the device support automatic DC offset corrections. The simulated device supports it.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true if the device has automatic DC offset corrections, false otherwise
+func (dev *Device) HasDCOffsetMode(direction device.Direction, channel uint) bool {
+
+	return dev.GetNumChannels(direction) > channel
+}
+
+// HasDCOffsetModeChecked returns if the device support automatic DC offset corrections, reporting an invalid channel.
+// See HasDCOffsetMode() for the details.
+//
+// Return true if the device has automatic DC offset corrections, false otherwise, and an error if the channel does not
+// exist
+func (dev *Device) HasDCOffsetModeChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("HasDCOffsetMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.HasDCOffsetMode(direction, channel), nil
+}
+
+// SetDCOffsetMode sets the automatic DC offset corrections mode.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//  - automatic: true for automatic offset correction
+//
+// Return an error or nil in case of success
+func (dev *Device) SetDCOffsetMode(direction device.Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.dcOffsetMode = automatic
+	})
+}
+
+// GetDCOffsetMode gets the automatic DC offset corrections mode.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true for automatic offset correction
+func (dev *Device) GetDCOffsetMode(direction device.Direction, channel uint) bool {
+
+	automatic := false
+	dev.readChannel(direction, channel, func(state *channelState) {
+		automatic = state.dcOffsetMode
+	})
+
+	return automatic
+}
+
+// GetDCOffsetModeChecked gets the automatic DC offset corrections mode, reporting an invalid channel. See
+// GetDCOffsetMode() for the details.
+//
+// Return true for automatic offset correction and an error if the channel does not exist
+func (dev *Device) GetDCOffsetModeChecked(direction device.Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetDCOffsetMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.GetDCOffsetMode(direction, channel), nil
+}
+
+// HasDCOffset returns if the device support frontend DC offset correction. The simulated device supports it.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true if the device supports frontend DC offset correction, false otherwise
+func (dev *Device) HasDCOffset(direction device.Direction, channel uint) bool {
+
+	return dev.GetNumChannels(direction) > channel
+}
+
+// HasDCOffsetChecked returns if the device support frontend DC offset correction, reporting an invalid channel. See
+// HasDCOffset() for the details.
+//
+// Return true if the device supports frontend DC offset correction, false otherwise, and an error if the channel does
+// not exist
+func (dev *Device) HasDCOffsetChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("HasDCOffset", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.HasDCOffset(direction, channel), nil
+}
+
+// SetDCOffset sets the frontend DC offset correction.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//  - offsetI: the relative correction (1.0 max)
+//  - offsetQ: the relative correction (1.0 max)
+//
+// Return an error or nil in case of success
+func (dev *Device) SetDCOffset(direction device.Direction, channel uint, offsetI float64, offsetQ float64) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.dcOffsetI = offsetI
+		state.dcOffsetQ = offsetQ
+	})
+}
+
+// GetDCOffset gets frontend DC offset correction.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return offsetI and offsetQ the relative correction (1.0 max) and an optional error
+func (dev *Device) GetDCOffset(direction device.Direction, channel uint) (offsetI float64, offsetQ float64, err sdrerror.SDRError) {
+
+	err = dev.readChannel(direction, channel, func(state *channelState) {
+		offsetI = state.dcOffsetI
+		offsetQ = state.dcOffsetQ
+	})
+
+	return offsetI, offsetQ, err
+}
+
+// HasIQBalance returns if the device support frontend IQ balance correction. The simulated device supports it.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true if the device supports frontend IQ balance correction, false otherwise
+func (dev *Device) HasIQBalance(direction device.Direction, channel uint) bool {
+
+	return dev.GetNumChannels(direction) > channel
+}
+
+// HasIQBalanceChecked returns if the device support frontend IQ balance correction, reporting an invalid channel. See
+// HasIQBalance() for the details.
+//
+// Return true if the device supports frontend IQ balance correction, false otherwise, and an error if the channel does
+// not exist
+func (dev *Device) HasIQBalanceChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("HasIQBalance", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.HasIQBalance(direction, channel), nil
+}
+
+// SetIQBalance sets the frontend IQ balance correction.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//  - balanceI: the relative correction (1.0 max)
+//  - balanceQ: the relative correction (1.0 max)
+//
+// Return an error or nil in case of success
+func (dev *Device) SetIQBalance(direction device.Direction, channel uint, balanceI float64, balanceQ float64) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.iqBalanceI = balanceI
+		state.iqBalanceQ = balanceQ
+	})
+}
+
+// GetIQBalance gets the IQ balance correction.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return balanceI and balanceQ the relative correction (1.0 max) and an optional error
+func (dev *Device) GetIQBalance(direction device.Direction, channel uint) (balanceI float64, balanceQ float64, err sdrerror.SDRError) {
+
+	err = dev.readChannel(direction, channel, func(state *channelState) {
+		balanceI = state.iqBalanceI
+		balanceQ = state.iqBalanceQ
+	})
+
+	return balanceI, balanceQ, err
+}
+
+// HasFrequencyCorrection returns if the device support frontend frequency correction. The simulated device supports
+// it.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true if the device supports frontend frequency correction, false otherwise
+func (dev *Device) HasFrequencyCorrection(direction device.Direction, channel uint) bool {
+
+	return dev.GetNumChannels(direction) > channel
+}
+
+// HasFrequencyCorrectionChecked returns if the device support frontend frequency correction, reporting an invalid
+// channel. See HasFrequencyCorrection() for the details.
+//
+// Return true if the device supports frontend frequency correction, false otherwise, and an error if the channel does
+// not exist
+func (dev *Device) HasFrequencyCorrectionChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("HasFrequencyCorrection", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.HasFrequencyCorrection(direction, channel), nil
+}
+
+// SetFrequencyCorrection fine tunes the frontend frequency correction.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//  - value: the correction in PPM
+//
+// Return an error or nil in case of success
+func (dev *Device) SetFrequencyCorrection(direction device.Direction, channel uint, value float64) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.freqCorrection = value
+	})
+}
+
+// GetFrequencyCorrection gets the frontend frequency correction value.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return the correction value in PPM
+func (dev *Device) GetFrequencyCorrection(direction device.Direction, channel uint) (value float64) {
+
+	dev.readChannel(direction, channel, func(state *channelState) {
+		value = state.freqCorrection
+	})
+
+	return value
+}
+
+// GetFrequencyCorrectionChecked gets the frontend frequency correction value, reporting an invalid channel. See
+// GetFrequencyCorrection() for the details.
+//
+// Return the correction value in PPM and an error if the channel does not exist
+func (dev *Device) GetFrequencyCorrectionChecked(direction device.Direction, channel uint) (value float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetFrequencyCorrection", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return dev.GetFrequencyCorrection(direction, channel), nil
+}
@@ -0,0 +1,298 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// ListGains lists available amplification elements.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return a list of gain string names
+func (dev *Device) ListGains(direction device.Direction, channel uint) []string {
+
+	names := make([]string, len(dev.config.GainElements))
+	for i, element := range dev.config.GainElements {
+		names[i] = element.Name
+	}
+
+	return names
+}
+
+// ListGainsChecked lists available amplification elements, reporting an invalid channel. See ListGains() for the
+// details.
+//
+// Return a list of gain string names and an error if the channel does not exist
+func (dev *Device) ListGainsChecked(direction device.Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("ListGains", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.ListGains(direction, channel), nil
+}
+
+// HasGainMode returns if the device support automatic gain control. The simulated device supports it.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true for automatic gain control
+func (dev *Device) HasGainMode(direction device.Direction, channel uint) bool {
+
+	return dev.GetNumChannels(direction) > channel
+}
+
+// HasGainModeChecked returns if the device support automatic gain control, reporting an invalid channel. See
+// HasGainMode() for the details.
+//
+// Return true for automatic gain control and an error if the channel does not exist
+func (dev *Device) HasGainModeChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("HasGainMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.HasGainMode(direction, channel), nil
+}
+
+// SetGainMode sets the automatic gain mode on the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//  - automatic: true for automatic gain setting
+//
+// Return an error or nil in case of success
+func (dev *Device) SetGainMode(direction device.Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.gainMode = automatic
+	})
+}
+
+// GetGainMode gets the automatic gain mode on the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel
+//
+// Return true for automatic gain setting
+func (dev *Device) GetGainMode(direction device.Direction, channel uint) bool {
+
+	automatic := false
+	dev.readChannel(direction, channel, func(state *channelState) {
+		automatic = state.gainMode
+	})
+
+	return automatic
+}
+
+// GetGainModeChecked gets the automatic gain mode on the chain, reporting an invalid channel. See GetGainMode() for the
+// details.
+//
+// Return true for automatic gain setting and an error if the channel does not exist
+func (dev *Device) GetGainModeChecked(direction device.Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetGainMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return dev.GetGainMode(direction, channel), nil
+}
+
+// SetGain sets the overall amplification in a chain.
+//
+// The gain is clipped to the range given by GetGainRange() and distributed across the elements, in order RF to
+// baseband.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - value: the new amplification value in dB
+//
+// Return an error or nil in case of success
+func (dev *Device) SetGain(direction device.Direction, channel uint, gain float64) (err sdrerror.SDRError) {
+
+	overall := dev.GetGainRange(direction, channel)
+	remaining := clip(gain, []device.SDRRange{overall}) - overall.Minimum
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		for i, element := range dev.config.GainElements {
+			value := clip(element.Range.Minimum+remaining, []device.SDRRange{element.Range})
+			remaining -= value - element.Range.Minimum
+			state.gains[i] = value
+		}
+	})
+}
+
+// SetGainElement sets the value of a amplification element in a chain. The value is clipped to the range of the
+// element.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of an amplification element
+//  - value: the new amplification value in dB
+//
+// Return an error or nil in case of success
+func (dev *Device) SetGainElement(direction device.Direction, channel uint, name string, gain float64) (err sdrerror.SDRError) {
+
+	idx := dev.gainElement(name)
+	if idx < 0 {
+		return notSupported()
+	}
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.gains[idx] = clip(gain, []device.SDRRange{dev.config.GainElements[idx].Range})
+	})
+}
+
+// GetGain gets the overall value of the gain elements in a chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the value of the gain in dB
+func (dev *Device) GetGain(direction device.Direction, channel uint) float64 {
+
+	gain := 0.0
+	dev.readChannel(direction, channel, func(state *channelState) {
+		for _, value := range state.gains {
+			gain += value
+		}
+	})
+
+	return gain
+}
+
+// GetGainChecked gets the overall value of the gain elements in a chain, reporting an invalid channel. See GetGain()
+// for the details.
+//
+// Return the value of the gain in dB and an error if the channel does not exist
+func (dev *Device) GetGainChecked(direction device.Direction, channel uint) (gain float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetGain", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return dev.GetGain(direction, channel), nil
+}
+
+// GetGainElement gets the value of an individual amplification element in a chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of an amplification element
+//
+// Return the value of the gain in dB
+func (dev *Device) GetGainElement(direction device.Direction, channel uint, name string) float64 {
+
+	idx := dev.gainElement(name)
+	if idx < 0 {
+		return 0
+	}
+
+	gain := 0.0
+	dev.readChannel(direction, channel, func(state *channelState) {
+		gain = state.gains[idx]
+	})
+
+	return gain
+}
+
+// GetGainElementChecked gets the value of an individual amplification element in a chain, reporting an invalid channel
+// or an unknown name. See GetGainElement() for the details.
+//
+// Return the value of the gain in dB and an error if the channel or the name is not available
+func (dev *Device) GetGainElementChecked(direction device.Direction, channel uint, name string) (gain float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("GetGainElement", direction, channel, dev.ListGains(direction, channel), name); err != nil {
+		return 0, err
+	}
+
+	return dev.GetGainElement(direction, channel, name), nil
+}
+
+// GetGainRange gets the overall range of possible gain values, which is the sum of the ranges of the elements.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of gain ranges in dB
+func (dev *Device) GetGainRange(direction device.Direction, channel uint) device.SDRRange {
+
+	overall := device.SDRRange{}
+	for _, element := range dev.config.GainElements {
+		overall.Minimum += element.Range.Minimum
+		overall.Maximum += element.Range.Maximum
+		if overall.Step == 0 || (element.Range.Step > 0 && element.Range.Step < overall.Step) {
+			overall.Step = element.Range.Step
+		}
+	}
+
+	return overall
+}
+
+// GetGainRangeChecked gets the overall range of possible gain values, which is the sum of the ranges of the elements,
+// reporting an invalid channel. See GetGainRange() for the details.
+//
+// Return a list of gain ranges in dB and an error if the channel does not exist
+func (dev *Device) GetGainRangeChecked(direction device.Direction, channel uint) (gainRange device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetGainRange", direction, channel); err != nil {
+		return device.SDRRange{}, err
+	}
+
+	return dev.GetGainRange(direction, channel), nil
+}
+
+// GetGainElementRange gets the range of possible gain values for a specific element.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - name: the name of an amplification element
+//
+// Return a list of gain ranges in dB
+func (dev *Device) GetGainElementRange(direction device.Direction, channel uint, name string) device.SDRRange {
+
+	idx := dev.gainElement(name)
+	if idx < 0 {
+		return device.SDRRange{}
+	}
+
+	return dev.config.GainElements[idx].Range
+}
+
+// GetGainElementRangeChecked gets the range of possible gain values for a specific element, reporting an invalid
+// channel or an unknown name. See GetGainElementRange() for the details.
+//
+// Return a list of gain ranges in dB and an error if the channel or the name is not available
+func (dev *Device) GetGainElementRangeChecked(direction device.Direction, channel uint, name string) (gainRange device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("GetGainElementRange", direction, channel, dev.ListGains(direction, channel), name); err != nil {
+		return device.SDRRange{}, err
+	}
+
+	return dev.GetGainElementRange(direction, channel, name), nil
+}
+
+// gainElement returns the index of a gain element, or -1 if the element does not exist
+func (dev *Device) gainElement(name string) int {
+
+	for i, element := range dev.config.GainElements {
+		if element.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
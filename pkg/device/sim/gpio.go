@@ -0,0 +1,139 @@
+package sim
+
+import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+
+// ListGPIOBanks gets a list of available GPIO banks by name.
+//
+// Return a list of available GPIO banks
+func (dev *Device) ListGPIOBanks() []string {
+
+	return append([]string(nil), dev.config.GPIOBanks...)
+}
+
+// ListGPIOBanksChecked gets a list of available GPIO banks by name, which never fails on the simulated device. See
+// ListGPIOBanks() for the details.
+//
+// Return a list of available GPIO banks and a nil error
+func (dev *Device) ListGPIOBanksChecked() (banks []string, err sdrerror.SDRError) {
+
+	return dev.ListGPIOBanks(), nil
+}
+
+// WriteGPIO writes the value of a GPIO bank.
+//
+// Params:
+//  - bank: the name of an available bank
+//  - value: an integer representing GPIO bits
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteGPIO(bank string, value uint32) (err sdrerror.SDRError) {
+
+	return dev.WriteGPIOMasked(bank, value, 0xffffffff)
+}
+
+// WriteGPIOMasked writes the value of a GPIO bank with modification mask.
+//
+// Params:
+//  - bank: the name of an available bank
+//  - value: an integer representing GPIO bits
+//  - mask: a modification mask where 1 = modify
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteGPIOMasked(bank string, value uint32, mask uint32) (err sdrerror.SDRError) {
+
+	return dev.writeBank(dev.gpio, bank, value, mask)
+}
+
+// ReadGPIO readbacks the value of a GPIO bank.
+//
+// Params:
+//  - bank: the name of an available bank
+//
+// Return an integer representing GPIO bits
+func (dev *Device) ReadGPIO(bank string) uint32 {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.gpio[bank]
+}
+
+// ReadGPIOChecked readbacks the value of a GPIO bank, reporting an unknown name. See ReadGPIO() for the details.
+//
+// Return an integer representing GPIO bits and an error if the name is not available
+func (dev *Device) ReadGPIOChecked(bank string) (value uint32, err sdrerror.SDRError) {
+
+	if err := checkName("ReadGPIO", dev.ListGPIOBanks(), bank); err != nil {
+		return 0, err
+	}
+
+	return dev.ReadGPIO(bank), nil
+}
+
+// WriteGPIODir writes the data direction of a GPIO bank. 1 bits represent outputs, 0 bits represent inputs.
+//
+// Params:
+//  - bank: the name of an available bank
+//  - dir: an integer representing data direction bits
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteGPIODir(bank string, dir uint32) (err sdrerror.SDRError) {
+
+	return dev.WriteGPIODirMasked(bank, dir, 0xffffffff)
+}
+
+// WriteGPIODirMasked writes the data direction of a GPIO bank with modification mask. 1 bits represent outputs, 0
+// bits represent inputs.
+//
+// Params:
+//  - bank: the name of an available bank
+//  - dir: an integer representing data direction bits
+//  - mask: a modification mask where 1 = modify
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteGPIODirMasked(bank string, dir uint32, mask uint32) (err sdrerror.SDRError) {
+
+	return dev.writeBank(dev.gpioDir, bank, dir, mask)
+}
+
+// ReadGPIODir reads the data direction of a GPIO bank.
+//
+// Params:
+//  - bank: the name of an available bank
+//
+// Return an integer representing data direction bits
+func (dev *Device) ReadGPIODir(bank string) uint32 {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.gpioDir[bank]
+}
+
+// ReadGPIODirChecked reads the data direction of a GPIO bank, reporting an unknown name. See ReadGPIODir() for the
+// details.
+//
+// Return an integer representing data direction bits and an error if the name is not available
+func (dev *Device) ReadGPIODirChecked(bank string) (dir uint32, err sdrerror.SDRError) {
+
+	if err := checkName("ReadGPIODir", dev.ListGPIOBanks(), bank); err != nil {
+		return 0, err
+	}
+
+	return dev.ReadGPIODir(bank), nil
+}
+
+// writeBank writes the masked bits of a bank in the given bank values
+func (dev *Device) writeBank(banks map[string]uint32, bank string, value uint32, mask uint32) sdrerror.SDRError {
+
+	if !contains(dev.config.GPIOBanks, bank) {
+		return notSupported()
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	banks[bank] = (banks[bank] &^ mask) | (value & mask)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package sim
+
+import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+
+// WriteI2C writes to an available I2C slave. The simulated slaves are loop-backs: the bytes written can be read back
+// with ReadI2C().
+//
+// Params:
+//  - addr: the address of the slave
+//  - data: an array of bytes write out
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteI2C(addr int32, data []uint8) (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.i2c[addr] = append(dev.i2c[addr], data...)
+
+	return nil
+}
+
+// ReadI2C reads from an available I2C slave. The bytes previously written to the slave are returned, the missing bytes
+// being 0.
+//
+// Params:
+//  - addr: the address of the slave
+//  - numBytes: the number of bytes to read
+//
+// Return an array of bytes read from the slave
+func (dev *Device) ReadI2C(addr int32, numBytes uint) (data []uint8) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	data = make([]uint8, numBytes)
+	n := copy(data, dev.i2c[addr])
+	dev.i2c[addr] = dev.i2c[addr][n:]
+
+	return data
+}
+
+// ReadI2CChecked reads from an available I2C slave, which never fails on the simulated device. See ReadI2C() for the
+// details.
+//
+// Return an array of bytes read from the slave and a nil error
+func (dev *Device) ReadI2CChecked(addr int32, numBytes uint) (data []uint8, err sdrerror.SDRError) {
+
+	return dev.ReadI2C(addr, numBytes), nil
+}
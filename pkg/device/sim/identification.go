@@ -0,0 +1,53 @@
+package sim
+
+import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+
+// GetDriverKey returns a key that uniquely identifies the device driver.
+func (dev *Device) GetDriverKey() (driverKey string) {
+
+	return dev.config.DriverKey
+}
+
+// GetDriverKeyChecked returns a key that uniquely identifies the device driver, which never fails on the simulated
+// device. See GetDriverKey() for the details.
+//
+// Return the key of the driver and a nil error
+func (dev *Device) GetDriverKeyChecked() (driverKey string, err sdrerror.SDRError) {
+
+	return dev.GetDriverKey(), nil
+}
+
+// GetHardwareKey returns a key that uniquely identifies the hardware.
+func (dev *Device) GetHardwareKey() (hardwareKey string) {
+
+	return dev.config.HardwareKey
+}
+
+// GetHardwareKeyChecked returns a key that uniquely identifies the hardware, which never fails on the simulated device.
+// See GetHardwareKey() for the details.
+//
+// Return the key of the hardware and a nil error
+func (dev *Device) GetHardwareKeyChecked() (hardwareKey string, err sdrerror.SDRError) {
+
+	return dev.GetHardwareKey(), nil
+}
+
+// GetHardwareInfo queries a dictionary of available device information.
+func (dev *Device) GetHardwareInfo() (hardwareInfo map[string]string) {
+
+	hardwareInfo = make(map[string]string, len(dev.config.HardwareInfo))
+	for k, v := range dev.config.HardwareInfo {
+		hardwareInfo[k] = v
+	}
+
+	return hardwareInfo
+}
+
+// GetHardwareInfoChecked queries a dictionary of available device information, which never fails on the simulated
+// device. See GetHardwareInfo() for the details.
+//
+// Return the information about the device and a nil error
+func (dev *Device) GetHardwareInfoChecked() (hardwareInfo map[string]string, err sdrerror.SDRError) {
+
+	return dev.GetHardwareInfo(), nil
+}
@@ -0,0 +1,120 @@
+package sim
+
+import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+
+// ListRegisterInterfaces gets a list of available register interfaces by name.
+//
+// Return a list of available register interfaces
+func (dev *Device) ListRegisterInterfaces() []string {
+
+	return append([]string(nil), dev.config.RegisterInterfaces...)
+}
+
+// ListRegisterInterfacesChecked gets a list of available register interfaces by name, which never fails on the
+// simulated device. See ListRegisterInterfaces() for the details.
+//
+// Return a list of available register interfaces and a nil error
+func (dev *Device) ListRegisterInterfacesChecked() (names []string, err sdrerror.SDRError) {
+
+	return dev.ListRegisterInterfaces(), nil
+}
+
+// WriteRegister writes a register on the device given the interface name. The simulated registers are plain memory
+// cells, initialized to 0.
+//
+// Params:
+//  - name: the name of a available register interface
+//  - addr: the register address
+//  - value: the register value
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteRegister(name string, addr uint32, value uint32) (err sdrerror.SDRError) {
+
+	return dev.WriteRegisters(name, addr, []uint32{value})
+}
+
+// ReadRegister reads a register on the device given the interface name.
+//
+// Params:
+//  - name: the name of a available register interface
+//  - addr: the register address
+//
+// Return the register value
+func (dev *Device) ReadRegister(name string, addr uint32) uint32 {
+
+	return dev.ReadRegisters(name, addr, 1)[0]
+}
+
+// ReadRegisterChecked reads a register on the device given the interface name, reporting an unknown name. See
+// ReadRegister() for the details.
+//
+// Return the register value and an error if the name is not available
+func (dev *Device) ReadRegisterChecked(name string, addr uint32) (value uint32, err sdrerror.SDRError) {
+
+	if err := checkName("ReadRegister", dev.ListRegisterInterfaces(), name); err != nil {
+		return 0, err
+	}
+
+	return dev.ReadRegister(name, addr), nil
+}
+
+// WriteRegisters writes a memory block on the device given the interface name.
+//
+// Params:
+//  - name: the name of a available memory block interface
+//  - addr: the memory block start address
+//  - value: the memory block content
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteRegisters(name string, addr uint32, value []uint32) (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	registers, found := dev.registers[name]
+	if !found {
+		return notSupported()
+	}
+
+	for i, v := range value {
+		registers[addr+uint32(i)] = v
+	}
+
+	return nil
+}
+
+// ReadRegisters reads a memory block on the device given the interface name.
+//
+// Params:
+//  - name: the name of a available memory block interface
+//  - addr: the memory block start address
+//  - length: number of 32-bit words to be read from memory block
+//
+// Return the memory block content
+func (dev *Device) ReadRegisters(name string, addr uint32, length uint) []uint32 {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	value := make([]uint32, length)
+	if registers, found := dev.registers[name]; found {
+		for i := range value {
+			value[i] = registers[addr+uint32(i)]
+		}
+	}
+
+	return value
+}
+
+// ReadRegistersChecked reads a memory block on the device given the interface name, reporting an unknown name. See
+// ReadRegisters() for the details.
+//
+// Return the memory block content and an error if the name is not available
+func (dev *Device) ReadRegistersChecked(name string, addr uint32, length uint) (values []uint32, err sdrerror.SDRError) {
+
+	if err := checkName("ReadRegisters", dev.ListRegisterInterfaces(), name); err != nil {
+		return nil, err
+	}
+
+	return dev.ReadRegisters(name, addr, length), nil
+}
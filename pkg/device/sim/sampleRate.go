@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetSampleRate sets the baseband sample rate of the chain. The rate is clipped to the range given by
+// GetSampleRateRange().
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - rate: the sample rate in samples per second
+//
+// Return an error or nil in case of success
+func (dev *Device) SetSampleRate(direction device.Direction, channel uint, rate float64) (err sdrerror.SDRError) {
+
+	return dev.updateChannel(direction, channel, func(state *channelState) {
+		state.sampleRate = clip(rate, dev.config.SampleRateRange)
+	})
+}
+
+// GetSampleRate gets the baseband sample rate of the chain.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the sample rate in samples per second
+func (dev *Device) GetSampleRate(direction device.Direction, channel uint) float64 {
+
+	rate := 0.0
+	dev.readChannel(direction, channel, func(state *channelState) {
+		rate = state.sampleRate
+	})
+
+	return rate
+}
+
+// GetSampleRateChecked gets the baseband sample rate of the chain, reporting an invalid channel. See GetSampleRate()
+// for the details.
+//
+// Return the sample rate in samples per second and an error if the channel does not exist
+func (dev *Device) GetSampleRateChecked(direction device.Direction, channel uint) (rate float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetSampleRate", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return dev.GetSampleRate(direction, channel), nil
+}
+
+// GetSampleRateRange gets the range of possible baseband sample rates.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of sample rate ranges in samples per second
+func (dev *Device) GetSampleRateRange(direction device.Direction, channel uint) []device.SDRRange {
+
+	return append([]device.SDRRange(nil), dev.config.SampleRateRange...)
+}
+
+// GetSampleRateRangeChecked gets the range of possible baseband sample rates, reporting an invalid channel. See
+// GetSampleRateRange() for the details.
+//
+// Return a list of sample rate ranges in samples per second and an error if the channel does not exist
+func (dev *Device) GetSampleRateRangeChecked(direction device.Direction, channel uint) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetSampleRateRange", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetSampleRateRange(direction, channel), nil
+}
@@ -0,0 +1,194 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// ListSensors gets a list of the available global readable sensors.
+//
+// Return a list of available sensor string names
+func (dev *Device) ListSensors() []string {
+
+	return sensorKeys(dev.config.Sensors)
+}
+
+// ListSensorsChecked gets a list of the available global readable sensors, which never fails on the simulated device.
+// See ListSensors() for the details.
+//
+// Return a list of available sensor string names and a nil error
+func (dev *Device) ListSensorsChecked() (keys []string, err sdrerror.SDRError) {
+
+	return dev.ListSensors(), nil
+}
+
+// GetSensorInfo gets meta-information about a sensor.
+//
+// Params:
+//  - key: the ID name of an available sensor
+//
+// Return meta-information about a sensor
+func (dev *Device) GetSensorInfo(key string) device.SDRArgInfo {
+
+	sensor := findSensor(dev.config.Sensors, key)
+	if sensor == nil {
+		return device.SDRArgInfo{}
+	}
+
+	return sensor.Info
+}
+
+// GetSensorInfoChecked gets meta-information about a sensor, reporting an unknown name. See GetSensorInfo() for the
+// details.
+//
+// Return meta-information about a sensor and an error if the name is not available
+func (dev *Device) GetSensorInfoChecked(key string) (info device.SDRArgInfo, err sdrerror.SDRError) {
+
+	if err := checkName("GetSensorInfo", dev.ListSensors(), key); err != nil {
+		return device.SDRArgInfo{}, err
+	}
+
+	return dev.GetSensorInfo(key), nil
+}
+
+// ReadSensor reads a global sensor given the name.
+//
+// Params:
+//  - key: the ID name of an available sensor
+//
+// Return the current value of the sensor
+func (dev *Device) ReadSensor(key string) string {
+
+	return readSensor(findSensor(dev.config.Sensors, key))
+}
+
+// ReadSensorChecked reads a global sensor given the name, reporting an unknown name. See ReadSensor() for the details.
+//
+// Return the current value of the sensor and an error if the name is not available
+func (dev *Device) ReadSensorChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if err := checkName("ReadSensor", dev.ListSensors(), key); err != nil {
+		return "", err
+	}
+
+	return dev.ReadSensor(key), nil
+}
+
+// ListChannelSensors gets a list of the available channel readable sensors.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of available sensor string names
+func (dev *Device) ListChannelSensors(direction device.Direction, channel uint) []string {
+
+	return sensorKeys(dev.config.ChannelSensors)
+}
+
+// ListChannelSensorsChecked gets a list of the available channel readable sensors, reporting an invalid channel. See
+// ListChannelSensors() for the details.
+//
+// Return a list of available sensor string names and an error if the channel does not exist
+func (dev *Device) ListChannelSensorsChecked(direction device.Direction, channel uint) (keys []string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("ListChannelSensors", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.ListChannelSensors(direction, channel), nil
+}
+
+// GetChannelSensorInfo gets meta-information about a channel sensor.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - key: the ID name of an available sensor
+//
+// Return meta-information about a sensor
+func (dev *Device) GetChannelSensorInfo(direction device.Direction, channel uint, key string) device.SDRArgInfo {
+
+	sensor := findSensor(dev.config.ChannelSensors, key)
+	if sensor == nil {
+		return device.SDRArgInfo{}
+	}
+
+	return sensor.Info
+}
+
+// GetChannelSensorInfoChecked gets meta-information about a channel sensor, reporting an invalid channel or an unknown
+// name. See GetChannelSensorInfo() for the details.
+//
+// Return meta-information about a sensor and an error if the channel or the name is not available
+func (dev *Device) GetChannelSensorInfoChecked(direction device.Direction, channel uint, key string) (info device.SDRArgInfo, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("GetChannelSensorInfo", direction, channel, dev.ListChannelSensors(direction, channel), key); err != nil {
+		return device.SDRArgInfo{}, err
+	}
+
+	return dev.GetChannelSensorInfo(direction, channel, key), nil
+}
+
+// ReadChannelSensor reads a channel sensor given the name.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - key: the ID name of an available sensor
+//
+// Return the current value of the sensor
+func (dev *Device) ReadChannelSensor(direction device.Direction, channel uint, key string) string {
+
+	return readSensor(findSensor(dev.config.ChannelSensors, key))
+}
+
+// ReadChannelSensorChecked reads a channel sensor given the name, reporting an invalid channel or an unknown name. See
+// ReadChannelSensor() for the details.
+//
+// Return the current value of the sensor and an error if the channel or the name is not available
+func (dev *Device) ReadChannelSensorChecked(direction device.Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("ReadChannelSensor", direction, channel, dev.ListChannelSensors(direction, channel), key); err != nil {
+		return "", err
+	}
+
+	return dev.ReadChannelSensor(direction, channel, key), nil
+}
+
+// sensorKeys returns the keys of a list of sensors
+func sensorKeys(sensors []Sensor) []string {
+
+	keys := make([]string, len(sensors))
+	for i, sensor := range sensors {
+		keys[i] = sensor.Info.Key
+	}
+
+	return keys
+}
+
+// findSensor returns the sensor with the given key, or nil if there is no such sensor
+func findSensor(sensors []Sensor, key string) *Sensor {
+
+	for i := range sensors {
+		if sensors[i].Info.Key == key {
+			return &sensors[i]
+		}
+	}
+
+	return nil
+}
+
+// readSensor returns the value of a sensor, or an empty string for a missing sensor
+func readSensor(sensor *Sensor) string {
+
+	if sensor == nil {
+		return ""
+	}
+
+	if sensor.Read == nil {
+		return sensor.Info.Value
+	}
+
+	return sensor.Read()
+}
@@ -0,0 +1,153 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// GetSettingInfo describes the allowed keys and values used for settings.
+//
+// Return a list of argument info structures
+func (dev *Device) GetSettingInfo() []device.SDRArgInfo {
+
+	return append([]device.SDRArgInfo(nil), dev.config.Settings...)
+}
+
+// GetSettingInfoChecked describes the allowed keys and values used for settings, which never fails on the simulated
+// device. See GetSettingInfo() for the details.
+//
+// Return a list of argument info structures and a nil error
+func (dev *Device) GetSettingInfoChecked() (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	return dev.GetSettingInfo(), nil
+}
+
+// WriteSetting writes an arbitrary setting on the device. Only the settings described by GetSettingInfo() can be
+// written.
+//
+// Params:
+//  - key: the setting identifier
+//  - value: the setting value
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteSetting(key string, value string) (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	if _, found := dev.settings[key]; !found {
+		return notSupported()
+	}
+
+	dev.settings[key] = value
+
+	return nil
+}
+
+// ReadSetting reads an arbitrary setting on the device.
+//
+// Params:
+//  - key: the setting identifier
+//
+// Return the setting value
+func (dev *Device) ReadSetting(key string) string {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.settings[key]
+}
+
+// ReadSettingChecked reads an arbitrary setting on the device, reporting an unknown name. See ReadSetting() for the
+// details.
+//
+// Return the setting value and an error if the name is not available
+func (dev *Device) ReadSettingChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if err := checkName("ReadSetting", argInfoKeys(dev.GetSettingInfo()), key); err != nil {
+		return "", err
+	}
+
+	return dev.ReadSetting(key), nil
+}
+
+// GetChannelSettingInfo describes the allowed keys and values used for channel settings.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of argument info structures
+func (dev *Device) GetChannelSettingInfo(direction device.Direction, channel uint) []device.SDRArgInfo {
+
+	return append([]device.SDRArgInfo(nil), dev.config.ChannelSettings...)
+}
+
+// GetChannelSettingInfoChecked describes the allowed keys and values used for channel settings, reporting an invalid
+// channel. See GetChannelSettingInfo() for the details.
+//
+// Return a list of argument info structures and an error if the channel does not exist
+func (dev *Device) GetChannelSettingInfoChecked(direction device.Direction, channel uint) (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetChannelSettingInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetChannelSettingInfo(direction, channel), nil
+}
+
+// WriteChannelSetting writes an arbitrary channel setting on the device. Only the settings described by
+// GetChannelSettingInfo() can be written.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - key: the setting identifier
+//  - value: the setting value
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteChannelSetting(direction device.Direction, channel uint, key string, value string) (err sdrerror.SDRError) {
+
+	found := false
+	err = dev.updateChannel(direction, channel, func(state *channelState) {
+		if _, found = state.settings[key]; found {
+			state.settings[key] = value
+		}
+	})
+	if err == nil && !found {
+		return notSupported()
+	}
+
+	return err
+}
+
+// ReadChannelSetting an arbitrary channel setting on the device.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//  - key: the setting identifier
+//
+// Return the setting value
+func (dev *Device) ReadChannelSetting(direction device.Direction, channel uint, key string) string {
+
+	value := ""
+	dev.readChannel(direction, channel, func(state *channelState) {
+		value = state.settings[key]
+	})
+
+	return value
+}
+
+// ReadChannelSettingChecked an arbitrary channel setting on the device, reporting an invalid channel or an unknown
+// name. See ReadChannelSetting() for the details.
+//
+// Return the setting value and an error if the channel or the name is not available
+func (dev *Device) ReadChannelSettingChecked(direction device.Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannelName("ReadChannelSetting", direction, channel, argInfoKeys(dev.GetChannelSettingInfo(direction, channel)), key); err != nil {
+		return "", err
+	}
+
+	return dev.ReadChannelSetting(direction, channel, key), nil
+}
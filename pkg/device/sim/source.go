@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Tuning describes the configuration of the channel receiving a signal
+type Tuning struct {
+	// Channel is the receive channel
+	Channel uint
+	// Frequency is the center frequency of the channel in Hz
+	Frequency float64
+	// SampleRate is the sample rate of the channel in samples per second
+	SampleRate float64
+}
+
+// Source is a signal received by the simulated device. Samples are complex values with a full scale of 1.0.
+type Source interface {
+	// Generate fills the given samples with the signal received by a channel.
+	//
+	// Params:
+	//  - samples: the samples to fill
+	//  - index: the index of the first sample since the activation of the stream
+	//  - tuning: the configuration of the channel
+	Generate(samples []complex128, index uint64, tuning Tuning)
+}
+
+// Silence is a source producing only zeros
+type Silence struct{}
+
+// Generate fills the given samples with zeros
+func (source Silence) Generate(samples []complex128, index uint64, tuning Tuning) {
+
+	for i := range samples {
+		samples[i] = 0
+	}
+}
+
+// Tone is a source producing a continuous wave
+type Tone struct {
+	// Frequency is the absolute frequency of the tone in Hz. The tone is received at Frequency minus the center
+	// frequency of the channel.
+	Frequency float64
+	// Amplitude is the amplitude of the tone, 1.0 being the full scale
+	Amplitude float64
+	// Phase is the phase of the tone at the activation of the stream in radians
+	Phase float64
+}
+
+// Generate fills the given samples with the tone
+func (source Tone) Generate(samples []complex128, index uint64, tuning Tuning) {
+
+	if tuning.SampleRate <= 0 {
+		Silence{}.Generate(samples, index, tuning)
+		return
+	}
+
+	step := 2 * math.Pi * (source.Frequency - tuning.Frequency) / tuning.SampleRate
+	for i := range samples {
+		phase := source.Phase + step*float64(index+uint64(i))
+		samples[i] = cmplx.Rect(source.Amplitude, math.Mod(phase, 2*math.Pi))
+	}
+}
+
+// Noise is a source producing a white gaussian noise. Noise must be used through a pointer, as in &Noise{Amplitude: 0.1}.
+type Noise struct {
+	// Amplitude is the standard deviation of each of the I and Q components, 1.0 being the full scale
+	Amplitude float64
+	// Seed is the seed of the random generator
+	Seed int64
+
+	mutex  sync.Mutex
+	random *rand.Rand
+}
+
+// Generate fills the given samples with noise
+func (source *Noise) Generate(samples []complex128, index uint64, tuning Tuning) {
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	if source.random == nil {
+		source.random = rand.New(rand.NewSource(source.Seed))
+	}
+
+	for i := range samples {
+		samples[i] = complex(source.random.NormFloat64()*source.Amplitude, source.random.NormFloat64()*source.Amplitude)
+	}
+}
+
+// Burst is a source producing a signal periodically, and silence the rest of the time
+type Burst struct {
+	// Source is the signal of the bursts
+	Source Source
+	// Period is the time between the starts of two bursts
+	Period time.Duration
+	// Duration is the duration of a burst
+	Duration time.Duration
+}
+
+// Generate fills the given samples with the bursts
+func (source Burst) Generate(samples []complex128, index uint64, tuning Tuning) {
+
+	source.Source.Generate(samples, index, tuning)
+
+	if tuning.SampleRate <= 0 || source.Period <= 0 {
+		return
+	}
+
+	for i := range samples {
+		elapsed := time.Duration(float64(index+uint64(i)) / tuning.SampleRate * float64(time.Second))
+		if elapsed%source.Period >= source.Duration {
+			samples[i] = 0
+		}
+	}
+}
+
+// Mix is a source producing the sum of several sources
+type Mix []Source
+
+// Generate fills the given samples with the sum of the sources
+func (source Mix) Generate(samples []complex128, index uint64, tuning Tuning) {
+
+	Silence{}.Generate(samples, index, tuning)
+
+	buffer := make([]complex128, len(samples))
+	for _, s := range source {
+		s.Generate(buffer, index, tuning)
+		for i := range samples {
+			samples[i] += buffer[i]
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package sim
+
+import "github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+
+// TransactSPI performs a SPI transaction and returns the result. The simulated slaves are loop-backs: the bits sent
+// are the bits received.
+//
+// Params:
+//  - addr: an address of an available SPI slave
+//  - data: the SPI data, numBits-1 is first out
+//  - numBits: the number of bits to clock out
+//
+// Return the readback data, numBits-1 is first in
+func (dev *Device) TransactSPI(addr int32, data uint32, numBits uint32) uint32 {
+
+	if numBits >= 32 {
+		return data
+	}
+
+	return data & (1<<numBits - 1)
+}
+
+// TransactSPIChecked performs a SPI transaction and returns the result, which never fails on the simulated device. See
+// TransactSPI() for the details.
+//
+// Return the readback data, numBits-1 is first in, and a nil error
+func (dev *Device) TransactSPIChecked(addr int32, data uint32, numBits uint32) (value uint32, err sdrerror.SDRError) {
+
+	return dev.TransactSPI(addr, data, numBits), nil
+}
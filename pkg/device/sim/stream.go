@@ -0,0 +1,525 @@
+package sim
+
+import (
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync"
+	"time"
+)
+
+// streamFormats are the formats supported by the streams of the simulated device
+var streamFormats = []string{
+	device.FormatCU8, device.FormatCS8, device.FormatCU16, device.FormatCS16, device.FormatCU32, device.FormatCS32,
+	device.FormatF32, device.FormatF64, device.FormatCF32, device.FormatCF64,
+}
+
+// GetStreamFormats queries a list of the available stream formats.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of allowed format strings. See SetupStream() for the format syntax.
+func (dev *Device) GetStreamFormats(direction device.Direction, channel uint) []string {
+
+	return append([]string(nil), streamFormats...)
+}
+
+// GetStreamFormatsChecked queries a list of the available stream formats, reporting an invalid channel. See
+// GetStreamFormats() for the details.
+//
+// Return a list of allowed format strings. See SetupStream() for the format syntax and an error if the channel does not
+// exist
+func (dev *Device) GetStreamFormatsChecked(direction device.Direction, channel uint) (formats []string, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetStreamFormats", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetStreamFormats(direction, channel), nil
+}
+
+// GetNativeStreamFormat gets the hardware's native stream format for this channel.
+//
+// This is the format used by the underlying transport layer, and the direct buffer access API calls (when supported).
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return the native stream buffer format string and the maximum possible value
+func (dev *Device) GetNativeStreamFormat(direction device.Direction, channel uint) (format string, fullScale float64) {
+
+	return dev.config.NativeStreamFormat, dev.config.NativeStreamFullScale
+}
+
+// GetNativeStreamFormatChecked gets the hardware's native stream format for this channel, reporting an invalid channel.
+// See GetNativeStreamFormat() for the details.
+//
+// Return the native stream buffer format string, the maximum possible value and an error if the channel does not exist
+func (dev *Device) GetNativeStreamFormatChecked(direction device.Direction, channel uint) (format string, fullScale float64, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetNativeStreamFormat", direction, channel); err != nil {
+		return "", 0, err
+	}
+
+	format, fullScale = dev.GetNativeStreamFormat(direction, channel)
+
+	return format, fullScale, nil
+}
+
+// GetStreamArgsInfo queries the argument info description for stream args. The simulated device has no stream args.
+//
+// Params:
+//  - direction: the channel direction RX or TX
+//  - channel: an available channel on the device
+//
+// Return a list of argument info structures
+func (dev *Device) GetStreamArgsInfo(direction device.Direction, channel uint) []device.SDRArgInfo {
+
+	return []device.SDRArgInfo{}
+}
+
+// GetStreamArgsInfoChecked queries the argument info description for stream args, reporting an invalid channel. See
+// GetStreamArgsInfo() for the details.
+//
+// Return a list of argument info structures and an error if the channel does not exist
+func (dev *Device) GetStreamArgsInfoChecked(direction device.Direction, channel uint) (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	if err := dev.checkChannel("GetStreamArgsInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return dev.GetStreamArgsInfo(direction, channel), nil
+}
+
+// streamStatus is a status event of a transmit stream
+type streamStatus struct {
+	flags  int
+	timeNs uint
+	code   int
+}
+
+// stream is the format independent part of a simulated stream. The samples are exchanged as complex128 with a full
+// scale of 1.0 and converted by the typed streams.
+type stream struct {
+	device    *Device
+	direction device.Direction
+	channels  []uint
+
+	mutex     sync.Mutex
+	closed    bool
+	active    bool
+	startNs   uint
+	started   time.Time
+	index     uint64
+	remaining uint64
+	reads     uint
+	statuses  chan streamStatus
+	late      bool
+	tunings   []Tuning
+
+	// activations counts the activations, so that a read waiting for its samples detects a reactivation
+	activations uint
+
+	// readMutex serialises the reads, which share the received buffers so that a read does not allocate
+	readMutex sync.Mutex
+	received  [][]complex128
+}
+
+// newStream checks the channels and creates the format independent part of a stream
+func (dev *Device) newStream(direction device.Direction, channels []uint) (*stream, error) {
+
+	if len(channels) == 0 {
+		return nil, errors.New("the channels must be given explicitly during stream setup")
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	if dev.unmade {
+		return nil, sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", "SetupStream", direction.String(), -1)
+	}
+
+	for _, channel := range channels {
+		if dev.channel(direction, channel) == nil {
+			return nil, notSupported()
+		}
+	}
+
+	return &stream{
+		device:    dev,
+		direction: direction,
+		channels:  append([]uint(nil), channels...),
+		statuses:  make(chan streamStatus, 16),
+		tunings:   make([]Tuning, len(channels)),
+		received:  make([][]complex128, len(channels)),
+	}, nil
+}
+
+// GetNumChannels returns the number of channels of the stream.
+//
+// Return the number of channels given when the stream was set up
+func (s *stream) GetNumChannels() uint {
+
+	return uint(len(s.channels))
+}
+
+// Close closes the stream.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the stream is already closed
+func (s *stream) Close() (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Close", false); err != nil {
+		return err
+	}
+
+	s.closed = true
+	s.active = false
+
+	return nil
+}
+
+// checkState rejects a call on a closed stream or, when the call requires an active stream, on a stream which is not
+// active. The mutex of the stream must be held.
+//
+// Params:
+//  - op: the name of the call
+//  - active: true if the call requires an active stream
+//
+// Return an error matching sdrerror.ErrClosed or sdrerror.ErrNotActive if the call is rejected
+func (s *stream) checkState(op string, active bool) sdrerror.SDRError {
+
+	switch {
+	case s.closed:
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", op, s.direction.String(), -1)
+	case active && !s.active:
+		return sdrerror.Wrap(sdrerror.ErrNotActive.SDRErrorCode(), "", op, s.direction.String(), -1)
+	}
+
+	return nil
+}
+
+// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
+//
+// Return the MTU in number of stream elements (never zero)
+func (s *stream) GetMTU() int {
+
+	return s.device.config.MTU
+}
+
+// Activate activates the stream. The sample counter of the stream is reset and the timestamps of the samples start at
+// timeNs if flags have StreamFlagHasTime, at the current hardware time otherwise.
+//
+// Params:
+//  - flags: optional flag indicators about the stream
+//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
+//  - numElems: optional element count for burst control. When not 0, a receive stream ends after numElems elements.
+//
+// Return an error or nil in case of success
+func (s *stream) Activate(flags device.StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Activate", false); err != nil {
+		return err
+	}
+
+	s.device.mutex.Lock()
+	s.startNs = s.device.hardwareTime()
+	s.device.mutex.Unlock()
+
+	if flags&device.StreamFlagHasTime != 0 {
+		s.startNs = uint(timeNs)
+	}
+
+	s.active = true
+	s.activations++
+	s.started = time.Now()
+	s.index = 0
+	s.remaining = 0
+	if numElems > 0 {
+		s.remaining = uint64(numElems)
+	}
+
+	return nil
+}
+
+// Deactivate deactivates the stream.
+//
+// Params:
+//  - flags: optional flag indicators about the stream
+//  - timeNs: optional deactivation time in nanoseconds
+//
+// Return an error or nil in case of success
+func (s *stream) Deactivate(flags device.StreamFlag, timeNs int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Deactivate", false); err != nil {
+		return err
+	}
+
+	s.active = false
+
+	return nil
+}
+
+// ReadStreamStatus reads status information about the stream. Transmit streams report the end of the bursts written
+// with StreamFlagEndBurst and, with a TimeError, the bursts whose time had already passed when they were written.
+// Receive streams do not support status.
+//
+// Params:
+//  - chanMask to which channels this status applies
+//  - flags optional input flags and output flags
+//  - timeoutUs the timeout in microseconds
+//
+// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
+func (s *stream) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
+
+	s.mutex.Lock()
+	err = s.checkState("ReadStreamStatus", false)
+	s.mutex.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if s.direction != device.DirectionTX {
+		return 0, notSupported()
+	}
+
+	// A queued status is returned even with a zero timeout, which would otherwise race with the timer
+	select {
+	case status := <-s.statuses:
+		return takeStatus(status, flags)
+	default:
+	}
+
+	timer := time.NewTimer(time.Duration(timeoutUs) * time.Microsecond)
+	defer timer.Stop()
+
+	select {
+	case status := <-s.statuses:
+		return takeStatus(status, flags)
+	case <-timer.C:
+		return 0, sdrerror.Err(sdrerror.ErrTimeout.SDRErrorCode())
+	}
+}
+
+// takeStatus returns a status read from the queue of a stream.
+//
+// Params:
+//  - status: the status
+//  - flags: the output flags, whose first element receives the flags of the status
+//
+// Return the timestamp of the status in nanoseconds and its error
+func takeStatus(status streamStatus, flags []int) (timeNs uint, err error) {
+
+	if len(flags) > 0 {
+		flags[0] = status.flags
+	}
+
+	return status.timeNs, sdrerror.Err(status.code)
+}
+
+// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide. The simulated streams do
+// not support direct access.
+//
+// Return 0
+func (s *stream) GetNumDirectAccessBuffers() uint {
+
+	return 0
+}
+
+// ReleaseReadBuffer does nothing as the simulated streams do not support direct access.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireReadBuffer()
+func (s *stream) ReleaseReadBuffer(handle uint) {
+}
+
+// ReleaseWriteBuffer does nothing as the simulated streams do not support direct access.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireWriteBuffer()
+//  - numElems: the number of elements written to each buffer
+//  - flags: input flags
+//  - timeNs: the buffer's timestamp in nanoseconds
+func (s *stream) ReleaseWriteBuffer(handle uint, numElems uint, flags []int, timeNs uint) {
+}
+
+// receive produces the samples of a read on a receive stream, applying the simulated faults and the realtime pacing.
+// The samples are produced in the received buffers of the stream: readMutex must be held until they are consumed.
+//
+// Params:
+//  - nbElems: the maximum number of samples to produce per channel
+//  - outputFlags: the flag indicators of the result by channel
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the samples of each channel, the timestamp of the first sample in nanoseconds and an error
+func (s *stream) receive(nbElems uint, outputFlags []int, timeoutUs uint) (samples [][]complex128, timeNs uint, err error) {
+
+	if uint(len(outputFlags)) != s.GetNumChannels() {
+		return nil, 0, errors.New("the flags must have the same number of channels as the stream")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Read", true); err != nil {
+		return nil, 0, err
+	}
+	if s.direction != device.DirectionRX {
+		return nil, 0, sdrerror.Err(sdrerror.ErrStream.SDRErrorCode())
+	}
+
+	for i := range outputFlags {
+		outputFlags[i] = 0
+	}
+
+	count := uint64(nbElems)
+	if mtu := uint64(s.device.config.MTU); count > mtu {
+		count = mtu
+	}
+	if s.remaining > 0 && count >= s.remaining {
+		count = s.remaining
+		for i := range outputFlags {
+			outputFlags[i] |= int(device.StreamFlagEndBurst)
+		}
+	}
+
+	s.reads++
+	faults := s.device.config.Faults
+	if faults.TimeoutEvery > 0 && s.reads%faults.TimeoutEvery == 0 {
+		return nil, 0, sdrerror.Err(sdrerror.ErrTimeout.SDRErrorCode())
+	}
+
+	tunings := s.tunings
+	s.device.mutex.Lock()
+	for i, channel := range s.channels {
+		state := s.device.channel(device.DirectionRX, channel)
+		tunings[i] = Tuning{Channel: channel, Frequency: state.frequency, SampleRate: state.sampleRate}
+	}
+	s.device.mutex.Unlock()
+
+	sampleRate := tunings[0].SampleRate
+	timeNs = s.startNs + uint(float64(s.index)*1e9/sampleRate)
+
+	if s.device.config.Realtime {
+		// The mutex is released during the wait so that the stream can be closed or deactivated meanwhile
+		activations := s.activations
+		wait := time.Until(s.started.Add(time.Duration(float64(s.index+count) / sampleRate * float64(time.Second))))
+		timeout := time.Duration(timeoutUs) * time.Microsecond
+
+		s.mutex.Unlock()
+		if wait > timeout {
+			time.Sleep(timeout)
+		} else {
+			time.Sleep(wait)
+		}
+		s.mutex.Lock()
+
+		if err := s.checkState("Read", true); err != nil {
+			return nil, 0, err
+		}
+		if wait > timeout || s.activations != activations {
+			return nil, 0, sdrerror.Err(sdrerror.ErrTimeout.SDRErrorCode())
+		}
+	}
+
+	if faults.OverflowEvery > 0 && s.reads%faults.OverflowEvery == 0 {
+		s.index += count
+		return nil, 0, sdrerror.Err(sdrerror.ErrOverflow.SDRErrorCode())
+	}
+
+	samples = s.received
+	for i := range samples {
+		if uint64(cap(samples[i])) < count {
+			samples[i] = make([]complex128, count)
+		}
+		samples[i] = samples[i][:count]
+		s.device.config.Source.Generate(samples[i], s.index, tunings[i])
+	}
+
+	s.index += count
+	if s.remaining > 0 {
+		s.remaining -= count
+		if s.remaining == 0 {
+			s.active = false
+		}
+	}
+
+	for i := range outputFlags {
+		outputFlags[i] |= int(device.StreamFlagHasTime)
+	}
+
+	return samples, timeNs, nil
+}
+
+// transmit consumes the samples of a write on a transmit stream.
+//
+// Params:
+//  - samples: the samples of each channel
+//  - flags: input flags by channel
+//  - timeNs: the buffer's timestamp in nanoseconds
+//
+// Return the number of samples written per channel and an error
+func (s *stream) transmit(samples [][]complex128, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	if uint(len(flags)) != s.GetNumChannels() {
+		return 0, errors.New("the write flags must have the same number of channels as the stream")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Write", true); err != nil {
+		return 0, err
+	}
+	if s.direction != device.DirectionTX {
+		return 0, sdrerror.Err(sdrerror.ErrStream.SDRErrorCode())
+	}
+
+	endBurst := flags[0]&int(device.StreamFlagEndBurst) != 0
+
+	// A burst whose time has passed is dropped, up to its end, and reported once
+	if flags[0]&int(device.StreamFlagHasTime) != 0 {
+		s.device.mutex.Lock()
+		s.late = timeNs < s.device.hardwareTime()
+		s.device.mutex.Unlock()
+		if s.late {
+			s.pushStatus(streamStatus{flags: flags[0], timeNs: timeNs, code: sdrerror.ErrTime.SDRErrorCode()})
+		}
+	}
+	if s.late {
+		s.late = !endBurst
+		return uint(len(samples[0])), nil
+	}
+
+	if s.device.config.Transmitted != nil {
+		for i, channel := range s.channels {
+			s.device.config.Transmitted(channel, samples[i], timeNs, flags[i])
+		}
+	}
+
+	if endBurst {
+		s.pushStatus(streamStatus{flags: flags[0], timeNs: timeNs})
+	}
+
+	return uint(len(samples[0])), nil
+}
+
+// pushStatus queues a status of the stream, dropping it if the queue is full
+func (s *stream) pushStatus(status streamStatus) {
+
+	select {
+	case s.statuses <- status:
+	default:
+	}
+}
@@ -0,0 +1,204 @@
+package sim_test
+
+import (
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+	"time"
+)
+
+// read reads up to numElems samples from a receive stream of one channel
+func read(stream device.TypedStream[complex64], numElems uint, timeoutUs uint) (timeNs uint, numElemsRead uint, flags int, err error) {
+
+	buffers := [][]complex64{make([]complex64, numElems)}
+	outputFlags := make([]int, 1)
+
+	timeNs, numElemsRead, err = stream.Read(buffers, numElems, outputFlags, timeoutUs)
+
+	return timeNs, numElemsRead, outputFlags[0], err
+}
+
+func TestStreamState(t *testing.T) {
+
+	dev := simtest.NewDevice(t, sim.Config{MTU: 1000})
+	stream, err := sim.SetupStream[complex64](dev, device.DirectionRX, []uint{0}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := read(stream, 100, 0); !errors.Is(err, sdrerror.ErrNotActive) {
+		t.Errorf("read before activation returned %v", err)
+	}
+
+	// The timestamps start at the activation time and follow the sample rate of 1MS/s
+	if err := stream.Activate(device.StreamFlagHasTime, 5e9, 0); err != nil {
+		t.Fatal(err)
+	}
+	timeNs, numElemsRead, flags, err := read(stream, 100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeNs != 5e9 || numElemsRead != 100 || !device.StreamFlag(flags).Has(device.StreamFlagHasTime) {
+		t.Errorf("first read of %v samples at %v with flags %v", numElemsRead, timeNs, device.StreamFlag(flags))
+	}
+	if timeNs, numElemsRead, _, _ = read(stream, 5000, 0); timeNs != 5e9+100e3 || numElemsRead != 1000 {
+		t.Errorf("second read of %v samples at %v, expected 1000 samples at %v", numElemsRead, timeNs, uint(5e9+100e3))
+	}
+
+	if err := stream.Deactivate(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := read(stream, 100, 0); !errors.Is(err, sdrerror.ErrNotActive) {
+		t.Errorf("read after deactivation returned %v", err)
+	}
+
+	// A finite burst ends with the flag EndBurst and deactivates the stream
+	if err := stream.Activate(0, 0, 1500); err != nil {
+		t.Fatal(err)
+	}
+	if _, numElemsRead, flags, _ = read(stream, 1000, 0); numElemsRead != 1000 || device.StreamFlag(flags).Has(device.StreamFlagEndBurst) {
+		t.Errorf("first read of the burst: %v samples with flags %v", numElemsRead, device.StreamFlag(flags))
+	}
+	if _, numElemsRead, flags, _ = read(stream, 1000, 0); numElemsRead != 500 || !device.StreamFlag(flags).Has(device.StreamFlagEndBurst) {
+		t.Errorf("last read of the burst: %v samples with flags %v", numElemsRead, device.StreamFlag(flags))
+	}
+	if _, _, _, err := read(stream, 100, 0); !errors.Is(err, sdrerror.ErrNotActive) {
+		t.Errorf("read after the end of the burst returned %v", err)
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Activate(0, 0, 0); !errors.Is(err, sdrerror.ErrClosed) {
+		t.Errorf("activation of a closed stream returned %v", err)
+	}
+	if err := stream.Close(); !errors.Is(err, sdrerror.ErrClosed) {
+		t.Errorf("second close returned %v", err)
+	}
+
+	if err := dev.Unmake(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.SetupStream[complex64](dev, device.DirectionRX, []uint{0}, nil); !errors.Is(err, sdrerror.ErrClosed) {
+		t.Errorf("setup on an unmade device returned %v", err)
+	}
+}
+
+func TestStreamFaults(t *testing.T) {
+
+	_, stream := simtest.NewActiveRXStream[complex64](t, sim.Config{
+		MTU:    100,
+		Faults: sim.Faults{OverflowEvery: 3, TimeoutEvery: 5},
+	})
+
+	expectedNs := uint(0)
+	for i := 1; i <= 15; i++ {
+
+		timeNs, numElemsRead, _, err := read(stream, 100, 0)
+		switch {
+		case i%5 == 0:
+			if !errors.Is(err, sdrerror.ErrTimeout) {
+				t.Errorf("read %v returned %v, expected a timeout", i, err)
+			}
+			continue
+		case i%3 == 0:
+			if !errors.Is(err, sdrerror.ErrOverflow) {
+				t.Errorf("read %v returned %v, expected an overflow", i, err)
+			}
+			// The samples of the overflow are dropped
+			expectedNs += 100e3
+			continue
+		case err != nil:
+			t.Fatalf("read %v: %v", i, err)
+		}
+
+		if i == 1 {
+			expectedNs = timeNs
+		}
+		if timeNs != expectedNs || numElemsRead != 100 {
+			t.Errorf("read %v of %v samples at %v, expected 100 samples at %v", i, numElemsRead, timeNs, expectedNs)
+		}
+		expectedNs += 100e3
+	}
+}
+
+func TestStreamRealtime(t *testing.T) {
+
+	dev, stream := simtest.NewActiveRXStream[complex64](t, sim.Config{MTU: 10000, Realtime: true})
+	if err := dev.SetSampleRate(device.DirectionRX, 0, 250e3); err != nil {
+		t.Fatal(err)
+	}
+	// The sample rate applies to the samples read after the reactivation
+	if err := stream.Activate(0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// 10000 samples at 250kS/s take 40ms
+	start := time.Now()
+	if _, _, _, err := read(stream, 10000, 1e6); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("10000 samples read in %v", elapsed)
+	}
+
+	// A read whose samples are not produced before the timeout times out
+	if _, _, _, err := read(stream, 10000, 1000); !errors.Is(err, sdrerror.ErrTimeout) {
+		t.Errorf("read shorter than its samples returned %v", err)
+	}
+}
+
+func TestStreamLateBurst(t *testing.T) {
+
+	var transmitted []uint
+	dev := simtest.NewDevice(t, sim.Config{
+		Transmitted: func(channel uint, samples []complex128, timeNs uint, flags int) {
+			transmitted = append(transmitted, timeNs)
+		},
+	})
+	if err := dev.SetHardwareTime(2e9, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := sim.SetupStream[complex64](dev, device.DirectionTX, []uint{0}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if err := stream.Activate(0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	buffers := [][]complex64{make([]complex64, 100)}
+	write := func(flags device.StreamFlag, timeNs uint) {
+		if numElemsWritten, err := stream.Write(buffers, 100, []int{int(flags)}, timeNs, 0); err != nil || numElemsWritten != 100 {
+			t.Fatalf("write of %v samples: %v", numElemsWritten, err)
+		}
+	}
+
+	// A late burst is dropped up to its end and reported once with a time error
+	write(device.StreamFlagHasTime, 1e9)
+	write(0, 0)
+	write(device.StreamFlagEndBurst, 0)
+	// A burst on time is transmitted and its end is reported
+	write(device.StreamFlagHasTime, 10e9)
+	write(device.StreamFlagEndBurst, 0)
+
+	if len(transmitted) != 2 || transmitted[0] != 10e9 {
+		t.Errorf("writes transmitted at %v, expected the 2 writes of the burst on time", transmitted)
+	}
+
+	flags := make([]int, 1)
+	if timeNs, err := stream.ReadStreamStatus(nil, flags, 0); !errors.Is(err, sdrerror.ErrTime) || timeNs != 1e9 {
+		t.Errorf("first status at %v: %v, expected a time error at %v", timeNs, err, uint(1e9))
+	}
+	if _, err := stream.ReadStreamStatus(nil, flags, 0); err != nil || !device.StreamFlag(flags[0]).Has(device.StreamFlagEndBurst) {
+		t.Errorf("second status with flags %v: %v, expected the end of the burst", device.StreamFlag(flags[0]), err)
+	}
+	if _, err := stream.ReadStreamStatus(nil, flags, 0); !errors.Is(err, sdrerror.ErrTimeout) {
+		t.Errorf("status without event returned %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+package sim
+
+import (
+	"context"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"unsafe"
+)
+
+// typedStream is a simulated stream whose elements are of type T
+type typedStream[T device.Sample] struct {
+	*stream
+	format         string
+	elemsPerSample uint
+}
+
+// Ensure typedStream implements device.TypedStream
+var _ device.TypedStream[complex64] = (*typedStream[complex64])(nil)
+
+// SetupStream initializes a simulated stream given a list of channels. The format of the stream is deduced from the
+// type of its elements, see device.StreamFormat().
+//
+// Params:
+//  - dev: the simulated device on which the stream is created
+//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
+//  - channels: a list of channels. The channels must be explicitly defined.
+//  - args: stream args, ignored by the simulated device
+//
+// Return the stream and an error
+func SetupStream[T device.Sample](dev *Device, direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStream[T], err error) {
+
+	format, elemsPerSample, err := device.StreamFormat[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := dev.newStream(direction, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typedStream[T]{stream: s, format: format, elemsPerSample: elemsPerSample}, nil
+}
+
+// SetupSDRStreamCU8 initializes a simulated stream in CU8 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCU8(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCU8, err error) {
+
+	return SetupStream[uint8](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCS8 initializes a simulated stream in CS8 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCS8(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCS8, err error) {
+
+	return SetupStream[int8](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCU16 initializes a simulated stream in CU16 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCU16(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCU16, err error) {
+
+	return SetupStream[uint16](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCS16 initializes a simulated stream in CS16 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCS16(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCS16, err error) {
+
+	return SetupStream[int16](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCF32 initializes a simulated stream in CF32 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCF32(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCF32, err error) {
+
+	return SetupStream[complex64](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCF64 initializes a simulated stream in CF64 format. See SetupStream() for the details.
+func (dev *Device) SetupSDRStreamCF64(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCF64, err error) {
+
+	return SetupStream[complex128](dev, direction, channels, args)
+}
+
+// GetFormat returns the format of the elements of the stream, such as "CF32".
+//
+// Return the format of the stream
+func (s *typedStream[T]) GetFormat() string {
+
+	return s.format
+}
+
+// Read reads elements from the stream for reception. The number of elements read is limited by the MTU of the stream.
+//
+// See device.Stream.Read() for the details.
+func (s *typedStream[T]) Read(buffers [][]T, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	if err := device.CheckBuffers(buffers, s.GetNumChannels(), nbElems, s.elemsPerSample); err != nil {
+		return 0, 0, err
+	}
+
+	s.readMutex.Lock()
+	defer s.readMutex.Unlock()
+
+	samples, timeNs, err := s.receive(nbElems, outputFlags, timeoutUs)
+	if err != nil {
+		return timeNs, 0, err
+	}
+
+	for c, channel := range samples {
+		toElems(channel, buffers[c])
+	}
+
+	return timeNs, uint(len(samples[0])), nil
+}
+
+// Write writes elements to the stream for transmission. The samples are given to the Transmitted function of the
+// configuration of the device.
+//
+// See device.Stream.Write() for the details.
+func (s *typedStream[T]) Write(buffers [][]T, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+
+	if err := device.CheckBuffers(buffers, s.GetNumChannels(), nbElems, s.elemsPerSample); err != nil {
+		return 0, err
+	}
+
+	samples := make([][]complex128, len(buffers))
+	for c, buffer := range buffers {
+		samples[c] = make([]complex128, nbElems)
+		fromElems(buffer, samples[c])
+	}
+
+	return s.transmit(samples, flags, timeNs)
+}
+
+// ReadBuffers reads elements from the stream for reception, as many as fit in the given buffers. See
+// device.Stream.ReadBuffers() for the details.
+func (s *typedStream[T]) ReadBuffers(buffers [][]T, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	return s.Read(buffers, device.BuffersNumElems(buffers, s.elemsPerSample), outputFlags, timeoutUs)
+}
+
+// WriteBuffers writes all the elements of the given buffers to the stream for transmission. See
+// device.Stream.WriteBuffers() for the details.
+func (s *typedStream[T]) WriteBuffers(buffers [][]T, flags []int, timeNs uint, timeoutUs uint) (numElemsWritten uint, err error) {
+
+	return s.Write(buffers, device.BuffersNumElems(buffers, s.elemsPerSample), flags, timeNs, timeoutUs)
+}
+
+// ReadContext reads elements from the stream until elements are received or the context is done. See
+// device.ReadContext() for the details.
+func (s *typedStream[T]) ReadContext(ctx context.Context, buffers [][]T, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error) {
+
+	return device.ReadContext[T](ctx, s, buffers, nbElems, outputFlags)
+}
+
+// WriteContext writes elements to the stream until elements are sent or the context is done. See
+// device.WriteContext() for the details.
+func (s *typedStream[T]) WriteContext(ctx context.Context, buffers [][]T, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	return device.WriteContext[T](ctx, s, buffers, nbElems, flags, timeNs)
+}
+
+// TransmitBurst transmits a burst of samples on the stream. See device.TransmitBurst() for the details.
+func (s *typedStream[T]) TransmitBurst(ctx context.Context, samples [][]T, atTimeNs uint) (report device.BurstReport, err error) {
+
+	return device.TransmitBurst[T](ctx, s, samples, atTimeNs)
+}
+
+// ReadStreamStatusContext reads status information about the stream until a status is available or the context is
+// done. See device.ReadStreamStatusContext() for the details.
+func (s *typedStream[T]) ReadStreamStatusContext(ctx context.Context, chanMask []uint, flags []int) (timeNs uint, err error) {
+
+	return device.ReadStreamStatusContext(ctx, s, chanMask, flags)
+}
+
+// GetDirectAccessBufferAddrs is not supported by the simulated streams.
+func (s *typedStream[T]) GetDirectAccessBufferAddrs(handle uint) (buffers [][]T, err error) {
+
+	return nil, notSupported()
+}
+
+// AcquireReadBuffer is not supported by the simulated streams.
+func (s *typedStream[T]) AcquireReadBuffer(outputFlags []int, timeoutUs uint) (handle uint, buffers [][]T, timeNs uint, numElemsRead uint, err error) {
+
+	return 0, nil, 0, 0, notSupported()
+}
+
+// AcquireWriteBuffer is not supported by the simulated streams.
+func (s *typedStream[T]) AcquireWriteBuffer(timeoutUs uint) (handle uint, buffers [][]T, numElems uint, err error) {
+
+	return 0, nil, 0, notSupported()
+}
+
+// toElems converts samples with a full scale of 1.0 to the elements of a stream. The integer elements use all the bits
+// of their components, see device.DefaultFullScale().
+func toElems[T device.Sample](samples []complex128, buffer []T) {
+
+	switch elems := any(buffer).(type) {
+	case []uint8:
+		quantize(asComplexInt[uint8, device.CU8](elems), samples)
+	case []int8:
+		quantize(asComplexInt[int8, device.CS8](elems), samples)
+	case []uint16:
+		quantize(asComplexInt[uint16, device.CU16](elems), samples)
+	case []int16:
+		quantize(asComplexInt[int16, device.CS16](elems), samples)
+	case []uint32:
+		quantize(asComplexInt[uint32, device.CU32](elems), samples)
+	case []int32:
+		quantize(asComplexInt[int32, device.CS32](elems), samples)
+	case []float32:
+		for i, sample := range samples {
+			elems[i] = float32(real(sample))
+		}
+	case []float64:
+		for i, sample := range samples {
+			elems[i] = real(sample)
+		}
+	case []complex64:
+		for i, sample := range samples {
+			elems[i] = complex64(sample)
+		}
+	case []complex128:
+		copy(elems, samples)
+	case []device.CU8:
+		quantize(elems, samples)
+	case []device.CS8:
+		quantize(elems, samples)
+	case []device.CU16:
+		quantize(elems, samples)
+	case []device.CS16:
+		quantize(elems, samples)
+	case []device.CU32:
+		quantize(elems, samples)
+	case []device.CS32:
+		quantize(elems, samples)
+	}
+}
+
+// fromElems converts the elements of a stream to samples with a full scale of 1.0
+func fromElems[T device.Sample](buffer []T, samples []complex128) {
+
+	switch elems := any(buffer).(type) {
+	case []uint8:
+		dequantize(asComplexInt[uint8, device.CU8](elems), samples)
+	case []int8:
+		dequantize(asComplexInt[int8, device.CS8](elems), samples)
+	case []uint16:
+		dequantize(asComplexInt[uint16, device.CU16](elems), samples)
+	case []int16:
+		dequantize(asComplexInt[int16, device.CS16](elems), samples)
+	case []uint32:
+		dequantize(asComplexInt[uint32, device.CU32](elems), samples)
+	case []int32:
+		dequantize(asComplexInt[int32, device.CS32](elems), samples)
+	case []float32:
+		for i := range samples {
+			samples[i] = complex(float64(elems[i]), 0)
+		}
+	case []float64:
+		for i := range samples {
+			samples[i] = complex(elems[i], 0)
+		}
+	case []complex64:
+		for i := range samples {
+			samples[i] = complex128(elems[i])
+		}
+	case []complex128:
+		copy(samples, elems)
+	case []device.CU8:
+		dequantize(elems, samples)
+	case []device.CS8:
+		dequantize(elems, samples)
+	case []device.CU16:
+		dequantize(elems, samples)
+	case []device.CS16:
+		dequantize(elems, samples)
+	case []device.CU32:
+		dequantize(elems, samples)
+	case []device.CS32:
+		dequantize(elems, samples)
+	}
+}
+
+// quantize converts samples with a full scale of 1.0 to complex integer samples
+func quantize[C device.ComplexInt](dst []C, samples []complex128) {
+
+	device.FromComplex128(dst, samples, device.DefaultFullScale[C]())
+}
+
+// dequantize converts complex integer samples to samples with a full scale of 1.0
+func dequantize[C device.ComplexInt](src []C, samples []complex128) {
+
+	device.ToComplex128(samples, src, device.DefaultFullScale[C]())
+}
+
+// asComplexInt returns interleaved I and Q components as complex integer samples, sharing the memory of the
+// components. E must be the type of the components of C.
+func asComplexInt[E any, C device.ComplexInt](elems []E) []C {
+
+	if len(elems) < 2 {
+		return nil
+	}
+
+	return unsafe.Slice((*C)(unsafe.Pointer(&elems[0])), len(elems)/2)
+}
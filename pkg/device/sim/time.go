@@ -0,0 +1,140 @@
+package sim
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"time"
+)
+
+// ListTimeSources gets the list of available time sources.
+//
+// Return a list of time source names
+func (dev *Device) ListTimeSources() []string {
+
+	return append([]string(nil), dev.config.TimeSources...)
+}
+
+// ListTimeSourcesChecked gets the list of available time sources, which never fails on the simulated device. See
+// ListTimeSources() for the details.
+//
+// Return a list of time source names and a nil error
+func (dev *Device) ListTimeSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	return dev.ListTimeSources(), nil
+}
+
+// SetTimeSource set the time source on the device.
+//
+// Params:
+//  - source: the name of a time source
+//
+// Return an error or nil in case of success
+func (dev *Device) SetTimeSource(source string) (err sdrerror.SDRError) {
+
+	if !contains(dev.config.TimeSources, source) {
+		return notSupported()
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.timeSource = source
+
+	return nil
+}
+
+// GetTimeSource gets the time source of the device.
+//
+// Return the name of a time source
+func (dev *Device) GetTimeSource() string {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.timeSource
+}
+
+// GetTimeSourceChecked gets the time source of the device, which never fails on the simulated device. See
+// GetTimeSource() for the details.
+//
+// Return the name of a time source and a nil error
+func (dev *Device) GetTimeSourceChecked() (source string, err sdrerror.SDRError) {
+
+	return dev.GetTimeSource(), nil
+}
+
+// HasHardwareTime checks if the device have a hardware clock. The simulated device has a single hardware clock,
+// selected with an empty what argument.
+//
+// Params:
+//  - what: optional argument
+//
+// Return true if the hardware clock exists
+func (dev *Device) HasHardwareTime(what string) bool {
+
+	return what == ""
+}
+
+// HasHardwareTimeChecked checks if the device have a hardware clock, which never fails on the simulated device. See
+// HasHardwareTime() for the details.
+//
+// Return true if the hardware clock exists and a nil error
+func (dev *Device) HasHardwareTimeChecked(what string) (supported bool, err sdrerror.SDRError) {
+
+	return dev.HasHardwareTime(what), nil
+}
+
+// GetHardwareTime reads the time from the hardware clock on the device. The simulated hardware clock starts at 0 when
+// the device is created and runs at the pace of the system clock.
+//
+// Params:
+//  - what: optional argument. The what argument can refer to a specific time counter.
+//
+// Return the time in nanoseconds
+func (dev *Device) GetHardwareTime(what string) uint {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	return dev.hardwareTime()
+}
+
+// GetHardwareTimeChecked reads the time from the hardware clock on the device, reporting an unknown time counter. See
+// GetHardwareTime() for the details.
+//
+// Return the time in nanoseconds and an error if the time counter is not available
+func (dev *Device) GetHardwareTimeChecked(what string) (timeNs uint, err sdrerror.SDRError) {
+
+	if err := checkName("GetHardwareTime", []string{""}, what); err != nil {
+		return 0, err
+	}
+
+	return dev.GetHardwareTime(what), nil
+}
+
+// SetHardwareTime writes the time to the hardware clock on the device.
+//
+// Params:
+//  - timeNs: time in nanoseconds
+//  - what: optional argument. The what argument can refer to a specific time counter.
+//
+// Return an error or nil in case of success
+func (dev *Device) SetHardwareTime(timeNs uint, what string) (err sdrerror.SDRError) {
+
+	if !dev.HasHardwareTime(what) {
+		return notSupported()
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.timeOffset = int64(timeNs)
+	dev.timeReference = time.Now()
+
+	return nil
+}
+
+// hardwareTime returns the time of the hardware clock in nanoseconds. The mutex of the device must be held.
+func (dev *Device) hardwareTime() uint {
+
+	return uint(dev.timeOffset + int64(time.Since(dev.timeReference)))
+}
@@ -0,0 +1,98 @@
+package sim
+
+import (
+	"bytes"
+	"context"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"time"
+)
+
+// ListUARTs enumerates the available UART devices.
+//
+// Return a list of names of available UARTs
+func (dev *Device) ListUARTs() []string {
+
+	return append([]string(nil), dev.config.UARTs...)
+}
+
+// ListUARTsChecked enumerates the available UART devices, which never fails on the simulated device. See ListUARTs()
+// for the details.
+//
+// Return a list of names of available UARTs and a nil error
+func (dev *Device) ListUARTsChecked() (uarts []string, err sdrerror.SDRError) {
+
+	return dev.ListUARTs(), nil
+}
+
+// WriteUART writes data to a UART device. The simulated UARTs are loop-backs: the data written can be read back with
+// ReadUART().
+//
+// Params:
+//  - which: the name of an available UART
+//  - data: a string of data to write out
+//
+// Return an error or nil in case of success
+func (dev *Device) WriteUART(which string, data string) (err sdrerror.SDRError) {
+
+	if !contains(dev.config.UARTs, which) {
+		return notSupported()
+	}
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	dev.uarts[which] = append(dev.uarts[which], data...)
+
+	return nil
+}
+
+// ReadUART reads bytes from a UART until timeout or newline.
+//
+// Params:
+//  - which: the name of an available UART
+//  - timeoutUs: a timeout in microseconds
+//
+// Return a string of bytes read from the UART
+func (dev *Device) ReadUART(which string, timeoutUs uint) string {
+
+	deadline := time.Now().Add(time.Duration(timeoutUs) * time.Microsecond)
+
+	for {
+		dev.mutex.Lock()
+		pending := dev.uarts[which]
+		if index := bytes.IndexByte(pending, '\n'); index >= 0 {
+			dev.uarts[which] = pending[index+1:]
+			dev.mutex.Unlock()
+			return string(pending[:index+1])
+		}
+		if !time.Now().Before(deadline) {
+			dev.uarts[which] = nil
+			dev.mutex.Unlock()
+			return string(pending)
+		}
+		dev.mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// ReadUARTChecked reads bytes from a UART until timeout or newline, reporting an unknown UART. See ReadUART() for the
+// details.
+//
+// Return a string of bytes read from the UART and an error if the UART is not available
+func (dev *Device) ReadUARTChecked(which string, timeoutUs uint) (data string, err sdrerror.SDRError) {
+
+	if err := checkName("ReadUART", dev.ListUARTs(), which); err != nil {
+		return "", err
+	}
+
+	return dev.ReadUART(which, timeoutUs), nil
+}
+
+// ReadUARTContext reads bytes from a UART until newline or until the context is done. See device.ReadUARTContext() for
+// the details.
+func (dev *Device) ReadUARTContext(ctx context.Context, which string) (data string, err error) {
+
+	return device.ReadUARTContext(ctx, dev, which)
+}
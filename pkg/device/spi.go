@@ -7,7 +7,11 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
 
 // TransactSPI performs a SPI transaction and return the result.
 //
@@ -24,5 +28,23 @@ import "C"
 // Return the readback data, numBits-1 is first in
 func (dev *SDRDevice) TransactSPI(addr int32, data uint32, numBits uint32) uint32 {
 
-	return uint32(C.SoapySDRDevice_transactSPI(dev.device, C.int(addr), C.uint(data), C.size_t(numBits)))
+	value, _ := dev.TransactSPIChecked(addr, data, numBits)
+
+	return value
+}
+
+// TransactSPIChecked performs a SPI transaction, returning the failure reported by the driver. See TransactSPI() for
+// the details.
+//
+// Return the readback data, numBits-1 being first in, and an error if the call failed
+func (dev *SDRDevice) TransactSPIChecked(addr int32, data uint32, numBits uint32) (value uint32, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "TransactSPI", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	value = uint32(C.SoapySDRGo_transactSPI(dev.device, C.int(addr), C.uint(data), C.size_t(numBits), &status))
+
+	return value, callError(&status, "TransactSPI")
 }
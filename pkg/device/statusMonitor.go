@@ -0,0 +1,253 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync/atomic"
+)
+
+// StatusEventType is the type of the events reported by a StatusMonitor
+type StatusEventType int
+
+const (
+	// StatusEventEndBurst reports the end of a burst: all its samples were transmitted
+	StatusEventEndBurst StatusEventType = iota
+	// StatusEventUnderflow reports an underflow: the device ran out of samples to transmit
+	StatusEventUnderflow
+	// StatusEventTimeError reports a time error, such as a burst which reached the device after its time
+	StatusEventTimeError
+	// StatusEventOverflow reports an overflow: samples were dropped by the device
+	StatusEventOverflow
+	// StatusEventStatus reports a status without error which is not the end of a burst
+	StatusEventStatus
+	// StatusEventError reports another error returned by the stream. The monitor stops after reporting it.
+	StatusEventError
+)
+
+// String returns the name of the type of event
+func (eventType StatusEventType) String() string {
+
+	switch eventType {
+	case StatusEventEndBurst:
+		return "end of burst"
+	case StatusEventUnderflow:
+		return "underflow"
+	case StatusEventTimeError:
+		return "time error"
+	case StatusEventOverflow:
+		return "overflow"
+	case StatusEventStatus:
+		return "status"
+	default:
+		return "error"
+	}
+}
+
+// StatusEvent is an event reported by a StatusMonitor
+type StatusEvent struct {
+	// Type is the type of the event
+	Type StatusEventType
+	// TimeNs is the timestamp reported with the status in nanoseconds, valid if Flags has StreamFlagHasTime
+	TimeNs uint
+	// Flags are the flags reported with the status
+	Flags StreamFlag
+	// Err is the error returned by ReadStreamStatus, nil for StatusEventEndBurst and StatusEventStatus
+	Err error
+}
+
+// StatusStats are the counters of a StatusMonitor
+type StatusStats struct {
+	// EndBursts is the number of ends of burst
+	EndBursts uint64
+	// Underflows is the number of underflows
+	Underflows uint64
+	// TimeErrors is the number of time errors
+	TimeErrors uint64
+	// Overflows is the number of overflows
+	Overflows uint64
+	// Errors is the number of other errors
+	Errors uint64
+	// DroppedEvents is the number of events which could not be delivered because the events channel was full
+	DroppedEvents uint64
+}
+
+// StatusMonitor polls the status of a stream in a goroutine and delivers the statuses as typed events on a channel.
+// The statuses are consumed by the monitor: the stream status must not be read by anyone else while the monitor runs,
+// TransmitBurst() included.
+type StatusMonitor struct {
+	stream SDRStream
+
+	events    chan StatusEvent
+	done      chan struct{}
+	err       error
+	supported int32
+
+	nbEndBursts     uint64
+	nbUnderflows    uint64
+	nbTimeErrors    uint64
+	nbOverflows     uint64
+	nbErrors        uint64
+	nbDroppedEvents uint64
+}
+
+// StartStatusMonitor starts polling the status of a stream in a goroutine. The goroutine stops when the context is
+// cancelled, when the stream is closed, when the stream returns an error other than a timeout, an underflow, a time
+// error or an overflow, or when the stream reports that it does not support status, in which case the monitor disables
+// itself (see Supported()).
+//
+// Params:
+//  - ctx: the context controlling the life of the monitor
+//  - stream: the stream to monitor, usually a transmit stream
+//  - queueSize: the number of events which can be queued before being consumed. The events which do not fit are
+//    dropped and counted in the statistics of the monitor.
+//
+// Return the monitor
+func StartStatusMonitor(ctx context.Context, stream SDRStream, queueSize int) *StatusMonitor {
+
+	monitor := &StatusMonitor{
+		stream:    stream,
+		events:    make(chan StatusEvent, queueSize),
+		done:      make(chan struct{}),
+		supported: 1,
+	}
+
+	go monitor.run(ctx)
+
+	return monitor
+}
+
+// Events returns the channel delivering the events. The channel is closed when the monitor stops.
+func (monitor *StatusMonitor) Events() <-chan StatusEvent {
+
+	return monitor.events
+}
+
+// Done returns a channel which is closed when the monitor stops
+func (monitor *StatusMonitor) Done() <-chan struct{} {
+
+	return monitor.done
+}
+
+// Wait waits for the monitor to stop.
+//
+// Return the error which stopped the monitor: the error of the context if it was cancelled, the error returned by the
+// stream otherwise, nil if the stream does not support status
+func (monitor *StatusMonitor) Wait() error {
+
+	<-monitor.done
+
+	return monitor.err
+}
+
+// Supported returns false once the stream reported that it does not support status
+func (monitor *StatusMonitor) Supported() bool {
+
+	return atomic.LoadInt32(&monitor.supported) != 0
+}
+
+// Stats returns the counters of the monitor
+func (monitor *StatusMonitor) Stats() StatusStats {
+
+	return StatusStats{
+		EndBursts:     atomic.LoadUint64(&monitor.nbEndBursts),
+		Underflows:    atomic.LoadUint64(&monitor.nbUnderflows),
+		TimeErrors:    atomic.LoadUint64(&monitor.nbTimeErrors),
+		Overflows:     atomic.LoadUint64(&monitor.nbOverflows),
+		Errors:        atomic.LoadUint64(&monitor.nbErrors),
+		DroppedEvents: atomic.LoadUint64(&monitor.nbDroppedEvents),
+	}
+}
+
+// run is the polling loop of the monitor
+func (monitor *StatusMonitor) run(ctx context.Context) {
+
+	defer close(monitor.done)
+	defer close(monitor.events)
+
+	nbChannels := monitor.stream.GetNumChannels()
+	chanMask := make([]uint, nbChannels)
+	flags := make([]int, nbChannels)
+
+	for {
+		timeoutUs, err := contextTimeoutUs(ctx)
+		if err != nil {
+			monitor.err = err
+			return
+		}
+
+		for channelIdx := range flags {
+			flags[channelIdx] = 0
+		}
+
+		timeNs, err := monitor.stream.ReadStreamStatus(chanMask, flags, timeoutUs)
+		switch {
+		case err == nil:
+			monitor.publish(statusEvent(timeNs, StreamFlag(flags[0])))
+		case isTimeout(err):
+		case errors.Is(err, sdrerror.ErrNotSupported):
+			atomic.StoreInt32(&monitor.supported, 0)
+			return
+		case errors.Is(err, sdrerror.ErrClosed):
+			monitor.err = err
+			return
+		default:
+			event := errorEvent(err, timeNs, StreamFlag(flags[0]))
+			monitor.publish(event)
+			if event.Type == StatusEventError {
+				monitor.err = err
+				return
+			}
+		}
+	}
+}
+
+// statusEvent returns the event of a status read without error
+func statusEvent(timeNs uint, flags StreamFlag) StatusEvent {
+
+	event := StatusEvent{Type: StatusEventStatus, TimeNs: timeNs, Flags: flags}
+	if flags.Has(StreamFlagEndBurst) {
+		event.Type = StatusEventEndBurst
+	}
+
+	return event
+}
+
+// errorEvent returns the event of an error returned by ReadStreamStatus
+func errorEvent(err error, timeNs uint, flags StreamFlag) StatusEvent {
+
+	event := StatusEvent{Type: StatusEventError, TimeNs: timeNs, Flags: flags, Err: err}
+	switch {
+	case errors.Is(err, sdrerror.ErrUnderflow):
+		event.Type = StatusEventUnderflow
+	case errors.Is(err, sdrerror.ErrTime):
+		event.Type = StatusEventTimeError
+	case errors.Is(err, sdrerror.ErrOverflow):
+		event.Type = StatusEventOverflow
+	}
+
+	return event
+}
+
+// publish counts an event and delivers it if the events channel is not full
+func (monitor *StatusMonitor) publish(event StatusEvent) {
+
+	switch event.Type {
+	case StatusEventEndBurst:
+		atomic.AddUint64(&monitor.nbEndBursts, 1)
+	case StatusEventUnderflow:
+		atomic.AddUint64(&monitor.nbUnderflows, 1)
+	case StatusEventTimeError:
+		atomic.AddUint64(&monitor.nbTimeErrors, 1)
+	case StatusEventOverflow:
+		atomic.AddUint64(&monitor.nbOverflows, 1)
+	case StatusEventError:
+		atomic.AddUint64(&monitor.nbErrors, 1)
+	}
+
+	select {
+	case monitor.events <- event:
+	default:
+		atomic.AddUint64(&monitor.nbDroppedEvents, 1)
+	}
+}
@@ -0,0 +1,128 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+	"time"
+)
+
+// writeBurst writes a burst of one write to a transmit stream
+func writeBurst(t *testing.T, stream device.TypedStream[complex64], timeNs uint) {
+
+	flags := []int{int(device.StreamFlagHasTime | device.StreamFlagEndBurst)}
+	if _, err := stream.Write([][]complex64{make([]complex64, 100)}, 100, flags, timeNs, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// nextEvent returns the next event delivered by a monitor, failing the test if none is delivered in time
+func nextEvent(t *testing.T, monitor *device.StatusMonitor) device.StatusEvent {
+
+	select {
+	case event, ok := <-monitor.Events():
+		if !ok {
+			t.Fatal("the events channel is closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event delivered")
+	}
+
+	return device.StatusEvent{}
+}
+
+func TestStatusMonitorEvents(t *testing.T) {
+
+	dev, stream, _ := newTXStream(t)
+	if err := dev.SetHardwareTime(2e9, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	monitor := device.StartStatusMonitor(ctx, stream, 4)
+
+	writeBurst(t, stream, 1e9)
+	writeBurst(t, stream, 10e9)
+
+	if event := nextEvent(t, monitor); event.Type != device.StatusEventTimeError || !errors.Is(event.Err, sdrerror.ErrTime) || event.TimeNs != 1e9 {
+		t.Errorf("unexpected first event %+v", event)
+	}
+	if event := nextEvent(t, monitor); event.Type != device.StatusEventEndBurst || event.Err != nil || event.TimeNs != 10e9 {
+		t.Errorf("unexpected second event %+v", event)
+	}
+
+	// Cancelling the context stops the monitor and closes the events channel
+	cancel()
+	if err := monitor.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("the monitor stopped with %v", err)
+	}
+	if _, ok := <-monitor.Events(); ok {
+		t.Error("the events channel is still open")
+	}
+
+	stats := monitor.Stats()
+	if stats.TimeErrors != 1 || stats.EndBursts != 1 || stats.Errors != 0 || stats.DroppedEvents != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if !monitor.Supported() {
+		t.Error("the monitor of a transmit stream is not supported")
+	}
+}
+
+func TestStatusMonitorNotSupported(t *testing.T) {
+
+	// The simulated receive streams do not support status
+	_, stream := simtest.NewActiveRXStream[complex64](t, sim.Config{})
+
+	monitor := device.StartStatusMonitor(context.Background(), stream, 4)
+
+	select {
+	case <-monitor.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the monitor did not stop")
+	}
+	if err := monitor.Wait(); err != nil {
+		t.Errorf("the monitor stopped with %v", err)
+	}
+	if monitor.Supported() {
+		t.Error("the monitor is still supported")
+	}
+	if _, ok := <-monitor.Events(); ok {
+		t.Error("the events channel is still open")
+	}
+}
+
+func TestStatusMonitorDroppedEvents(t *testing.T) {
+
+	_, stream, _ := newTXStream(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	monitor := device.StartStatusMonitor(ctx, stream, 1)
+
+	// Nobody consumes the events: the first one is queued, the others are dropped
+	for i := uint(0); i < 3; i++ {
+		writeBurst(t, stream, 10e9+i*1e6)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for monitor.Stats().EndBursts < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if stats := monitor.Stats(); stats.EndBursts != 3 || stats.DroppedEvents != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if event := nextEvent(t, monitor); event.TimeNs != 10e9 {
+		t.Errorf("the queued event is %+v, expected the first end of burst", event)
+	}
+
+	cancel()
+	_ = monitor.Wait()
+}
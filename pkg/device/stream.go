@@ -1,7 +1,5 @@
 package device
 
-//go:generate go run gen/gen_streams.go
-
 // #cgo CFLAGS: -g -Wall
 // #cgo LDFLAGS: -lSoapySDR
 // #include <stdlib.h>
@@ -9,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"errors"
@@ -40,12 +39,28 @@ import (
 // Return a list of allowed format strings.
 func (dev *SDRDevice) GetStreamFormats(direction Direction, channel uint) []string {
 
+	formats, _ := dev.GetStreamFormatsChecked(direction, channel)
+
+	return formats
+}
+
+// GetStreamFormatsChecked queries a list of the available stream formats, returning the failure reported by the driver.
+// See GetStreamFormats() for the details.
+//
+// Return a list of allowed format strings and an error if the call failed
+func (dev *SDRDevice) GetStreamFormatsChecked(direction Direction, channel uint) (formats []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetStreamFormats", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getStreamFormats(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getStreamFormats(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), channelError(&status, "GetStreamFormats", direction, channel)
 }
 
 // GetNativeStreamFormat gets the hardware's native stream format for this channel.
@@ -59,12 +74,28 @@ func (dev *SDRDevice) GetStreamFormats(direction Direction, channel uint) []stri
 // Return the native stream buffer format string and the maximum possible value
 func (dev *SDRDevice) GetNativeStreamFormat(direction Direction, channel uint) (format string, fullScale float64) {
 
+	format, fullScale, _ = dev.GetNativeStreamFormatChecked(direction, channel)
+
+	return format, fullScale
+}
+
+// GetNativeStreamFormatChecked gets the hardware's native stream format for this channel, returning the failure
+// reported by the driver. See GetNativeStreamFormat() for the details.
+//
+// Return the native stream buffer format string, the maximum possible value and an error if the call failed
+func (dev *SDRDevice) GetNativeStreamFormatChecked(direction Direction, channel uint) (format string, fullScale float64, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", 0, rejectedError(sdrerror.ErrClosed, "GetNativeStreamFormat", direction.String())
+	}
+
 	scale := C.double(0.0)
 
-	val := (*C.char)(C.SoapySDRDevice_getNativeStreamFormat(dev.device, C.int(direction), C.size_t(channel), &scale))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getNativeStreamFormat(dev.device, C.int(direction), C.size_t(channel), &scale, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val), float64(fullScale)
+	return C.GoString(val), float64(scale), channelError(&status, "GetNativeStreamFormat", direction, channel)
 }
 
 // GetStreamArgsInfo queries the argument info description for stream args.
@@ -76,12 +107,28 @@ func (dev *SDRDevice) GetNativeStreamFormat(direction Direction, channel uint) (
 // Return a list of argument info structures
 func (dev *SDRDevice) GetStreamArgsInfo(direction Direction, channel uint) []SDRArgInfo {
 
+	infos, _ := dev.GetStreamArgsInfoChecked(direction, channel)
+
+	return infos
+}
+
+// GetStreamArgsInfoChecked queries the argument info description for stream args, returning the failure reported by the
+// driver. See GetStreamArgsInfo() for the details.
+//
+// Return a list of argument info structures and an error if the call failed
+func (dev *SDRDevice) GetStreamArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "GetStreamArgsInfo", direction.String())
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_getStreamArgsInfo(dev.device, C.int(direction), C.size_t(channel), &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_getStreamArgsInfo(dev.device, C.int(direction), C.size_t(channel), &length, &status)
 	defer argInfoListClear(info, length)
 
-	return argInfoList2Go(info, length)
+	return argInfoList2Go(info, length), channelError(&status, "GetStreamArgsInfo", direction, channel)
 }
 
 // ReadStreamStatus reads status information about a stream.
@@ -101,7 +148,7 @@ func (dev *SDRDevice) GetStreamArgsInfo(direction Direction, channel uint) []SDR
 //  - timeoutUs the timeout in microseconds
 //
 // Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func readStreamStatus(stream SDRStream, chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
+func readStreamStatus(stream cStream, chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
 
 	if uint(len(flags)) != stream.getNbChannels() {
 		return 0, errors.New("the flags buffer must have the same number of chanMask as the stream")
@@ -115,16 +162,18 @@ func readStreamStatus(stream SDRStream, chanMask []uint, flags []int, timeoutUs
 
 	cTimeNs := C.longlong(0)
 
+	var status C.SoapySDRGoStatus
 	result := int(
-		C.SoapySDRDevice_readStreamStatus(
+		C.SoapySDRGo_readStreamStatus(
 			stream.getDevice(),
 			stream.getStream(),
 			channelMasks,
 			cFlags,
 			&cTimeNs,
-			C.long(timeoutUs)))
+			C.long(timeoutUs),
+			&status))
 	if result < 0 {
-		return 0, sdrerror.Err(int(result))
+		return 0, directionError(&status, "ReadStreamStatus", stream.getDirection())
 	}
 
 	return uint(cTimeNs), nil
@@ -139,7 +188,7 @@ func readStreamStatus(stream SDRStream, chanMask []uint, flags []int, timeoutUs
 //  - stream the stream from which to retrieve the status
 //
 // Return the number of direct access buffers or 0
-func getNumDirectAccessBuffers(stream SDRStream) uint {
+func getNumDirectAccessBuffers(stream cStream) uint {
 
 	return uint(C.SoapySDRDevice_getNumDirectAccessBuffers(stream.getDevice(), stream.getStream()))
 }
@@ -0,0 +1,425 @@
+package device
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert/kernel"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"math"
+	"unsafe"
+)
+
+// SampleReader is an io.Reader over an activated receive stream. It returns the samples read from the stream as raw
+// little-endian values. When the stream has several channels, the samples of the channels are interleaved: the first
+// sample of each channel, then the second sample of each channel...
+//
+// A SampleReader is not safe for concurrent use.
+type SampleReader[T Sample] struct {
+	stream         TypedStream[T]
+	timeoutUs      uint
+	elemsPerSample uint
+	mtu            uint
+	buffers        [][]T
+	flags          []int
+	interleaved    []T
+	pending        []byte
+	data           []byte
+}
+
+// SampleWriter is an io.Writer over an activated transmit stream. It writes the raw little-endian values it receives
+// to the stream. When the stream has several channels, the samples of the channels must be interleaved: the first
+// sample of each channel, then the second sample of each channel...
+//
+// The bytes which do not form a complete sample for every channel are kept until the next call to Write.
+//
+// A SampleWriter is not safe for concurrent use.
+type SampleWriter[T Sample] struct {
+	stream         TypedStream[T]
+	timeoutUs      uint
+	elemsPerSample uint
+	mtu            uint
+	buffers        [][]T
+	flags          []int
+	interleaved    []T
+	pending        []byte
+	// The buffers of the samples remaining to write in writeAll
+	remaining [][]T
+}
+
+// NewSampleReader creates an io.Reader reading the samples of a receive stream. The stream must be activated before
+// reading.
+//
+// Params:
+//  - stream: the receive stream
+//  - timeoutUs: the timeout of each read on the stream in microseconds. Reads which time out are retried.
+//
+// Return the reader or an error if the format of the stream is not supported
+func NewSampleReader[T Sample](stream TypedStream[T], timeoutUs uint) (*SampleReader[T], error) {
+
+	elemsPerSample, err := FormatElemsPerSample[T](stream.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	mtu := uint(stream.GetMTU())
+	nbChannels := stream.GetNumChannels()
+
+	return &SampleReader[T]{
+		stream:         stream,
+		timeoutUs:      timeoutUs,
+		elemsPerSample: elemsPerSample,
+		mtu:            mtu,
+		buffers:        makeBuffers[T](nbChannels, mtu*elemsPerSample),
+		flags:          make([]int, nbChannels),
+		interleaved:    make([]T, nbChannels*mtu*elemsPerSample),
+	}, nil
+}
+
+// Read reads up to len(p) bytes of samples. Read blocks until at least one sample is available. The samples which do
+// not fit in p are returned by the next calls.
+//
+// Params:
+//  - p: the buffer receiving the bytes
+//
+// Return the number of bytes read and an error. Timeouts of the stream are retried and never returned.
+func (reader *SampleReader[T]) Read(p []byte) (n int, err error) {
+
+	return reader.ReadContext(context.Background(), p)
+}
+
+// ReadContext reads up to len(p) bytes of samples, like Read, until at least one sample is available or the context is
+// done.
+//
+// Params:
+//  - ctx: the context of the read
+//  - p: the buffer receiving the bytes
+//
+// Return the number of bytes read and an error, which is the error of the context if it is done before any sample is
+// read. Timeouts of the stream are retried and never returned.
+func (reader *SampleReader[T]) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for len(reader.pending) == 0 {
+
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		_, numElemsRead, err := reader.stream.Read(reader.buffers, reader.mtu, reader.flags, reader.timeoutUs)
+		if err != nil {
+			if isTimeout(err) {
+				continue
+			}
+			return 0, err
+		}
+
+		elems := interleave(reader.interleaved, reader.buffers, numElemsRead*reader.elemsPerSample, reader.elemsPerSample)
+		reader.data = encodeLittleEndian(reader.data[:0], elems)
+		reader.pending = reader.data
+	}
+
+	n = copy(p, reader.pending)
+	reader.pending = reader.pending[n:]
+
+	return n, nil
+}
+
+// NewSampleWriter creates an io.Writer writing samples to a transmit stream. The stream must be activated before
+// writing.
+//
+// Params:
+//  - stream: the transmit stream
+//  - timeoutUs: the timeout of each write on the stream in microseconds. Writes which time out are retried.
+//
+// Return the writer or an error if the format of the stream is not supported
+func NewSampleWriter[T Sample](stream TypedStream[T], timeoutUs uint) (*SampleWriter[T], error) {
+
+	elemsPerSample, err := FormatElemsPerSample[T](stream.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	mtu := uint(stream.GetMTU())
+	nbChannels := stream.GetNumChannels()
+
+	return &SampleWriter[T]{
+		stream:         stream,
+		timeoutUs:      timeoutUs,
+		elemsPerSample: elemsPerSample,
+		mtu:            mtu,
+		buffers:        makeBuffers[T](nbChannels, mtu*elemsPerSample),
+		flags:          make([]int, nbChannels),
+		interleaved:    make([]T, nbChannels*mtu*elemsPerSample),
+		remaining:      make([][]T, nbChannels),
+	}, nil
+}
+
+// Write writes the samples contained in p to the stream, in chunks of at most one MTU. Write blocks until all the
+// complete samples are written.
+//
+// Params:
+//  - p: the bytes of the samples
+//
+// Return the number of bytes consumed, which is len(p) in case of success, and an error. Timeouts of the stream are
+// retried and never returned. A write which accepts no sample without any error is reported as an error matching
+// sdrerror.ErrStream.
+func (writer *SampleWriter[T]) Write(p []byte) (n int, err error) {
+
+	var elem T
+
+	nbChannels := uint(len(writer.buffers))
+	frameSize := int(nbChannels*writer.elemsPerSample) * int(unsafe.Sizeof(elem))
+	chunkSize := int(writer.mtu) * frameSize
+
+	previous := len(writer.pending)
+	writer.pending = append(writer.pending, p...)
+	consumed := 0
+
+	for len(writer.pending)-consumed >= frameSize {
+
+		chunk := writer.pending[consumed:]
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		chunk = chunk[:len(chunk)-len(chunk)%frameSize]
+		nbSamples := uint(len(chunk) / frameSize)
+
+		elems := decodeLittleEndian(writer.interleaved[:nbSamples*nbChannels*writer.elemsPerSample], chunk)
+		deinterleave(writer.buffers, elems, writer.elemsPerSample)
+
+		if err := writer.writeAll(nbSamples); err != nil {
+			// The bytes of the failed chunk are dropped
+			writer.pending = writer.pending[:0]
+			if consumed < previous {
+				return 0, err
+			}
+			return consumed - previous, err
+		}
+
+		consumed += len(chunk)
+	}
+
+	// Keep the incomplete sample for the next call at the start of the buffer
+	writer.pending = writer.pending[:copy(writer.pending, writer.pending[consumed:])]
+
+	return len(p), nil
+}
+
+// writeAll writes the given number of samples of the buffers to the stream, retrying on timeouts and partial writes.
+// A write which accepts no sample without any error fails, as retrying it would never end.
+func (writer *SampleWriter[T]) writeAll(nbSamples uint) error {
+
+	written := uint(0)
+
+	for written < nbSamples {
+
+		for channelIdx := range writer.remaining {
+			writer.remaining[channelIdx] = writer.buffers[channelIdx][written*writer.elemsPerSample:]
+			writer.flags[channelIdx] = 0
+		}
+
+		numElemsWritten, err := writer.stream.Write(writer.remaining, nbSamples-written, writer.flags, 0, writer.timeoutUs)
+		if err != nil {
+			if isTimeout(err) {
+				continue
+			}
+			return err
+		}
+		if numElemsWritten == 0 {
+			return sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), "the stream accepted no sample", "Write", DirectionTX.String(), -1)
+		}
+
+		written += numElemsWritten
+	}
+
+	return nil
+}
+
+// isTimeout checks if an error returned by a stream is a timeout
+func isTimeout(err error) bool {
+
+	return errors.Is(err, sdrerror.ErrTimeout)
+}
+
+// makeBuffers allocates a buffer of the given length for each channel
+func makeBuffers[T Sample](nbChannels uint, length uint) [][]T {
+
+	buffers := make([][]T, nbChannels)
+	for channelIdx := range buffers {
+		buffers[channelIdx] = make([]T, length)
+	}
+
+	return buffers
+}
+
+// interleave copies the first length elements of each buffer into dst, one sample of each channel after the other
+func interleave[T Sample](dst []T, buffers [][]T, length uint, elemsPerSample uint) []T {
+
+	if len(buffers) == 1 {
+		return buffers[0][:length]
+	}
+
+	dst = dst[:0]
+	for offset := uint(0); offset < length; offset += elemsPerSample {
+		for _, buffer := range buffers {
+			dst = append(dst, buffer[offset:offset+elemsPerSample]...)
+		}
+	}
+
+	return dst
+}
+
+// deinterleave copies the samples of src, one sample of each channel after the other, to the buffers
+func deinterleave[T Sample](buffers [][]T, src []T, elemsPerSample uint) {
+
+	if len(buffers) == 1 {
+		copy(buffers[0], src)
+		return
+	}
+
+	frameSize := uint(len(buffers)) * elemsPerSample
+	for offset := uint(0); offset < uint(len(src)); offset += frameSize {
+		for channelIdx, buffer := range buffers {
+			start := offset + uint(channelIdx)*elemsPerSample
+			copy(buffer[offset/uint(len(buffers)):], src[start:start+elemsPerSample])
+		}
+	}
+}
+
+// encodeLittleEndian appends the little-endian representation of the elements to dst
+func encodeLittleEndian[T Sample](dst []byte, elems []T) []byte {
+
+	var elem T
+
+	start := len(dst)
+	length := start + len(elems)*int(unsafe.Sizeof(elem))
+	if cap(dst) < length {
+		dst = append(make([]byte, 0, length), dst...)
+	}
+	dst = dst[:length]
+	out := dst[start:]
+
+	switch values := any(elems).(type) {
+	case []uint8:
+		copy(out, values)
+	case []int8:
+		for i, v := range values {
+			out[i] = uint8(v)
+		}
+	case []uint16:
+		for i, v := range values {
+			binary.LittleEndian.PutUint16(out[2*i:], v)
+		}
+	case []int16:
+		for i, v := range values {
+			binary.LittleEndian.PutUint16(out[2*i:], uint16(v))
+		}
+	case []uint32:
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(out[4*i:], v)
+		}
+	case []int32:
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(out[4*i:], uint32(v))
+		}
+	case []float32:
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(out[4*i:], math.Float32bits(v))
+		}
+	case []float64:
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(out[8*i:], math.Float64bits(v))
+		}
+	case []complex64:
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(out[8*i:], math.Float32bits(real(v)))
+			binary.LittleEndian.PutUint32(out[8*i+4:], math.Float32bits(imag(v)))
+		}
+	case []complex128:
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(out[16*i:], math.Float64bits(real(v)))
+			binary.LittleEndian.PutUint64(out[16*i+8:], math.Float64bits(imag(v)))
+		}
+	case []CU8:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CU8, uint8](values))
+	case []CS8:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CS8, int8](values))
+	case []CU16:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CU16, uint16](values))
+	case []CS16:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CS16, int16](values))
+	case []CU32:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CU32, uint32](values))
+	case []CS32:
+		encodeLittleEndian(out[:0], kernel.Interleaved[CS32, int32](values))
+	}
+
+	return dst
+}
+
+// decodeLittleEndian decodes the little-endian representation of elements into elems
+func decodeLittleEndian[T Sample](elems []T, src []byte) []T {
+
+	switch values := any(elems).(type) {
+	case []uint8:
+		copy(values, src)
+	case []int8:
+		for i := range values {
+			values[i] = int8(src[i])
+		}
+	case []uint16:
+		for i := range values {
+			values[i] = binary.LittleEndian.Uint16(src[2*i:])
+		}
+	case []int16:
+		for i := range values {
+			values[i] = int16(binary.LittleEndian.Uint16(src[2*i:]))
+		}
+	case []uint32:
+		for i := range values {
+			values[i] = binary.LittleEndian.Uint32(src[4*i:])
+		}
+	case []int32:
+		for i := range values {
+			values[i] = int32(binary.LittleEndian.Uint32(src[4*i:]))
+		}
+	case []float32:
+		for i := range values {
+			values[i] = math.Float32frombits(binary.LittleEndian.Uint32(src[4*i:]))
+		}
+	case []float64:
+		for i := range values {
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(src[8*i:]))
+		}
+	case []complex64:
+		for i := range values {
+			values[i] = complex(
+				math.Float32frombits(binary.LittleEndian.Uint32(src[8*i:])),
+				math.Float32frombits(binary.LittleEndian.Uint32(src[8*i+4:])))
+		}
+	case []complex128:
+		for i := range values {
+			values[i] = complex(
+				math.Float64frombits(binary.LittleEndian.Uint64(src[16*i:])),
+				math.Float64frombits(binary.LittleEndian.Uint64(src[16*i+8:])))
+		}
+	case []CU8:
+		decodeLittleEndian(kernel.Interleaved[CU8, uint8](values), src)
+	case []CS8:
+		decodeLittleEndian(kernel.Interleaved[CS8, int8](values), src)
+	case []CU16:
+		decodeLittleEndian(kernel.Interleaved[CU16, uint16](values), src)
+	case []CS16:
+		decodeLittleEndian(kernel.Interleaved[CS16, int16](values), src)
+	case []CU32:
+		decodeLittleEndian(kernel.Interleaved[CU32, uint32](values), src)
+	case []CS32:
+		decodeLittleEndian(kernel.Interleaved[CS32, int32](values), src)
+	}
+
+	return elems
+}
@@ -0,0 +1,261 @@
+package device_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"testing"
+)
+
+// scriptedStream is a stream of CS16 samples whose reads and writes return the errors of a script
+type scriptedStream struct {
+	device.TypedStream[int16]
+	nbChannels uint
+	mtu        int
+	// errs are returned by the first calls, one per call, a nil error letting the call proceed
+	errs []error
+	// maxElems is the maximum number of samples accepted by a write, 0 if not limited
+	maxElems uint
+	// stalled makes the writes accept no sample
+	stalled bool
+	// read is the number of samples read from each channel
+	read int
+	// sizes are the numbers of samples of each read or write which succeeded
+	sizes []uint
+	// written are the elements written to each channel
+	written [][]int16
+}
+
+func (s *scriptedStream) GetFormat() string {
+
+	return device.FormatCS16
+}
+
+func (s *scriptedStream) GetMTU() int {
+
+	return s.mtu
+}
+
+func (s *scriptedStream) GetNumChannels() uint {
+
+	return s.nbChannels
+}
+
+// nextErr returns the next error of the script
+func (s *scriptedStream) nextErr() error {
+
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func (s *scriptedStream) Read(buffers [][]int16, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	if err := s.nextErr(); err != nil {
+		return 0, 0, err
+	}
+
+	for i := uint(0); i < nbElems; i++ {
+		for channelIdx, buffer := range buffers {
+			buffer[2*i], buffer[2*i+1] = sampleValue(s.read+int(i), channelIdx)
+		}
+	}
+	s.read += int(nbElems)
+	s.sizes = append(s.sizes, nbElems)
+
+	return 0, nbElems, nil
+}
+
+func (s *scriptedStream) Write(buffers [][]int16, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+
+	if err := s.nextErr(); err != nil {
+		return 0, err
+	}
+	if s.stalled {
+		return 0, nil
+	}
+
+	if s.maxElems != 0 && nbElems > s.maxElems {
+		nbElems = s.maxElems
+	}
+	if s.written == nil {
+		s.written = make([][]int16, len(buffers))
+	}
+	for channelIdx, buffer := range buffers {
+		s.written[channelIdx] = append(s.written[channelIdx], buffer[:2*nbElems]...)
+	}
+	s.sizes = append(s.sizes, nbElems)
+
+	return nbElems, nil
+}
+
+// sampleValue returns the I and Q values of a sample of a channel of a scripted stream
+func sampleValue(sampleIdx int, channelIdx int) (int16, int16) {
+
+	value := int16(10*sampleIdx + channelIdx)
+
+	return value, -value
+}
+
+// interleavedBytes returns the bytes of the given samples of a scripted stream, interleaving the channels
+func interleavedBytes(from int, to int, nbChannels int) []byte {
+
+	var data []byte
+	for sampleIdx := from; sampleIdx < to; sampleIdx++ {
+		for channelIdx := 0; channelIdx < nbChannels; channelIdx++ {
+			i, q := sampleValue(sampleIdx, channelIdx)
+			sample := make([]byte, 4)
+			binary.LittleEndian.PutUint16(sample, uint16(i))
+			binary.LittleEndian.PutUint16(sample[2:], uint16(q))
+			data = append(data, sample...)
+		}
+	}
+
+	return data
+}
+
+// checkWritten checks that the given samples were written to each channel of a scripted stream
+func checkWritten(t *testing.T, stream *scriptedStream, from int, to int) {
+
+	t.Helper()
+
+	for channelIdx := 0; channelIdx < int(stream.nbChannels); channelIdx++ {
+		var expected []int16
+		for sampleIdx := from; sampleIdx < to; sampleIdx++ {
+			i, q := sampleValue(sampleIdx, channelIdx)
+			expected = append(expected, i, q)
+		}
+		var written []int16
+		if stream.written != nil {
+			written = stream.written[channelIdx]
+		}
+		if len(written) != len(expected) {
+			t.Errorf("channel %v: %v elements written, expected %v", channelIdx, len(written), len(expected))
+			continue
+		}
+		for i := range expected {
+			if written[i] != expected[i] {
+				t.Errorf("channel %v: elements %v written, expected %v", channelIdx, written, expected)
+				break
+			}
+		}
+	}
+}
+
+func TestSampleReader(t *testing.T) {
+
+	// A timeout is retried
+	stream := &scriptedStream{nbChannels: 2, mtu: 3, errs: []error{sdrerror.ErrTimeout}}
+	reader, err := device.NewSampleReader[int16](stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The reads of 5 bytes split the samples of 8 bytes, the rest of each sample is returned by the next read
+	var data []byte
+	p := make([]byte, 5)
+	for len(data) < 2*3*8 {
+		n, err := reader.Read(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, p[:n]...)
+	}
+
+	if !bytes.Equal(data, interleavedBytes(0, 6, 2)) {
+		t.Errorf("read %v, expected %v", data, interleavedBytes(0, 6, 2))
+	}
+	if len(stream.sizes) != 2 || stream.sizes[0] != 3 || stream.sizes[1] != 3 {
+		t.Errorf("reads of %v samples, expected 2 reads of an MTU", stream.sizes)
+	}
+
+	// Another error is returned
+	stream.errs = []error{sdrerror.ErrOverflow}
+	if n, err := reader.Read(p); n != 0 || !errors.Is(err, sdrerror.ErrOverflow) {
+		t.Errorf("read of %v bytes returned %v, expected an overflow", n, err)
+	}
+}
+
+func TestSampleWriter(t *testing.T) {
+
+	stream := &scriptedStream{nbChannels: 2, mtu: 3}
+	writer, err := device.NewSampleWriter[int16](stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The samples are written in chunks of an MTU
+	data := interleavedBytes(0, 7, 2)
+	if n, err := writer.Write(data); n != len(data) || err != nil {
+		t.Fatalf("write of %v bytes: %v bytes consumed, %v", len(data), n, err)
+	}
+	if len(stream.sizes) != 3 || stream.sizes[0] != 3 || stream.sizes[1] != 3 || stream.sizes[2] != 1 {
+		t.Errorf("writes of %v samples, expected 3, 3 and 1 samples", stream.sizes)
+	}
+	checkWritten(t, stream, 0, 7)
+}
+
+func TestSampleWriterPartialWrites(t *testing.T) {
+
+	// The stream times out, then accepts at most 2 samples per write
+	stream := &scriptedStream{nbChannels: 2, mtu: 3, maxElems: 2, errs: []error{sdrerror.ErrTimeout}}
+	writer, err := device.NewSampleWriter[int16](stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The writes of 5 bytes split the samples of 8 bytes, the incomplete samples are kept for the next write
+	data := interleavedBytes(0, 10, 2)
+	for offset := 0; offset < len(data); offset += 5 {
+		end := offset + 5
+		if end > len(data) {
+			end = len(data)
+		}
+		if n, err := writer.Write(data[offset:end]); n != end-offset || err != nil {
+			t.Fatalf("write of %v bytes: %v bytes consumed, %v", end-offset, n, err)
+		}
+	}
+
+	for _, size := range stream.sizes {
+		if size == 0 || size > 2 {
+			t.Errorf("writes of %v samples, expected at most 2 samples", stream.sizes)
+			break
+		}
+	}
+	checkWritten(t, stream, 0, 10)
+}
+
+func TestSampleWriterErrors(t *testing.T) {
+
+	stream := &scriptedStream{nbChannels: 2, mtu: 3}
+	writer, err := device.NewSampleWriter[int16](stream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bytes of the chunk which failed are dropped, including the incomplete sample of the previous write
+	data := interleavedBytes(0, 3, 2)
+	if n, err := writer.Write(data[:3]); n != 3 || err != nil {
+		t.Fatalf("write of an incomplete sample: %v bytes consumed, %v", n, err)
+	}
+	stream.errs = []error{sdrerror.ErrUnderflow}
+	if n, err := writer.Write(data[3:16]); n != 0 || !errors.Is(err, sdrerror.ErrUnderflow) {
+		t.Errorf("failed write: %v bytes consumed, %v", n, err)
+	}
+	if n, err := writer.Write(data[16:]); n != 8 || err != nil {
+		t.Fatalf("write after the error: %v bytes consumed, %v", n, err)
+	}
+	checkWritten(t, stream, 2, 3)
+
+	// A write which makes no progress fails instead of being retried forever
+	stream.stalled = true
+	if _, err := writer.Write(data); !errors.Is(err, sdrerror.ErrStream) {
+		t.Errorf("write without progress returned %v, expected a stream error", err)
+	}
+}
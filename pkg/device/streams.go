@@ -1,9 +1,3 @@
-// Package device regroups all the functions for accessing
-// devices and streams.
-//
-// Code generated by go generate; DO NOT EDIT.
-//
-// This file was generated by gen_streams.go at 2019-03-25 02:32:46.51218605 +0200 EET m=+0.001173895
 package device
 
 // #cgo CFLAGS: -g -Wall
@@ -13,10 +7,14 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sync"
 	"unsafe"
 )
 
@@ -26,75 +24,199 @@ import (
 /*                                                                                    */
 /* ********************************************************************************** */
 
-// SDRStreamCU8 is a stream for accessing data in CU8 format
-type SDRStreamCU8 struct {
-	device      *C.SoapySDRDevice
-	stream      *C.SoapySDRStream
-	nbChannels  uint
-	readBuffer  **C.void
-	writeBuffer **C.void
-}
-
-// SDRStreamCS8 is a stream for accessing data in CS8 format
-type SDRStreamCS8 struct {
-	device      *C.SoapySDRDevice
-	stream      *C.SoapySDRStream
-	nbChannels  uint
-	readBuffer  **C.void
-	writeBuffer **C.void
-}
-
-// SDRStreamCU16 is a stream for accessing data in CU16 format
-type SDRStreamCU16 struct {
-	device      *C.SoapySDRDevice
-	stream      *C.SoapySDRStream
-	nbChannels  uint
-	readBuffer  **C.void
-	writeBuffer **C.void
-}
-
-// SDRStreamCS16 is a stream for accessing data in CS16 format
-type SDRStreamCS16 struct {
-	device      *C.SoapySDRDevice
-	stream      *C.SoapySDRStream
-	nbChannels  uint
-	readBuffer  **C.void
-	writeBuffer **C.void
-}
-
-// SDRStreamCF32 is a stream for accessing data in CF32 format
-type SDRStreamCF32 struct {
-	device      *C.SoapySDRDevice
-	stream      *C.SoapySDRStream
-	nbChannels  uint
-	readBuffer  **C.void
-	writeBuffer **C.void
-}
-
-// SDRStreamCF64 is a stream for accessing data in CF64 format
-type SDRStreamCF64 struct {
+// Sample is the constraint gathering the Go types which can be used as elements of a stream. By default, the format
+// of the stream is deduced from the type of its elements (see StreamFormat()):
+//   - uint8: CU8, I and Q interleaved (2 elements per sample)
+//   - int8: CS8, I and Q interleaved (2 elements per sample)
+//   - uint16: CU16, I and Q interleaved (2 elements per sample)
+//   - int16: CS16, I and Q interleaved (2 elements per sample)
+//   - uint32: CU32, I and Q interleaved (2 elements per sample)
+//   - int32: CS32, I and Q interleaved (2 elements per sample)
+//   - float32: F32 (1 element per sample)
+//   - float64: F64 (1 element per sample)
+//   - complex64: CF32 (1 element per sample)
+//   - complex128: CF64 (1 element per sample)
+//   - CU8, CS8, CU16, CS16, CU32, CS32: the format of the same name (1 element per sample)
+// The other formats, such as the real integer formats or the packed formats, are selected with SetupStreamFormat().
+type Sample interface {
+	uint8 | int8 | uint16 | int16 | uint32 | int32 | float32 | float64 | complex64 | complex128 |
+		CU8 | CS8 | CU16 | CS16 | CU32 | CS32
+}
+
+// Stream is a stream for accessing data whose elements are of type T
+type Stream[T Sample] struct {
+	*streamCore
+	format         string
+	elemsPerSample uint
+	nbChannels     uint
+
+	// The results of the C reads and writes are kept in the stream so that the reads and the writes do not allocate
+	readTimeNs  C.longlong
+	readStatus  C.SoapySDRGoStatus
+	writeStatus C.SoapySDRGoStatus
+}
+
+// streamCore is the part of a stream which is closed by Close() or, if the stream is still open, by the Unmake() of
+// its device. The device references the cores of its streams, and a core references neither its stream nor its device:
+// a device and its streams are not in a reference cycle, which would prevent their leak finalizers from running.
+type streamCore struct {
 	device      *C.SoapySDRDevice
 	stream      *C.SoapySDRStream
-	nbChannels  uint
+	direction   Direction
 	readBuffer  **C.void
 	writeBuffer **C.void
-}
+	handle      uint64
+	streams     *streamSet
+	stateMutex  sync.RWMutex
+	state       streamState
+}
+
+// streamState is the state of a stream
+type streamState int
+
+const (
+	// streamCreated is the state of a stream which is set up or deactivated
+	streamCreated streamState = iota
+	// streamActive is the state of an activated stream
+	streamActive
+	// streamClosed is the state of a closed stream, whose C stream is freed
+	streamClosed
+)
 
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCU8                             */
-/*                                                                                    */
-/* ********************************************************************************** */
+// StreamReader is the interface of the streams receiving data whose elements are of type T
+type StreamReader[T Sample] interface {
+	// Read reads elements from a stream for reception. The elements are written in the given buffer which must be
+	// allocated before call.
+	//
+	// See Stream.Read() for the details.
+	Read(buffers [][]T, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error)
+}
+
+// StreamWriter is the interface of the streams transmitting data whose elements are of type T
+type StreamWriter[T Sample] interface {
+	// Write writes elements to a stream for transmission.
+	//
+	// See Stream.Write() for the details.
+	Write(buffers [][]T, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error)
+}
+
+// TypedStream is the interface of the streams accessing data whose elements are of type T. It gathers the functions
+// common to all streams, the typed read/write functions and the typed direct buffer access functions.
+type TypedStream[T Sample] interface {
+	SDRStream
+	StreamReader[T]
+	StreamWriter[T]
+
+	// ReadBuffers reads elements from a stream for reception, as many as fit in the given buffers.
+	//
+	// See Stream.ReadBuffers() for the details.
+	ReadBuffers(buffers [][]T, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error)
+
+	// WriteBuffers writes all the elements of the given buffers to a stream for transmission.
+	//
+	// See Stream.WriteBuffers() for the details.
+	WriteBuffers(buffers [][]T, flags []int, timeNs uint, timeoutUs uint) (numElemsWritten uint, err error)
+
+	// ReadContext reads elements from a stream until elements are received or the context is done.
+	//
+	// See the ReadContext function for the details.
+	ReadContext(ctx context.Context, buffers [][]T, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error)
+
+	// WriteContext writes elements to a stream until elements are sent or the context is done.
+	//
+	// See the WriteContext function for the details.
+	WriteContext(ctx context.Context, buffers [][]T, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error)
+
+	// TransmitBurst transmits a burst of samples, split by MTU, and reports the status of the burst.
+	//
+	// See the TransmitBurst function for the details.
+	TransmitBurst(ctx context.Context, samples [][]T, atTimeNs uint) (report BurstReport, err error)
+
+	// GetDirectAccessBufferAddrs gets the buffers of a scatter/gather table entry.
+	//
+	// See Stream.GetDirectAccessBufferAddrs() for the details.
+	GetDirectAccessBufferAddrs(handle uint) (buffers [][]T, err error)
+
+	// AcquireReadBuffer acquires direct buffers from a receive stream, without any copy.
+	//
+	// See Stream.AcquireReadBuffer() for the details.
+	AcquireReadBuffer(outputFlags []int, timeoutUs uint) (handle uint, buffers [][]T, timeNs uint, numElemsRead uint, err error)
+
+	// AcquireWriteBuffer acquires direct buffers from a transmit stream, without any copy.
+	//
+	// See Stream.AcquireWriteBuffer() for the details.
+	AcquireWriteBuffer(timeoutUs uint) (handle uint, buffers [][]T, numElems uint, err error)
+}
+
+// Ensure Stream implements TypedStream and gives access to the internal C objects
+var _ TypedStream[complex64] = (*Stream[complex64])(nil)
+var _ cStream = (*Stream[complex64])(nil)
+
+// Names of the streams and of their interfaces for each format
+type (
+	// SDRStreamCU8 is a stream for accessing data in CU8 format
+	SDRStreamCU8 = Stream[uint8]
+	// SDRStreamCS8 is a stream for accessing data in CS8 format
+	SDRStreamCS8 = Stream[int8]
+	// SDRStreamCU16 is a stream for accessing data in CU16 format
+	SDRStreamCU16 = Stream[uint16]
+	// SDRStreamCS16 is a stream for accessing data in CS16 format
+	SDRStreamCS16 = Stream[int16]
+	// SDRStreamCF32 is a stream for accessing data in CF32 format
+	SDRStreamCF32 = Stream[complex64]
+	// SDRStreamCF64 is a stream for accessing data in CF64 format
+	SDRStreamCF64 = Stream[complex128]
+
+	// StreamReaderCU8 is the interface of the streams receiving data in CU8 format
+	StreamReaderCU8 = StreamReader[uint8]
+	// StreamReaderCS8 is the interface of the streams receiving data in CS8 format
+	StreamReaderCS8 = StreamReader[int8]
+	// StreamReaderCU16 is the interface of the streams receiving data in CU16 format
+	StreamReaderCU16 = StreamReader[uint16]
+	// StreamReaderCS16 is the interface of the streams receiving data in CS16 format
+	StreamReaderCS16 = StreamReader[int16]
+	// StreamReaderCF32 is the interface of the streams receiving data in CF32 format
+	StreamReaderCF32 = StreamReader[complex64]
+	// StreamReaderCF64 is the interface of the streams receiving data in CF64 format
+	StreamReaderCF64 = StreamReader[complex128]
+
+	// StreamWriterCU8 is the interface of the streams transmitting data in CU8 format
+	StreamWriterCU8 = StreamWriter[uint8]
+	// StreamWriterCS8 is the interface of the streams transmitting data in CS8 format
+	StreamWriterCS8 = StreamWriter[int8]
+	// StreamWriterCU16 is the interface of the streams transmitting data in CU16 format
+	StreamWriterCU16 = StreamWriter[uint16]
+	// StreamWriterCS16 is the interface of the streams transmitting data in CS16 format
+	StreamWriterCS16 = StreamWriter[int16]
+	// StreamWriterCF32 is the interface of the streams transmitting data in CF32 format
+	StreamWriterCF32 = StreamWriter[complex64]
+	// StreamWriterCF64 is the interface of the streams transmitting data in CF64 format
+	StreamWriterCF64 = StreamWriter[complex128]
+
+	// TypedStreamCU8 is the interface of the streams accessing data in CU8 format
+	TypedStreamCU8 = TypedStream[uint8]
+	// TypedStreamCS8 is the interface of the streams accessing data in CS8 format
+	TypedStreamCS8 = TypedStream[int8]
+	// TypedStreamCU16 is the interface of the streams accessing data in CU16 format
+	TypedStreamCU16 = TypedStream[uint16]
+	// TypedStreamCS16 is the interface of the streams accessing data in CS16 format
+	TypedStreamCS16 = TypedStream[int16]
+	// TypedStreamCF32 is the interface of the streams accessing data in CF32 format
+	TypedStreamCF32 = TypedStream[complex64]
+	// TypedStreamCF64 is the interface of the streams accessing data in CF64 format
+	TypedStreamCF64 = TypedStream[complex128]
+)
 
 /* ********************************************************************************** */
 /*                          CREATION OF STREAMS                                       */
 /* ********************************************************************************** */
 
-// SetupSDRStreamCU8 initializes a stream given a list of channels and stream arguments.
+// SetupStream initializes a stream given a list of channels and stream arguments. The format of the stream is deduced
+// from the type of its elements, see StreamFormat(). Other formats are available with SetupStreamFormat().
 //
 // The implementation may change switches or power-up components.
 // All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCU8() is complete, regardless of the activity state.
+// after SetupStream() is complete, regardless of the activity state.
 //
 // The API allows any number of simultaneous TX and RX streams, but many dual-channel
 // devices are limited to one stream in each direction, using either one or both channels.
@@ -105,6 +227,7 @@ type SDRStreamCF64 struct {
 // the same sample rate. See SetSampleRate().
 //
 // Params:
+//  - dev: the device on which the stream is created
 //  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
 //  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
 //    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
@@ -115,15 +238,46 @@ type SDRStreamCF64 struct {
 // Recommended keys to use in the args dictionary:
 //   - "WIRE" - format of the samples between device and host
 //
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCU8(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCU8, err error) {
+// Return the stream and an error. The stream is not required to have internal locking, and may not be used
+// concurrently from multiple threads.
+func SetupStream[T Sample](dev *SDRDevice, direction Direction, channels []uint, args map[string]string) (stream *Stream[T], err error) {
+
+	format, _, err := StreamFormat[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	return SetupStreamFormat[T](dev, format, direction, channels, args)
+}
+
+// SetupStreamFormat initializes a stream in the given format. The format must be stored in elements of type T, for
+// example FormatS16 and FormatCS16 use int16 elements, and the packed formats FormatCS12, FormatCU12, FormatCS4 and
+// FormatCU4 use uint8 elements (see UnpackCS12() and the other unpacking functions).
+//
+// Params:
+//  - dev: the device on which the stream is created
+//  - format: the format of the stream, such as FormatS16
+//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
+//  - channels: a list of channels. The channels must be explicitly defined.
+//  - args: stream args or empty for defaults
+//
+// Return the stream and an error. See SetupStream() for the details.
+func SetupStreamFormat[T Sample](dev *SDRDevice, format string, direction Direction, channels []uint, args map[string]string) (stream *Stream[T], err error) {
 
 	if len(channels) == 0 {
 		return nil, errors.New("the channels must be given explicitly during stream setup")
 	}
 
-	cFormat := C.CString("CU8")
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "SetupStream", direction.String())
+	}
+
+	elemsPerSample, err := FormatElemsPerSample[T](format)
+	if err != nil {
+		return nil, err
+	}
+
+	cFormat := C.CString(format)
 	defer C.free(unsafe.Pointer(cFormat))
 
 	cArgs, cArgsLength := go2Args(args)
@@ -132,10 +286,11 @@ func (dev *SDRDevice) SetupSDRStreamCU8(direction Direction, channels []uint, ar
 	cChannels, cChannelsLength := go2SizeTList(channels)
 	defer C.free(unsafe.Pointer(cChannels))
 
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
+	var status C.SoapySDRGoStatus
+	val := C.SoapySDRGo_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs, &status)
 
 	if val == nil {
-		return nil, errors.New(LastError())
+		return nil, createError(&status, "SetupStream", direction.String())
 	}
 
 	var voidPtrTemplate *C.void
@@ -146,53 +301,194 @@ func (dev *SDRDevice) SetupSDRStreamCU8(direction Direction, channels []uint, ar
 	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
 	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
 
-	return &SDRStreamCU8{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
+	if dev.streams == nil {
+		dev.streams = &streamSet{}
+	}
+
+	stream = trackStream(&Stream[T]{
+		streamCore: &streamCore{
+			device:      dev.device,
+			stream:      val,
+			direction:   direction,
+			readBuffer:  readBuffers,
+			writeBuffer: writeBuffers,
+			streams:     dev.streams,
+		},
+		format:         format,
+		elemsPerSample: elemsPerSample,
+		nbChannels:     nbChannels,
+	}, channels)
+	dev.streams.add(stream.streamCore)
+
+	return stream, nil
+}
+
+// setupTypedStream initializes a stream and returns it through its interface, a failed setup returning a nil
+// interface rather than a nil *Stream
+func setupTypedStream[T Sample](dev *SDRDevice, direction Direction, channels []uint, args map[string]string) (TypedStream[T], error) {
+
+	stream, err := SetupStream[T](dev, direction, channels, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// SetupSDRStreamCU8 initializes a stream in CU8 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCU8.
+func (dev *SDRDevice) SetupSDRStreamCU8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU8, err error) {
+
+	return setupTypedStream[uint8](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCS8 initializes a stream in CS8 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCS8.
+func (dev *SDRDevice) SetupSDRStreamCS8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS8, err error) {
+
+	return setupTypedStream[int8](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCU16 initializes a stream in CU16 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCU16.
+func (dev *SDRDevice) SetupSDRStreamCU16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU16, err error) {
+
+	return setupTypedStream[uint16](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCS16 initializes a stream in CS16 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCS16.
+func (dev *SDRDevice) SetupSDRStreamCS16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS16, err error) {
+
+	return setupTypedStream[int16](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCF32 initializes a stream in CF32 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCF32.
+func (dev *SDRDevice) SetupSDRStreamCF32(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF32, err error) {
+
+	return setupTypedStream[complex64](dev, direction, channels, args)
+}
+
+// SetupSDRStreamCF64 initializes a stream in CF64 format. See SetupStream() for the details.
+//
+// Return the stream and an error. The returned stream is a *SDRStreamCF64.
+func (dev *SDRDevice) SetupSDRStreamCF64(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF64, err error) {
+
+	return setupTypedStream[complex128](dev, direction, channels, args)
 }
 
 /* ********************************************************************************** */
 /*                                GETTER AND SETTER                                   */
 /* ********************************************************************************** */
+
 // getDevice returns the internal device
-func (stream *SDRStreamCU8) getDevice() *C.SoapySDRDevice {
+func (stream *Stream[T]) getDevice() *C.SoapySDRDevice {
 	return stream.device
 }
 
 // getStream returns the internal stream
-func (stream *SDRStreamCU8) getStream() *C.SoapySDRStream {
+func (stream *Stream[T]) getStream() *C.SoapySDRStream {
 	return stream.stream
 }
 
+// getDirection returns the direction of the stream
+func (stream *Stream[T]) getDirection() Direction {
+	return stream.direction
+}
+
 // getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCU8) getNbChannels() uint {
+func (stream *Stream[T]) getNbChannels() uint {
 	return stream.nbChannels
 }
 
+// GetFormat returns the format of the elements of the stream, such as "CF32"
+func (stream *Stream[T]) GetFormat() string {
+	return stream.format
+}
+
+// GetNumChannels returns the number of channels of the stream
+func (stream *Stream[T]) GetNumChannels() uint {
+	return stream.nbChannels
+}
+
+// lockState takes the state lock of the stream for a call on the C stream. The call is rejected if the stream is
+// closed, which is the case once its device is unmade, or, when it requires an active stream, if the stream is not
+// active. If no error is returned, the lock must be released with stream.stateMutex.RUnlock() once the call is done.
+//
+// Params:
+//  - op: the name of the call
+//  - active: true if the call requires an active stream
+//
+// Return an error if the call is rejected
+func (stream *Stream[T]) lockState(op string, active bool) sdrerror.SDRError {
+
+	stream.stateMutex.RLock()
+
+	switch {
+	case stream.state == streamClosed:
+		stream.stateMutex.RUnlock()
+		return rejectedError(sdrerror.ErrClosed, op, stream.direction.String())
+	case active && stream.state != streamActive:
+		stream.stateMutex.RUnlock()
+		return rejectedError(sdrerror.ErrNotActive, op, stream.direction.String())
+	}
+
+	return nil
+}
+
 /* ********************************************************************************** */
 /*                                STREAMS FUNCTIONS                                   */
 /* ********************************************************************************** */
 
-// Close closes an open stream created by setupStream
+// Close closes an open stream created by setupStream. Close waits for the calls in progress on the stream, such as a
+// Read(), to complete. Any call on the stream after Close() returns an error matching sdrerror.ErrClosed. The stream
+// is closed by the Unmake() of its device if it is still open.
 //
 // Params:
 //  - stream: the opaque pointer to a stream handle
 //
-// Return an error or nil in case of success
-func (stream *SDRStreamCU8) Close() (err sdrerror.SDRError) {
+// Return an error or nil in case of success, sdrerror.ErrClosed if the stream is already closed
+func (stream *Stream[T]) Close() (err sdrerror.SDRError) {
+
+	untrackStream(stream)
+
+	return stream.streamCore.close()
+}
+
+// close closes the C stream and frees the buffers of the stream, once the calls in progress on the stream are
+// completed.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the stream is already closed
+func (core *streamCore) close() (err sdrerror.SDRError) {
+
+	core.stateMutex.Lock()
+	defer core.stateMutex.Unlock()
+
+	if core.state == streamClosed {
+		return rejectedError(sdrerror.ErrClosed, "Close", core.direction.String())
+	}
 
 	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
+	C.free(unsafe.Pointer(core.readBuffer))
+	C.free(unsafe.Pointer(core.writeBuffer))
 	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
+	core.readBuffer = nil
+	core.writeBuffer = nil
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_closeStream(core.device, core.stream, &status)
+	core.stream = nil
+	core.state = streamClosed
+	closeHandle(core.handle)
+	core.streams.remove(core)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
+	return directionError(&status, "Close", core.direction)
 }
 
 // GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
@@ -200,8 +496,13 @@ func (stream *SDRStreamCU8) Close() (err sdrerror.SDRError) {
 // The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
 // allocation size that can best optimize throughput given the underlying stream implementation.
 //
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCU8) GetMTU() int {
+// Return the MTU in number of stream elements (never zero), 0 if the stream is closed
+func (stream *Stream[T]) GetMTU() int {
+
+	if stream.lockState("GetMTU", false) != nil {
+		return 0
+	}
+	defer stream.stateMutex.RUnlock()
 
 	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
 }
@@ -219,9 +520,24 @@ func (stream *SDRStreamCU8) GetMTU() int {
 //  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
 //
 // Return an error or nil in case of success
-func (stream *SDRStreamCU8) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
+func (stream *Stream[T]) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
+
+	stream.stateMutex.Lock()
+	defer stream.stateMutex.Unlock()
+
+	if stream.state == streamClosed {
+		return rejectedError(sdrerror.ErrClosed, "Activate", stream.direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems), &status)
+
+	err = directionError(&status, "Activate", stream.direction)
+	if err == nil {
+		stream.state = streamActive
+	}
 
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
+	return err
 }
 
 // Deactivate deactivates a stream.
@@ -234,9 +550,24 @@ func (stream *SDRStreamCU8) Activate(flags StreamFlag, timeNs int, numElems int)
 //  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
 //
 // Return an error or nil in case of success
-func (stream *SDRStreamCU8) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
+func (stream *Stream[T]) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
 
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
+	stream.stateMutex.Lock()
+	defer stream.stateMutex.Unlock()
+
+	if stream.state == streamClosed {
+		return rejectedError(sdrerror.ErrClosed, "Deactivate", stream.direction.String())
+	}
+
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), &status)
+
+	err = directionError(&status, "Deactivate", stream.direction)
+	if err == nil {
+		stream.state = streamCreated
+	}
+
+	return err
 }
 
 // GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
@@ -245,11 +576,159 @@ func (stream *SDRStreamCU8) Deactivate(flags StreamFlag, timeNs int) (err sdrerr
 // release(). A return value of 0 means that direct access is not supported.
 //
 // Return the number of direct access buffers or 0
-func (stream *SDRStreamCU8) GetNumDirectAccessBuffers() uint {
+func (stream *Stream[T]) GetNumDirectAccessBuffers() uint {
+
+	if stream.lockState("GetNumDirectAccessBuffers", false) != nil {
+		return 0
+	}
+	defer stream.stateMutex.RUnlock()
 
 	return getNumDirectAccessBuffers(stream)
 }
 
+/* ********************************************************************************** */
+/*                             DIRECT BUFFER ACCESS FUNCTIONS                         */
+/* ********************************************************************************** */
+
+// directBuffers maps the driver memory of direct access buffers to slices of T
+func (stream *Stream[T]) directBuffers(addrs []unsafe.Pointer, numElems uint) [][]T {
+
+	length := numElems * stream.elemsPerSample
+
+	buffers := make([][]T, len(addrs))
+	for channelIdx, addr := range addrs {
+		buffers[channelIdx] = unsafe.Slice((*T)(addr), length)
+	}
+
+	return buffers
+}
+
+// GetDirectAccessBufferAddrs gets the buffers of a scatter/gather table entry.
+//
+// When the underlying DMA implementation uses scatter/gather then this call provides the user addresses for that
+// table. The returned slices map the driver memory directly and are sized to hold an MTU of elements. They are only
+// valid while the stream is open.
+//
+// Note that one stream element may use more than one slice element. For example complex data stored in non complex
+// slice (such as CS8) will use 2 elements of the slice for 1 single element.
+//
+// Params:
+//  - handle: an index value between 0 and GetNumDirectAccessBuffers() - 1
+//
+// Return the buffer of each channel, or an error
+func (stream *Stream[T]) GetDirectAccessBufferAddrs(handle uint) (buffers [][]T, err error) {
+
+	if err := stream.lockState("GetDirectAccessBufferAddrs", false); err != nil {
+		return nil, err
+	}
+	defer stream.stateMutex.RUnlock()
+
+	addrs, err := getDirectAccessBufferAddrs(stream, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.directBuffers(addrs, uint(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))), nil
+}
+
+// AcquireReadBuffer acquires direct buffers from a receive stream, without any copy.
+//
+// This call is part of the direct buffer access API. The returned slices map the driver memory directly: they are only
+// valid until ReleaseReadBuffer() is called with the returned handle and must not be used nor retained afterwards.
+// The number of buffers which can be acquired at the same time without being released is given by
+// GetNumDirectAccessBuffers().
+//
+// Note that one stream element may use more than one slice element. For example complex data stored in non complex
+// slice (such as CS8) will use 2 elements of the slice for 1 single element.
+//
+// Params:
+//  - outputFlags: The flag indicators of the result. The number of flags must match the number of channels of the
+//    stream.
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the handle of the buffers to give to ReleaseReadBuffer(), the buffer of each channel, the buffer's timestamp
+// in nanoseconds, the number of elements read per buffer and an error
+func (stream *Stream[T]) AcquireReadBuffer(outputFlags []int, timeoutUs uint) (handle uint, buffers [][]T, timeNs uint, numElemsRead uint, err error) {
+
+	if err := stream.lockState("AcquireReadBuffer", true); err != nil {
+		return 0, nil, 0, 0, err
+	}
+	defer stream.stateMutex.RUnlock()
+
+	handle, addrs, timeNs, numElemsRead, err := acquireReadBuffer(stream, outputFlags, timeoutUs)
+	if err != nil {
+		return 0, nil, timeNs, 0, err
+	}
+
+	return handle, stream.directBuffers(addrs, numElemsRead), timeNs, numElemsRead, nil
+}
+
+// ReleaseReadBuffer releases buffers acquired with AcquireReadBuffer() back to the receive stream.
+//
+// The slices returned by AcquireReadBuffer() for this handle must not be used after this call.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireReadBuffer()
+func (stream *Stream[T]) ReleaseReadBuffer(handle uint) {
+
+	if stream.lockState("ReleaseReadBuffer", false) != nil {
+		return
+	}
+	defer stream.stateMutex.RUnlock()
+
+	releaseReadBuffer(stream, handle)
+}
+
+// AcquireWriteBuffer acquires direct buffers from a transmit stream, without any copy.
+//
+// This call is part of the direct buffer access API. The returned slices map the driver memory directly and must be
+// filled by the caller: they are only valid until ReleaseWriteBuffer() is called with the returned handle and must not
+// be used nor retained afterwards. The number of buffers which can be acquired at the same time without being released
+// is given by GetNumDirectAccessBuffers().
+//
+// Note that one stream element may use more than one slice element. For example complex data stored in non complex
+// slice (such as CS8) will use 2 elements of the slice for 1 single element.
+//
+// Params:
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the handle of the buffers to give to ReleaseWriteBuffer(), the buffer of each channel, the number of elements
+// available for writing per buffer and an error
+func (stream *Stream[T]) AcquireWriteBuffer(timeoutUs uint) (handle uint, buffers [][]T, numElems uint, err error) {
+
+	if err := stream.lockState("AcquireWriteBuffer", true); err != nil {
+		return 0, nil, 0, err
+	}
+	defer stream.stateMutex.RUnlock()
+
+	handle, addrs, numElems, err := acquireWriteBuffer(stream, timeoutUs)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	return handle, stream.directBuffers(addrs, numElems), numElems, nil
+}
+
+// ReleaseWriteBuffer releases buffers acquired with AcquireWriteBuffer() back to the transmit stream, for transmission.
+//
+// The slices returned by AcquireWriteBuffer() for this handle must not be used after this call.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireWriteBuffer()
+//  - numElems: the number of elements written to each buffer
+//  - flags: input flags, updated with the value of the output flags (device specific). The number of flags must match
+//    the number of channels of the stream.
+//  - timeNs: the buffer's timestamp in nanoseconds
+func (stream *Stream[T]) ReleaseWriteBuffer(handle uint, numElems uint, flags []int, timeNs uint) {
+
+	if stream.lockState("ReleaseWriteBuffer", false) != nil {
+		return
+	}
+	defer stream.stateMutex.RUnlock()
+
+	releaseWriteBuffer(stream, handle, numElems, flags, timeNs)
+}
+
 /* ********************************************************************************** */
 /*                                READ WRITE FUNCTIONS                                */
 /* ********************************************************************************** */
@@ -257,31 +736,37 @@ func (stream *SDRStreamCU8) GetNumDirectAccessBuffers() uint {
 // Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
 // before call.
 //
-//
-// This is a multi-channel call, and buffs should be a slice of slice of uint8, where each slice of uint8 will
-// be filled with data from a different channel.
+// This is a multi-channel call, and buffs should be a slice of slice of T, where each slice of T will be filled with
+// data from a different channel.
 //
 // Params:
-// Params:
 //  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
 //    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
+//  - nbElems: the number of data to read. The buffers must be large enough to hold the data, otherwise an error is
+//    returned. For example complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer
+//    for 1 single data.
 //  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
 //    of the stream.
 //  - timeoutUs: the timeout in microseconds
 //
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCU8) Read(buffers [][]uint8, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+// Return the buffer's timestamp in nanoseconds, the number of elements read per buffer and an error. The read is
+// rejected with an error matching sdrerror.ErrNotActive if the stream is not active. A stream must not be read by
+// several goroutines at the same time; the read does not allocate.
+func (stream *Stream[T]) Read(buffers [][]T, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
 
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
+	if err := CheckBuffers(buffers, stream.nbChannels, nbElems, stream.elemsPerSample); err != nil {
+		return 0, 0, err
 	}
 
 	if uint(len(outputFlags)) != stream.nbChannels {
 		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
 	}
 
+	if err := stream.lockState("Read", true); err != nil {
+		return 0, 0, err
+	}
+	defer stream.stateMutex.RUnlock()
+
 	var voidPtrTemplate *C.void
 
 	// Convert the given buffers to C pointers
@@ -293,60 +778,67 @@ func (stream *SDRStreamCU8) Read(buffers [][]uint8, nbElems uint, outputFlags []
 	}
 
 	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
+	stream.readTimeNs = 0
 
 	// Make the actual read
 	result := int(
-		C.SoapySDRDevice_readStream(
+		C.SoapySDRGo_readStream(
 			stream.device,
 			stream.stream,
 			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
 			C.size_t(nbElems),
 			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
+			&stream.readTimeNs,
+			C.long(timeoutUs),
+			&stream.readStatus))
 
 	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
+		return uint(stream.readTimeNs), 0, directionError(&stream.readStatus, "Read", stream.direction)
 	}
 
-	return uint(cTimeNs), uint(result), nil
+	return uint(stream.readTimeNs), uint(result), nil
 }
 
-// WriteStream writes elements to a stream for transmission.
+// Write writes elements to a stream for transmission.
 //
-// This is a multi-channel call, and buffs should be a slice of slice of uint8, where each slice of uint8
-// is sent to a channel.
+// This is a multi-channel call, and buffs should be a slice of slice of T, where each slice of T is sent to a channel.
 //
 // Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
+//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
 //    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
+//  - nbElems: the number of data to write. The buffers must be large enough to hold the data, otherwise an error is
+//    returned. For example complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer
+//    for 1 single data.
 //  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
 //    match the number of channels of the stream.
 //  - timeNs: the buffer's timestamp in nanoseconds
 //  - timeoutUs: the timeout in microseconds
 //
 // Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCU8) Write(buffers [][]uint8, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+// error). The write is rejected with an error matching sdrerror.ErrNotActive if the stream is not active. A stream
+// must not be written by several goroutines at the same time; the write does not allocate.
+func (stream *Stream[T]) Write(buffers [][]T, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
 
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
+	if err := CheckBuffers(buffers, stream.nbChannels, nbElems, stream.elemsPerSample); err != nil {
+		return 0, err
 	}
 
 	if uint(len(flags)) != stream.nbChannels {
 		return 0, errors.New("the write flags must have the same number of channels as the stream")
 	}
 
+	if err := stream.lockState("Write", true); err != nil {
+		return 0, err
+	}
+	defer stream.stateMutex.RUnlock()
+
 	var voidPtrTemplate *C.void
 
 	// Convert the given buffer to C
 	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
 
 		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
+		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.writeBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
 		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
 	}
 
@@ -354,22 +846,106 @@ func (stream *SDRStreamCU8) Write(buffers [][]uint8, nbElems uint, flags []int,
 
 	// Make the actual write
 	result := int(
-		C.SoapySDRDevice_writeStream(
+		C.SoapySDRGo_writeStream(
 			stream.device,
 			stream.stream,
 			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
 			C.size_t(nbElems),
 			cFlags,
 			C.longlong(timeNs),
-			C.long(timeoutUs)))
+			C.long(timeoutUs),
+			&stream.writeStatus))
 
 	if result < 0 {
-		return 0, sdrerror.Err(int(result))
+		return 0, directionError(&stream.writeStatus, "Write", stream.direction)
 	}
 
 	return uint(result), nil
 }
 
+// ReadBuffers reads elements from a stream for reception, as many as fit in the given buffers. See Read() for the
+// details.
+//
+// Params:
+//  - buffers: the buffers of each channel. The number of elements to read is derived from the length of the shortest
+//    buffer.
+//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
+//    of the stream.
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the buffer's timestamp in nanoseconds, the number of elements read per buffer and an error
+func (stream *Stream[T]) ReadBuffers(buffers [][]T, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	return stream.Read(buffers, BuffersNumElems(buffers, stream.elemsPerSample), outputFlags, timeoutUs)
+}
+
+// WriteBuffers writes all the elements of the given buffers to a stream for transmission. See Write() for the details.
+//
+// Params:
+//  - buffers: the buffers of each channel. The number of elements to write is derived from the length of the shortest
+//    buffer.
+//  - flags: input flags by channel. The number of flags must match the number of channels of the stream.
+//  - timeNs: the buffer's timestamp in nanoseconds
+//  - timeoutUs: the timeout in microseconds
+//
+// Return the number of elements written per buffer and an error
+func (stream *Stream[T]) WriteBuffers(buffers [][]T, flags []int, timeNs uint, timeoutUs uint) (numElemsWritten uint, err error) {
+
+	return stream.Write(buffers, BuffersNumElems(buffers, stream.elemsPerSample), flags, timeNs, timeoutUs)
+}
+
+// CheckBuffers checks the buffers given to a read or a write on a stream: there must be one buffer per channel, each
+// one large enough to hold the given number of stream elements. The buffers are never empty, even to transfer no
+// element, as the address of their first element is given to the driver.
+//
+// Params:
+//  - buffers: the buffers of each channel
+//  - nbChannels: the number of channels of the stream
+//  - nbElems: the number of stream elements to read or write
+//  - elemsPerSample: the number of buffer elements used by a stream element, see FormatElemsPerSample()
+//
+// Return an error if the buffers cannot be used
+func CheckBuffers[T Sample](buffers [][]T, nbChannels uint, nbElems uint, elemsPerSample uint) error {
+
+	if uint(len(buffers)) != nbChannels {
+		return fmt.Errorf("%v buffers given for a stream of %v channels", len(buffers), nbChannels)
+	}
+
+	for channelIdx, buffer := range buffers {
+		if len(buffer) == 0 {
+			return fmt.Errorf("the buffer of channel %v is empty", channelIdx)
+		}
+		if uint(len(buffer)) < nbElems*elemsPerSample {
+			return fmt.Errorf("the buffer of channel %v holds %v elements, %v are needed for %v stream elements", channelIdx, len(buffer), nbElems*elemsPerSample, nbElems)
+		}
+	}
+
+	return nil
+}
+
+// BuffersNumElems returns the number of stream elements which fit in all the given buffers.
+//
+// Params:
+//  - buffers: the buffers of each channel
+//  - elemsPerSample: the number of buffer elements used by a stream element, see FormatElemsPerSample()
+//
+// Return the number of stream elements held by the shortest buffer, 0 if there is no buffer
+func BuffersNumElems[T Sample](buffers [][]T, elemsPerSample uint) uint {
+
+	if len(buffers) == 0 || elemsPerSample == 0 {
+		return 0
+	}
+
+	nbElems := uint(len(buffers[0])) / elemsPerSample
+	for _, buffer := range buffers[1:] {
+		if length := uint(len(buffer)) / elemsPerSample; length < nbElems {
+			nbElems = length
+		}
+	}
+
+	return nbElems
+}
+
 // ReadStreamStatus reads status information about a stream.
 //
 // This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
@@ -382,1566 +958,15 @@ func (stream *SDRStreamCU8) Write(buffers [][]uint8, nbElems uint, flags []int,
 // Params:
 //  - chanMask to which channels this status applies
 //  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
 //  - timeoutUs the timeout in microseconds
 //
 // Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCU8) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
-
-	return readStreamStatus(stream, chanMask, flags, timeoutUs)
-}
-
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCS8                             */
-/*                                                                                    */
-/* ********************************************************************************** */
-
-/* ********************************************************************************** */
-/*                          CREATION OF STREAMS                                       */
-/* ********************************************************************************** */
+func (stream *Stream[T]) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
 
-// SetupSDRStreamCS8 initializes a stream given a list of channels and stream arguments.
-//
-// The implementation may change switches or power-up components.
-// All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCS8() is complete, regardless of the activity state.
-//
-// The API allows any number of simultaneous TX and RX streams, but many dual-channel
-// devices are limited to one stream in each direction, using either one or both channels.
-// This call will return an error if an unsupported combination is requested,
-// or if a requested channel in this direction is already in use by another stream.
-//
-// When multiple channels are added to a stream, they are typically expected to have
-// the same sample rate. See SetSampleRate().
-//
-// Params:
-//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
-//  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
-//    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
-//    channels must be explicitly defined. Hence the channels slice can not be given empty.
-//  - args: stream args or empty for defaults
-//
-// Args:
-// Recommended keys to use in the args dictionary:
-//   - "WIRE" - format of the samples between device and host
-//
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCS8(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCS8, err error) {
-
-	if len(channels) == 0 {
-		return nil, errors.New("the channels must be given explicitly during stream setup")
-	}
-
-	cFormat := C.CString("CS8")
-	defer C.free(unsafe.Pointer(cFormat))
-
-	cArgs, cArgsLength := go2Args(args)
-	defer argsListClear(cArgs, cArgsLength)
-
-	cChannels, cChannelsLength := go2SizeTList(channels)
-	defer C.free(unsafe.Pointer(cChannels))
-
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
-
-	if val == nil {
-		return nil, errors.New(LastError())
-	}
-
-	var voidPtrTemplate *C.void
-
-	nbChannels := uint(len(channels))
-
-	// Allocate the buffers
-	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-
-	return &SDRStreamCS8{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
-}
-
-/* ********************************************************************************** */
-/*                                GETTER AND SETTER                                   */
-/* ********************************************************************************** */
-// getDevice returns the internal device
-func (stream *SDRStreamCS8) getDevice() *C.SoapySDRDevice {
-	return stream.device
-}
-
-// getStream returns the internal stream
-func (stream *SDRStreamCS8) getStream() *C.SoapySDRStream {
-	return stream.stream
-}
-
-// getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCS8) getNbChannels() uint {
-	return stream.nbChannels
-}
-
-/* ********************************************************************************** */
-/*                                STREAMS FUNCTIONS                                   */
-/* ********************************************************************************** */
-
-// Close closes an open stream created by setupStream
-//
-// Params:
-//  - stream: the opaque pointer to a stream handle
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS8) Close() (err sdrerror.SDRError) {
-
-	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
-	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
-
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
-}
-
-// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
-//
-// The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
-// allocation size that can best optimize throughput given the underlying stream implementation.
-//
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCS8) GetMTU() int {
-
-	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
-}
-
-// Activate activates a stream.
-//
-// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
-// flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. The StreamFlagEndBurst flag can signal end on the finite burst.
-//    Not all implementations will support the full range of options. In this case, the implementation returns
-//    ErrorNotSupported.
-//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS8) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
-}
-
-// Deactivate deactivates a stream.
-//
-// Call deactivate when not using using read/write(). The implementation control switches or halt data flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. Not all implementations will support the full range of options.
-//    In this case, the implementation returns ErrorNotSupported.
-//  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS8) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
-}
-
-// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
-//
-// This is the number of times the user can call acquire() on a stream without making subsequent calls to
-// release(). A return value of 0 means that direct access is not supported.
-//
-// Return the number of direct access buffers or 0
-func (stream *SDRStreamCS8) GetNumDirectAccessBuffers() uint {
-
-	return getNumDirectAccessBuffers(stream)
-}
-
-/* ********************************************************************************** */
-/*                                READ WRITE FUNCTIONS                                */
-/* ********************************************************************************** */
-
-// Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
-// before call.
-//
-//
-// This is a multi-channel call, and buffs should be a slice of slice of int8, where each slice of int8 will
-// be filled with data from a different channel.
-//
-// Params:
-// Params:
-//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
-//    of the stream.
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCS8) Read(buffers [][]int8, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(outputFlags)) != stream.nbChannels {
-		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffers to C pointers
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
-
-	// Make the actual read
-	result := int(
-		C.SoapySDRDevice_readStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
-	}
-
-	return uint(cTimeNs), uint(result), nil
-}
-
-// WriteStream writes elements to a stream for transmission.
-//
-// This is a multi-channel call, and buffs should be a slice of slice of int8, where each slice of int8
-// is sent to a channel.
-//
-// Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
-//    match the number of channels of the stream.
-//  - timeNs: the buffer's timestamp in nanoseconds
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCS8) Write(buffers [][]int8, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(flags)) != stream.nbChannels {
-		return 0, errors.New("the write flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffer to C
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&flags[0]))
-
-	// Make the actual write
-	result := int(
-		C.SoapySDRDevice_writeStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			C.longlong(timeNs),
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return 0, sdrerror.Err(int(result))
-	}
-
-	return uint(result), nil
-}
-
-// ReadStreamStatus reads status information about a stream.
-//
-// This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
-//
-// Client code may continually poll readStreamStatus() in a loop. Implementations of readStreamStatus() should wait in
-// the call for a status change event or until the timeout expiration. When stream status is not implemented on a
-// particular stream, readStreamStatus() should return SOAPY_SDR_NOT_SUPPORTED. Client code may use this indication to
-// disable a polling loop.
-//
-// Params:
-//  - chanMask to which channels this status applies
-//  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
-//  - timeoutUs the timeout in microseconds
-//
-// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCS8) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
-
-	return readStreamStatus(stream, chanMask, flags, timeoutUs)
-}
-
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCU16                             */
-/*                                                                                    */
-/* ********************************************************************************** */
-
-/* ********************************************************************************** */
-/*                          CREATION OF STREAMS                                       */
-/* ********************************************************************************** */
-
-// SetupSDRStreamCU16 initializes a stream given a list of channels and stream arguments.
-//
-// The implementation may change switches or power-up components.
-// All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCU16() is complete, regardless of the activity state.
-//
-// The API allows any number of simultaneous TX and RX streams, but many dual-channel
-// devices are limited to one stream in each direction, using either one or both channels.
-// This call will return an error if an unsupported combination is requested,
-// or if a requested channel in this direction is already in use by another stream.
-//
-// When multiple channels are added to a stream, they are typically expected to have
-// the same sample rate. See SetSampleRate().
-//
-// Params:
-//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
-//  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
-//    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
-//    channels must be explicitly defined. Hence the channels slice can not be given empty.
-//  - args: stream args or empty for defaults
-//
-// Args:
-// Recommended keys to use in the args dictionary:
-//   - "WIRE" - format of the samples between device and host
-//
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCU16(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCU16, err error) {
-
-	if len(channels) == 0 {
-		return nil, errors.New("the channels must be given explicitly during stream setup")
-	}
-
-	cFormat := C.CString("CU16")
-	defer C.free(unsafe.Pointer(cFormat))
-
-	cArgs, cArgsLength := go2Args(args)
-	defer argsListClear(cArgs, cArgsLength)
-
-	cChannels, cChannelsLength := go2SizeTList(channels)
-	defer C.free(unsafe.Pointer(cChannels))
-
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
-
-	if val == nil {
-		return nil, errors.New(LastError())
-	}
-
-	var voidPtrTemplate *C.void
-
-	nbChannels := uint(len(channels))
-
-	// Allocate the buffers
-	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-
-	return &SDRStreamCU16{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
-}
-
-/* ********************************************************************************** */
-/*                                GETTER AND SETTER                                   */
-/* ********************************************************************************** */
-// getDevice returns the internal device
-func (stream *SDRStreamCU16) getDevice() *C.SoapySDRDevice {
-	return stream.device
-}
-
-// getStream returns the internal stream
-func (stream *SDRStreamCU16) getStream() *C.SoapySDRStream {
-	return stream.stream
-}
-
-// getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCU16) getNbChannels() uint {
-	return stream.nbChannels
-}
-
-/* ********************************************************************************** */
-/*                                STREAMS FUNCTIONS                                   */
-/* ********************************************************************************** */
-
-// Close closes an open stream created by setupStream
-//
-// Params:
-//  - stream: the opaque pointer to a stream handle
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCU16) Close() (err sdrerror.SDRError) {
-
-	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
-	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
-
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
-}
-
-// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
-//
-// The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
-// allocation size that can best optimize throughput given the underlying stream implementation.
-//
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCU16) GetMTU() int {
-
-	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
-}
-
-// Activate activates a stream.
-//
-// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
-// flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. The StreamFlagEndBurst flag can signal end on the finite burst.
-//    Not all implementations will support the full range of options. In this case, the implementation returns
-//    ErrorNotSupported.
-//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCU16) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
-}
-
-// Deactivate deactivates a stream.
-//
-// Call deactivate when not using using read/write(). The implementation control switches or halt data flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. Not all implementations will support the full range of options.
-//    In this case, the implementation returns ErrorNotSupported.
-//  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCU16) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
-}
-
-// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
-//
-// This is the number of times the user can call acquire() on a stream without making subsequent calls to
-// release(). A return value of 0 means that direct access is not supported.
-//
-// Return the number of direct access buffers or 0
-func (stream *SDRStreamCU16) GetNumDirectAccessBuffers() uint {
-
-	return getNumDirectAccessBuffers(stream)
-}
-
-/* ********************************************************************************** */
-/*                                READ WRITE FUNCTIONS                                */
-/* ********************************************************************************** */
-
-// Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
-// before call.
-//
-//
-// This is a multi-channel call, and buffs should be a slice of slice of uint16, where each slice of uint16 will
-// be filled with data from a different channel.
-//
-// Params:
-// Params:
-//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
-//    of the stream.
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCU16) Read(buffers [][]uint16, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(outputFlags)) != stream.nbChannels {
-		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffers to C pointers
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
-
-	// Make the actual read
-	result := int(
-		C.SoapySDRDevice_readStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
-	}
-
-	return uint(cTimeNs), uint(result), nil
-}
-
-// WriteStream writes elements to a stream for transmission.
-//
-// This is a multi-channel call, and buffs should be a slice of slice of uint16, where each slice of uint16
-// is sent to a channel.
-//
-// Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
-//    match the number of channels of the stream.
-//  - timeNs: the buffer's timestamp in nanoseconds
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCU16) Write(buffers [][]uint16, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(flags)) != stream.nbChannels {
-		return 0, errors.New("the write flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffer to C
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&flags[0]))
-
-	// Make the actual write
-	result := int(
-		C.SoapySDRDevice_writeStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			C.longlong(timeNs),
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return 0, sdrerror.Err(int(result))
-	}
-
-	return uint(result), nil
-}
-
-// ReadStreamStatus reads status information about a stream.
-//
-// This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
-//
-// Client code may continually poll readStreamStatus() in a loop. Implementations of readStreamStatus() should wait in
-// the call for a status change event or until the timeout expiration. When stream status is not implemented on a
-// particular stream, readStreamStatus() should return SOAPY_SDR_NOT_SUPPORTED. Client code may use this indication to
-// disable a polling loop.
-//
-// Params:
-//  - chanMask to which channels this status applies
-//  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
-//  - timeoutUs the timeout in microseconds
-//
-// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCU16) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
-
-	return readStreamStatus(stream, chanMask, flags, timeoutUs)
-}
-
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCS16                             */
-/*                                                                                    */
-/* ********************************************************************************** */
-
-/* ********************************************************************************** */
-/*                          CREATION OF STREAMS                                       */
-/* ********************************************************************************** */
-
-// SetupSDRStreamCS16 initializes a stream given a list of channels and stream arguments.
-//
-// The implementation may change switches or power-up components.
-// All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCS16() is complete, regardless of the activity state.
-//
-// The API allows any number of simultaneous TX and RX streams, but many dual-channel
-// devices are limited to one stream in each direction, using either one or both channels.
-// This call will return an error if an unsupported combination is requested,
-// or if a requested channel in this direction is already in use by another stream.
-//
-// When multiple channels are added to a stream, they are typically expected to have
-// the same sample rate. See SetSampleRate().
-//
-// Params:
-//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
-//  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
-//    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
-//    channels must be explicitly defined. Hence the channels slice can not be given empty.
-//  - args: stream args or empty for defaults
-//
-// Args:
-// Recommended keys to use in the args dictionary:
-//   - "WIRE" - format of the samples between device and host
-//
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCS16(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCS16, err error) {
-
-	if len(channels) == 0 {
-		return nil, errors.New("the channels must be given explicitly during stream setup")
-	}
-
-	cFormat := C.CString("CS16")
-	defer C.free(unsafe.Pointer(cFormat))
-
-	cArgs, cArgsLength := go2Args(args)
-	defer argsListClear(cArgs, cArgsLength)
-
-	cChannels, cChannelsLength := go2SizeTList(channels)
-	defer C.free(unsafe.Pointer(cChannels))
-
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
-
-	if val == nil {
-		return nil, errors.New(LastError())
-	}
-
-	var voidPtrTemplate *C.void
-
-	nbChannels := uint(len(channels))
-
-	// Allocate the buffers
-	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-
-	return &SDRStreamCS16{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
-}
-
-/* ********************************************************************************** */
-/*                                GETTER AND SETTER                                   */
-/* ********************************************************************************** */
-// getDevice returns the internal device
-func (stream *SDRStreamCS16) getDevice() *C.SoapySDRDevice {
-	return stream.device
-}
-
-// getStream returns the internal stream
-func (stream *SDRStreamCS16) getStream() *C.SoapySDRStream {
-	return stream.stream
-}
-
-// getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCS16) getNbChannels() uint {
-	return stream.nbChannels
-}
-
-/* ********************************************************************************** */
-/*                                STREAMS FUNCTIONS                                   */
-/* ********************************************************************************** */
-
-// Close closes an open stream created by setupStream
-//
-// Params:
-//  - stream: the opaque pointer to a stream handle
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS16) Close() (err sdrerror.SDRError) {
-
-	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
-	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
-
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
-}
-
-// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
-//
-// The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
-// allocation size that can best optimize throughput given the underlying stream implementation.
-//
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCS16) GetMTU() int {
-
-	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
-}
-
-// Activate activates a stream.
-//
-// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
-// flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. The StreamFlagEndBurst flag can signal end on the finite burst.
-//    Not all implementations will support the full range of options. In this case, the implementation returns
-//    ErrorNotSupported.
-//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS16) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
-}
-
-// Deactivate deactivates a stream.
-//
-// Call deactivate when not using using read/write(). The implementation control switches or halt data flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. Not all implementations will support the full range of options.
-//    In this case, the implementation returns ErrorNotSupported.
-//  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCS16) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
-}
-
-// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
-//
-// This is the number of times the user can call acquire() on a stream without making subsequent calls to
-// release(). A return value of 0 means that direct access is not supported.
-//
-// Return the number of direct access buffers or 0
-func (stream *SDRStreamCS16) GetNumDirectAccessBuffers() uint {
-
-	return getNumDirectAccessBuffers(stream)
-}
-
-/* ********************************************************************************** */
-/*                                READ WRITE FUNCTIONS                                */
-/* ********************************************************************************** */
-
-// Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
-// before call.
-//
-//
-// This is a multi-channel call, and buffs should be a slice of slice of int16, where each slice of int16 will
-// be filled with data from a different channel.
-//
-// Params:
-// Params:
-//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
-//    of the stream.
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCS16) Read(buffers [][]int16, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(outputFlags)) != stream.nbChannels {
-		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffers to C pointers
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
-
-	// Make the actual read
-	result := int(
-		C.SoapySDRDevice_readStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
-	}
-
-	return uint(cTimeNs), uint(result), nil
-}
-
-// WriteStream writes elements to a stream for transmission.
-//
-// This is a multi-channel call, and buffs should be a slice of slice of int16, where each slice of int16
-// is sent to a channel.
-//
-// Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
-//    match the number of channels of the stream.
-//  - timeNs: the buffer's timestamp in nanoseconds
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCS16) Write(buffers [][]int16, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(flags)) != stream.nbChannels {
-		return 0, errors.New("the write flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffer to C
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&flags[0]))
-
-	// Make the actual write
-	result := int(
-		C.SoapySDRDevice_writeStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			C.longlong(timeNs),
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return 0, sdrerror.Err(int(result))
-	}
-
-	return uint(result), nil
-}
-
-// ReadStreamStatus reads status information about a stream.
-//
-// This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
-//
-// Client code may continually poll readStreamStatus() in a loop. Implementations of readStreamStatus() should wait in
-// the call for a status change event or until the timeout expiration. When stream status is not implemented on a
-// particular stream, readStreamStatus() should return SOAPY_SDR_NOT_SUPPORTED. Client code may use this indication to
-// disable a polling loop.
-//
-// Params:
-//  - chanMask to which channels this status applies
-//  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
-//  - timeoutUs the timeout in microseconds
-//
-// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCS16) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
-
-	return readStreamStatus(stream, chanMask, flags, timeoutUs)
-}
-
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCF32                             */
-/*                                                                                    */
-/* ********************************************************************************** */
-
-/* ********************************************************************************** */
-/*                          CREATION OF STREAMS                                       */
-/* ********************************************************************************** */
-
-// SetupSDRStreamCF32 initializes a stream given a list of channels and stream arguments.
-//
-// The implementation may change switches or power-up components.
-// All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCF32() is complete, regardless of the activity state.
-//
-// The API allows any number of simultaneous TX and RX streams, but many dual-channel
-// devices are limited to one stream in each direction, using either one or both channels.
-// This call will return an error if an unsupported combination is requested,
-// or if a requested channel in this direction is already in use by another stream.
-//
-// When multiple channels are added to a stream, they are typically expected to have
-// the same sample rate. See SetSampleRate().
-//
-// Params:
-//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
-//  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
-//    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
-//    channels must be explicitly defined. Hence the channels slice can not be given empty.
-//  - args: stream args or empty for defaults
-//
-// Args:
-// Recommended keys to use in the args dictionary:
-//   - "WIRE" - format of the samples between device and host
-//
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCF32(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCF32, err error) {
-
-	if len(channels) == 0 {
-		return nil, errors.New("the channels must be given explicitly during stream setup")
-	}
-
-	cFormat := C.CString("CF32")
-	defer C.free(unsafe.Pointer(cFormat))
-
-	cArgs, cArgsLength := go2Args(args)
-	defer argsListClear(cArgs, cArgsLength)
-
-	cChannels, cChannelsLength := go2SizeTList(channels)
-	defer C.free(unsafe.Pointer(cChannels))
-
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
-
-	if val == nil {
-		return nil, errors.New(LastError())
-	}
-
-	var voidPtrTemplate *C.void
-
-	nbChannels := uint(len(channels))
-
-	// Allocate the buffers
-	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-
-	return &SDRStreamCF32{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
-}
-
-/* ********************************************************************************** */
-/*                                GETTER AND SETTER                                   */
-/* ********************************************************************************** */
-// getDevice returns the internal device
-func (stream *SDRStreamCF32) getDevice() *C.SoapySDRDevice {
-	return stream.device
-}
-
-// getStream returns the internal stream
-func (stream *SDRStreamCF32) getStream() *C.SoapySDRStream {
-	return stream.stream
-}
-
-// getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCF32) getNbChannels() uint {
-	return stream.nbChannels
-}
-
-/* ********************************************************************************** */
-/*                                STREAMS FUNCTIONS                                   */
-/* ********************************************************************************** */
-
-// Close closes an open stream created by setupStream
-//
-// Params:
-//  - stream: the opaque pointer to a stream handle
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF32) Close() (err sdrerror.SDRError) {
-
-	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
-	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
-
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
-}
-
-// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
-//
-// The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
-// allocation size that can best optimize throughput given the underlying stream implementation.
-//
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCF32) GetMTU() int {
-
-	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
-}
-
-// Activate activates a stream.
-//
-// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
-// flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. The StreamFlagEndBurst flag can signal end on the finite burst.
-//    Not all implementations will support the full range of options. In this case, the implementation returns
-//    ErrorNotSupported.
-//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF32) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
-}
-
-// Deactivate deactivates a stream.
-//
-// Call deactivate when not using using read/write(). The implementation control switches or halt data flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. Not all implementations will support the full range of options.
-//    In this case, the implementation returns ErrorNotSupported.
-//  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF32) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
-}
-
-// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
-//
-// This is the number of times the user can call acquire() on a stream without making subsequent calls to
-// release(). A return value of 0 means that direct access is not supported.
-//
-// Return the number of direct access buffers or 0
-func (stream *SDRStreamCF32) GetNumDirectAccessBuffers() uint {
-
-	return getNumDirectAccessBuffers(stream)
-}
-
-/* ********************************************************************************** */
-/*                                READ WRITE FUNCTIONS                                */
-/* ********************************************************************************** */
-
-// Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
-// before call.
-//
-//
-// This is a multi-channel call, and buffs should be a slice of slice of complex64, where each slice of complex64 will
-// be filled with data from a different channel.
-//
-// Params:
-// Params:
-//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
-//    of the stream.
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCF32) Read(buffers [][]complex64, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(outputFlags)) != stream.nbChannels {
-		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffers to C pointers
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
-
-	// Make the actual read
-	result := int(
-		C.SoapySDRDevice_readStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
-	}
-
-	return uint(cTimeNs), uint(result), nil
-}
-
-// WriteStream writes elements to a stream for transmission.
-//
-// This is a multi-channel call, and buffs should be a slice of slice of complex64, where each slice of complex64
-// is sent to a channel.
-//
-// Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
-//    match the number of channels of the stream.
-//  - timeNs: the buffer's timestamp in nanoseconds
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCF32) Write(buffers [][]complex64, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(flags)) != stream.nbChannels {
-		return 0, errors.New("the write flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffer to C
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&flags[0]))
-
-	// Make the actual write
-	result := int(
-		C.SoapySDRDevice_writeStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			C.longlong(timeNs),
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return 0, sdrerror.Err(int(result))
-	}
-
-	return uint(result), nil
-}
-
-// ReadStreamStatus reads status information about a stream.
-//
-// This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
-//
-// Client code may continually poll readStreamStatus() in a loop. Implementations of readStreamStatus() should wait in
-// the call for a status change event or until the timeout expiration. When stream status is not implemented on a
-// particular stream, readStreamStatus() should return SOAPY_SDR_NOT_SUPPORTED. Client code may use this indication to
-// disable a polling loop.
-//
-// Params:
-//  - chanMask to which channels this status applies
-//  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
-//  - timeoutUs the timeout in microseconds
-//
-// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCF32) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
-
-	return readStreamStatus(stream, chanMask, flags, timeoutUs)
-}
-
-/* ********************************************************************************** */
-/*                                                                                    */
-/*                     FUNCTIONS OF STREAMS SDRStreamCF64                             */
-/*                                                                                    */
-/* ********************************************************************************** */
-
-/* ********************************************************************************** */
-/*                          CREATION OF STREAMS                                       */
-/* ********************************************************************************** */
-
-// SetupSDRStreamCF64 initializes a stream given a list of channels and stream arguments.
-//
-// The implementation may change switches or power-up components.
-// All stream API calls should be usable with the new stream object
-// after SetupSDRStreamCF64() is complete, regardless of the activity state.
-//
-// The API allows any number of simultaneous TX and RX streams, but many dual-channel
-// devices are limited to one stream in each direction, using either one or both channels.
-// This call will return an error if an unsupported combination is requested,
-// or if a requested channel in this direction is already in use by another stream.
-//
-// When multiple channels are added to a stream, they are typically expected to have
-// the same sample rate. See SetSampleRate().
-//
-// Params:
-//  - direction: the channel direction ('DirectionRX' or 'DirectionTX')
-//  - channels: a list of channels or empty for automatic. When multiple channels are added to a stream, they are
-//    typically expected to have the same sample rate. See SetSampleRate(). Warning: Contrary to SoapySDR API, the
-//    channels must be explicitly defined. Hence the channels slice can not be given empty.
-//  - args: stream args or empty for defaults
-//
-// Args:
-// Recommended keys to use in the args dictionary:
-//   - "WIRE" - format of the samples between device and host
-//
-// Return the stream pointer and an error. The returned stream is not required to have internal locking,
-// and may not be used concurrently from multiple threads.
-func (dev *SDRDevice) SetupSDRStreamCF64(direction Direction, channels []uint, args map[string]string) (stream *SDRStreamCF64, err error) {
-
-	if len(channels) == 0 {
-		return nil, errors.New("the channels must be given explicitly during stream setup")
-	}
-
-	cFormat := C.CString("CF64")
-	defer C.free(unsafe.Pointer(cFormat))
-
-	cArgs, cArgsLength := go2Args(args)
-	defer argsListClear(cArgs, cArgsLength)
-
-	cChannels, cChannelsLength := go2SizeTList(channels)
-	defer C.free(unsafe.Pointer(cChannels))
-
-	val := C.SoapySDRDevice_setupStream(dev.device, C.int(direction), cFormat, cChannels, cChannelsLength, cArgs)
-
-	if val == nil {
-		return nil, errors.New(LastError())
-	}
-
-	var voidPtrTemplate *C.void
-
-	nbChannels := uint(len(channels))
-
-	// Allocate the buffers
-	readBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-	writeBuffers := (**C.void)(C.malloc(C.size_t(nbChannels * uint(unsafe.Sizeof(voidPtrTemplate)))))
-
-	return &SDRStreamCF64{
-		device:      dev.device,
-		stream:      val,
-		nbChannels:  nbChannels,
-		readBuffer:  readBuffers,
-		writeBuffer: writeBuffers,
-	}, nil
-}
-
-/* ********************************************************************************** */
-/*                                GETTER AND SETTER                                   */
-/* ********************************************************************************** */
-// getDevice returns the internal device
-func (stream *SDRStreamCF64) getDevice() *C.SoapySDRDevice {
-	return stream.device
-}
-
-// getStream returns the internal stream
-func (stream *SDRStreamCF64) getStream() *C.SoapySDRStream {
-	return stream.stream
-}
-
-// getNbChannels returns the number of channels used by the stream
-func (stream *SDRStreamCF64) getNbChannels() uint {
-	return stream.nbChannels
-}
-
-/* ********************************************************************************** */
-/*                                STREAMS FUNCTIONS                                   */
-/* ********************************************************************************** */
-
-// Close closes an open stream created by setupStream
-//
-// Params:
-//  - stream: the opaque pointer to a stream handle
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF64) Close() (err sdrerror.SDRError) {
-
-	// Free the buffers
-	C.free(unsafe.Pointer(stream.readBuffer))
-	C.free(unsafe.Pointer(stream.writeBuffer))
-	// Set the buffers to nil, in case someone try to reuse the stream
-	stream.readBuffer = nil
-	stream.writeBuffer = nil
-
-	return sdrerror.Err(int(C.SoapySDRDevice_closeStream(stream.device, stream.stream)))
-}
-
-// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
-//
-// The MTU specifies the maximum payload transfer in a stream operation. This value can be used as a stream buffer
-// allocation size that can best optimize throughput given the underlying stream implementation.
-//
-// Return the MTU in number of stream elements (never zero)
-func (stream *SDRStreamCF64) GetMTU() int {
-
-	return int(C.SoapySDRDevice_getStreamMTU(stream.device, stream.stream))
-}
-
-// Activate activates a stream.
-//
-// Call activate to prepare a stream before using read/write(). The implementation control switches or stimulate data
-// flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. The StreamFlagEndBurst flag can signal end on the finite burst.
-//    Not all implementations will support the full range of options. In this case, the implementation returns
-//    ErrorNotSupported.
-//  - timeNs: optional activation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//  - numElems: optional element count for burst control. The numElems count can be used to request a finite burst size.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF64) Activate(flags StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_activateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs), C.size_t(numElems))))
-}
-
-// Deactivate deactivates a stream.
-//
-// Call deactivate when not using using read/write(). The implementation control switches or halt data flow.
-//
-// Params:
-//  - flags: optional flag indicators about the stream. Not all implementations will support the full range of options.
-//    In this case, the implementation returns ErrorNotSupported.
-//  - timeNs: optional deactivation time in nanoseconds. The timeNs is only valid when the flags have StreamFlagHasTime.
-//
-// Return an error or nil in case of success
-func (stream *SDRStreamCF64) Deactivate(flags StreamFlag, timeNs int) (err sdrerror.SDRError) {
-
-	return sdrerror.Err(int(C.SoapySDRDevice_deactivateStream(stream.device, stream.stream, C.int(flags), C.longlong(timeNs))))
-}
-
-// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide.
-//
-// This is the number of times the user can call acquire() on a stream without making subsequent calls to
-// release(). A return value of 0 means that direct access is not supported.
-//
-// Return the number of direct access buffers or 0
-func (stream *SDRStreamCF64) GetNumDirectAccessBuffers() uint {
-
-	return getNumDirectAccessBuffers(stream)
-}
-
-/* ********************************************************************************** */
-/*                                READ WRITE FUNCTIONS                                */
-/* ********************************************************************************** */
-
-// Read reads elements from a stream for reception. The elements are written in the given buffer which must be allocated
-// before call.
-//
-//
-// This is a multi-channel call, and buffs should be a slice of slice of complex128, where each slice of complex128 will
-// be filled with data from a different channel.
-//
-// Params:
-// Params:
-//  - buffs: an array of buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream. The buffers MUST already be fully allocated before the call.
-//  - nbElems: the number of data to read. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - outputFlags: The flag indicators of the result by channel. The number of flags must match the number of channels
-//    of the stream.
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the flag indicators about the result by channel, the buffer's timestamp in nanoseconds, the number of elements read per buffer, the errorCode and the error message
-func (stream *SDRStreamCF64) Read(buffers [][]complex128, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, 0, errors.New("the read buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(outputFlags)) != stream.nbChannels {
-		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffers to C pointers
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&outputFlags[0]))
-	cTimeNs := C.longlong(0)
-
-	// Make the actual read
-	result := int(
-		C.SoapySDRDevice_readStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.readBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			&cTimeNs,
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return uint(cTimeNs), 0, sdrerror.Err(int(result))
-	}
-
-	return uint(cTimeNs), uint(result), nil
-}
-
-// WriteStream writes elements to a stream for transmission.
-//
-// This is a multi-channel call, and buffs should be a slice of slice of complex128, where each slice of complex128
-// is sent to a channel.
-//
-// Params:
-//  - buffs: an array of void* buffers num chans in size. The number of buffers must match the number of channels of the
-//    stream.
-//  - nbElems: the number of data to write. Note that the buffer must be large enough to hold the data. For example
-//    complex data stored in non complex buffer (such as CS8) will use 2 elements of the buffer for 1 single data.
-//  - flags: input flags, may be updated with the value of the output flags (device specific). The number of flags must
-//    match the number of channels of the stream.
-//  - timeNs: the buffer's timestamp in nanoseconds
-//  - timeoutUs: the timeout in microseconds
-//
-// Return the number of elements written per buffer or 0 in case of an error (even if some data were sent before the
-// error)
-func (stream *SDRStreamCF64) Write(buffers [][]complex128, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
-
-	if uint(len(buffers)) != stream.nbChannels {
-		return 0, errors.New("the write buffer must have the same number of channels as the stream")
-	}
-
-	if uint(len(flags)) != stream.nbChannels {
-		return 0, errors.New("the write flags must have the same number of channels as the stream")
-	}
-
-	var voidPtrTemplate *C.void
-
-	// Convert the given buffer to C
-	for channelIdx := uint(0); channelIdx < stream.nbChannels; channelIdx++ {
-
-		// Get the pointer to the buffer for the channel
-		ptrPtrBuffer := (**C.void)(unsafe.Pointer(uintptr(unsafe.Pointer(stream.readBuffer)) + uintptr(channelIdx)*unsafe.Sizeof(voidPtrTemplate)))
-		*ptrPtrBuffer = (*C.void)(unsafe.Pointer(&buffers[channelIdx][0]))
-	}
-
-	cFlags := (*C.int)(unsafe.Pointer(&flags[0]))
-
-	// Make the actual write
-	result := int(
-		C.SoapySDRDevice_writeStream(
-			stream.device,
-			stream.stream,
-			(*unsafe.Pointer)(unsafe.Pointer(stream.writeBuffer)),
-			C.size_t(nbElems),
-			cFlags,
-			C.longlong(timeNs),
-			C.long(timeoutUs)))
-
-	if result < 0 {
-		return 0, sdrerror.Err(int(result))
+	if err := stream.lockState("ReadStreamStatus", false); err != nil {
+		return 0, err
 	}
-
-	return uint(result), nil
-}
-
-// ReadStreamStatus reads status information about a stream.
-//
-// This call is typically used on a transmit stream to report time errors, underflows, and burst completion.
-//
-// Client code may continually poll readStreamStatus() in a loop. Implementations of readStreamStatus() should wait in
-// the call for a status change event or until the timeout expiration. When stream status is not implemented on a
-// particular stream, readStreamStatus() should return SOAPY_SDR_NOT_SUPPORTED. Client code may use this indication to
-// disable a polling loop.
-//
-// Params:
-//  - chanMask to which channels this status applies
-//  - flags optional input flags and output flags
-//  - timeNs the buffer's timestamp in nanoseconds
-//  - timeoutUs the timeout in microseconds
-//
-// Return the buffer's timestamp in nanoseconds in case of success, an error otherwise
-func (stream *SDRStreamCF64) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
+	defer stream.stateMutex.RUnlock()
 
 	return readStreamStatus(stream, chanMask, flags, timeoutUs)
 }
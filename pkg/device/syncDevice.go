@@ -0,0 +1,201 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"runtime"
+	"sync"
+)
+
+//go:generate go run ../../internal/syncgen -output syncDeviceAPI.go
+
+// SyncDevice is a Device whose control calls are serialised, so that it can be used from several goroutines. Each
+// call on the device waits for the previous calls to complete.
+//
+// The streams created by a SyncDevice are not wrapped: their data path (Read, Write, direct buffer access) does not
+// take the lock of the device. A stream must still not be used concurrently from several goroutines.
+//
+// Optionally, all the calls are made from a single dedicated OS thread. As the last status and the last error message
+// of SoapySDR (see LastStatus() and LastError()) are stored per thread, this makes them consistent across calls, and
+// suits the drivers expecting to be controlled from a single thread. A call which panics on the dedicated thread
+// returns an error matching sdrerror.ErrUnknown, or the zero value for the calls without error, and the thread keeps
+// running the next calls.
+type SyncDevice struct {
+	device   Device
+	mutex    sync.Mutex
+	executor *threadExecutor
+	closed   bool
+}
+
+// Ensure SyncDevice implements the full Device API
+var _ Device = (*SyncDevice)(nil)
+
+// threadExecutor runs calls one after the other on a dedicated OS thread
+type threadExecutor struct {
+	calls chan func()
+}
+
+// NewSyncDevice wraps a device so that it can be used from several goroutines.
+//
+// Params:
+//  - device: the device to wrap, which must not be used directly afterwards
+//  - dedicatedThread: true to make all the calls from a dedicated OS thread, which is stopped by Unmake()
+//
+// Return the synchronised device
+func NewSyncDevice(device Device, dedicatedThread bool) *SyncDevice {
+
+	dev := &SyncDevice{
+		device: device,
+	}
+
+	if dedicatedThread {
+		dev.executor = newThreadExecutor()
+	}
+
+	return dev
+}
+
+// Device returns the wrapped device. Calls made directly on the wrapped device are not serialised.
+func (dev *SyncDevice) Device() Device {
+
+	return dev.device
+}
+
+// Do runs a function with exclusive access to the wrapped device, on the dedicated OS thread if any. It allows to
+// serialise calls which are not part of the Device interface, such as SetupStream(), or to make several calls
+// atomically.
+//
+// Params:
+//  - call: the function to run, receiving the wrapped device
+//
+// Return nil once the function has run, an error matching sdrerror.ErrUnknown if it panicked on the dedicated OS
+// thread, or an error matching sdrerror.ErrClosed without running it if the device is unmade
+func (dev *SyncDevice) Do(call func(device Device)) (err sdrerror.SDRError) {
+
+	return dev.do("Do", func() {
+		call(dev.device)
+	})
+}
+
+// do runs a call with exclusive access to the wrapped device. The call is rejected once the device is unmade, in which
+// case the results of the call keep their zero values.
+//
+// Params:
+//  - op: the name of the call
+//  - call: the call
+//
+// Return nil once the call has run, an error matching sdrerror.ErrUnknown if the call panicked on the dedicated OS
+// thread, or an error matching sdrerror.ErrClosed if the call is rejected
+func (dev *SyncDevice) do(op string, call func()) sdrerror.SDRError {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	if dev.closed {
+		return rejectedError(sdrerror.ErrClosed, op, "")
+	}
+
+	if dev.executor != nil {
+		if recovered := dev.executor.do(call); recovered != nil {
+			return panicError(op, recovered)
+		}
+		return nil
+	}
+
+	call()
+
+	return nil
+}
+
+// Unmake unmakes or releases the wrapped device and stops the dedicated OS thread, if any. Any call on the device
+// afterwards returns an error matching sdrerror.ErrClosed, or the zero value for the calls without error.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the device is already released
+func (dev *SyncDevice) Unmake() (err sdrerror.SDRError) {
+
+	dev.mutex.Lock()
+	defer dev.mutex.Unlock()
+
+	if dev.closed {
+		return rejectedError(sdrerror.ErrClosed, "Unmake", "")
+	}
+	dev.closed = true
+
+	if dev.executor == nil {
+		return dev.device.Unmake()
+	}
+
+	if recovered := dev.executor.do(func() {
+		err = dev.device.Unmake()
+	}); recovered != nil {
+		err = panicError("Unmake", recovered)
+	}
+	dev.executor.stop()
+	dev.executor = nil
+
+	return err
+}
+
+// panicError returns the error of a call which panicked on the dedicated OS thread
+func panicError(op string, recovered any) sdrerror.SDRError {
+
+	return sdrerror.Wrap(sdrerror.ErrUnknown.SDRErrorCode(), fmt.Sprintf("panic: %v", recovered), op, "", -1)
+}
+
+// newThreadExecutor starts an executor and its OS thread
+func newThreadExecutor() *threadExecutor {
+
+	executor := &threadExecutor{
+		calls: make(chan func()),
+	}
+
+	go executor.run()
+
+	return executor
+}
+
+// run runs the calls on the OS thread of the executor until the executor is stopped. The goroutine never unlocks its
+// thread, so that the thread is terminated with the goroutine rather than reused for other goroutines.
+func (executor *threadExecutor) run() {
+
+	runtime.LockOSThread()
+
+	for call := range executor.calls {
+		call()
+	}
+}
+
+// do runs a call on the OS thread of the executor and waits for its completion. A panic of the call is recovered on
+// the OS thread, which keeps running the next calls.
+//
+// Return the value of the panic of the call, nil if the call did not panic
+func (executor *threadExecutor) do(call func()) (recovered any) {
+
+	done := make(chan struct{})
+
+	executor.calls <- func() {
+		defer close(done)
+		defer func() {
+			recovered = recover()
+		}()
+		call()
+	}
+
+	<-done
+
+	return recovered
+}
+
+// stop stops the executor and terminates its OS thread
+func (executor *threadExecutor) stop() {
+
+	close(executor.calls)
+}
+
+// ReadUARTContext reads bytes from a UART until newline or until the context is done. The lock of the device is only
+// held during the successive reads with a bounded timeout, see the ReadUARTContext function.
+func (dev *SyncDevice) ReadUARTContext(ctx context.Context, which string) (data string, err error) {
+
+	return ReadUARTContext(ctx, dev, which)
+}
@@ -0,0 +1,1844 @@
+// Code generated by syncgen from api.go; DO NOT EDIT.
+
+package device
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// GetDriverKey returns a key that uniquely identifies the device driver.
+func (dev *SyncDevice) GetDriverKey() (driverKey string) {
+
+	dev.do("GetDriverKey", func() {
+		driverKey = dev.device.GetDriverKey()
+	})
+
+	return driverKey
+}
+
+// GetDriverKeyChecked returns a key that uniquely identifies the device driver, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetDriverKeyChecked() (driverKey string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetDriverKey", func() {
+		driverKey, err = dev.device.GetDriverKeyChecked()
+	}); rejected != nil {
+		return driverKey, rejected
+	}
+
+	return driverKey, err
+}
+
+// GetHardwareKey returns a key that uniquely identifies the hardware.
+func (dev *SyncDevice) GetHardwareKey() (hardwareKey string) {
+
+	dev.do("GetHardwareKey", func() {
+		hardwareKey = dev.device.GetHardwareKey()
+	})
+
+	return hardwareKey
+}
+
+// GetHardwareKeyChecked returns a key that uniquely identifies the hardware, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetHardwareKeyChecked() (hardwareKey string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetHardwareKey", func() {
+		hardwareKey, err = dev.device.GetHardwareKeyChecked()
+	}); rejected != nil {
+		return hardwareKey, rejected
+	}
+
+	return hardwareKey, err
+}
+
+// GetHardwareInfo queries a dictionary of available device information.
+func (dev *SyncDevice) GetHardwareInfo() (hardwareInfo map[string]string) {
+
+	dev.do("GetHardwareInfo", func() {
+		hardwareInfo = dev.device.GetHardwareInfo()
+	})
+
+	return hardwareInfo
+}
+
+// GetHardwareInfoChecked queries a dictionary of available device information, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetHardwareInfoChecked() (hardwareInfo map[string]string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetHardwareInfo", func() {
+		hardwareInfo, err = dev.device.GetHardwareInfoChecked()
+	}); rejected != nil {
+		return hardwareInfo, rejected
+	}
+
+	return hardwareInfo, err
+}
+
+// SetFrontendMapping sets the frontend mapping of available DSP units to RF frontends.
+func (dev *SyncDevice) SetFrontendMapping(direction Direction, mapping string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetFrontendMapping", func() {
+		err = dev.device.SetFrontendMapping(direction, mapping)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetFrontendMapping gets the mapping configuration string.
+func (dev *SyncDevice) GetFrontendMapping(direction Direction) (mapping string) {
+
+	dev.do("GetFrontendMapping", func() {
+		mapping = dev.device.GetFrontendMapping(direction)
+	})
+
+	return mapping
+}
+
+// GetFrontendMappingChecked gets the mapping configuration string, returning the failure reported by the driver.
+func (dev *SyncDevice) GetFrontendMappingChecked(direction Direction) (mapping string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrontendMapping", func() {
+		mapping, err = dev.device.GetFrontendMappingChecked(direction)
+	}); rejected != nil {
+		return mapping, rejected
+	}
+
+	return mapping, err
+}
+
+// GetNumChannels gets a number of channels given the streaming direction.
+func (dev *SyncDevice) GetNumChannels(direction Direction) (nbChannels uint) {
+
+	dev.do("GetNumChannels", func() {
+		nbChannels = dev.device.GetNumChannels(direction)
+	})
+
+	return nbChannels
+}
+
+// GetNumChannelsChecked gets a number of channels given the streaming direction, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetNumChannelsChecked(direction Direction) (nbChannels uint, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetNumChannels", func() {
+		nbChannels, err = dev.device.GetNumChannelsChecked(direction)
+	}); rejected != nil {
+		return nbChannels, rejected
+	}
+
+	return nbChannels, err
+}
+
+// GetChannelInfo gets channel info given the streaming direction.
+func (dev *SyncDevice) GetChannelInfo(direction Direction, channel uint) (info map[string]string) {
+
+	dev.do("GetChannelInfo", func() {
+		info = dev.device.GetChannelInfo(direction, channel)
+	})
+
+	return info
+}
+
+// GetChannelInfoChecked gets channel info given the streaming direction, returning the failure reported by the driver.
+func (dev *SyncDevice) GetChannelInfoChecked(direction Direction, channel uint) (info map[string]string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetChannelInfo", func() {
+		info, err = dev.device.GetChannelInfoChecked(direction, channel)
+	}); rejected != nil {
+		return info, rejected
+	}
+
+	return info, err
+}
+
+// GetFullDuplex finds out if the specified channel is full or half duplex.
+func (dev *SyncDevice) GetFullDuplex(direction Direction, channel uint) (fullDuplex bool) {
+
+	dev.do("GetFullDuplex", func() {
+		fullDuplex = dev.device.GetFullDuplex(direction, channel)
+	})
+
+	return fullDuplex
+}
+
+// GetFullDuplexChecked finds out if the specified channel is full or half duplex, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetFullDuplexChecked(direction Direction, channel uint) (fullDuplex bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFullDuplex", func() {
+		fullDuplex, err = dev.device.GetFullDuplexChecked(direction, channel)
+	}); rejected != nil {
+		return fullDuplex, rejected
+	}
+
+	return fullDuplex, err
+}
+
+// GetStreamFormats queries a list of the available stream formats.
+func (dev *SyncDevice) GetStreamFormats(direction Direction, channel uint) (formats []string) {
+
+	dev.do("GetStreamFormats", func() {
+		formats = dev.device.GetStreamFormats(direction, channel)
+	})
+
+	return formats
+}
+
+// GetStreamFormatsChecked queries a list of the available stream formats, returning the failure reported by the driver.
+func (dev *SyncDevice) GetStreamFormatsChecked(direction Direction, channel uint) (formats []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetStreamFormats", func() {
+		formats, err = dev.device.GetStreamFormatsChecked(direction, channel)
+	}); rejected != nil {
+		return formats, rejected
+	}
+
+	return formats, err
+}
+
+// GetNativeStreamFormat gets the hardware's native stream format for this channel.
+func (dev *SyncDevice) GetNativeStreamFormat(direction Direction, channel uint) (format string, fullScale float64) {
+
+	dev.do("GetNativeStreamFormat", func() {
+		format, fullScale = dev.device.GetNativeStreamFormat(direction, channel)
+	})
+
+	return format, fullScale
+}
+
+// GetNativeStreamFormatChecked gets the hardware's native stream format for this channel, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) GetNativeStreamFormatChecked(direction Direction, channel uint) (format string, fullScale float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetNativeStreamFormat", func() {
+		format, fullScale, err = dev.device.GetNativeStreamFormatChecked(direction, channel)
+	}); rejected != nil {
+		return format, fullScale, rejected
+	}
+
+	return format, fullScale, err
+}
+
+// GetStreamArgsInfo queries the argument info description for stream args.
+func (dev *SyncDevice) GetStreamArgsInfo(direction Direction, channel uint) (infos []SDRArgInfo) {
+
+	dev.do("GetStreamArgsInfo", func() {
+		infos = dev.device.GetStreamArgsInfo(direction, channel)
+	})
+
+	return infos
+}
+
+// GetStreamArgsInfoChecked queries the argument info description for stream args, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetStreamArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetStreamArgsInfo", func() {
+		infos, err = dev.device.GetStreamArgsInfoChecked(direction, channel)
+	}); rejected != nil {
+		return infos, rejected
+	}
+
+	return infos, err
+}
+
+// SetupSDRStreamCU8 initializes a stream in CU8 format.
+func (dev *SyncDevice) SetupSDRStreamCU8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU8, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCU8", func() {
+		stream, err = dev.device.SetupSDRStreamCU8(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// SetupSDRStreamCS8 initializes a stream in CS8 format.
+func (dev *SyncDevice) SetupSDRStreamCS8(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS8, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCS8", func() {
+		stream, err = dev.device.SetupSDRStreamCS8(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// SetupSDRStreamCU16 initializes a stream in CU16 format.
+func (dev *SyncDevice) SetupSDRStreamCU16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCU16, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCU16", func() {
+		stream, err = dev.device.SetupSDRStreamCU16(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// SetupSDRStreamCS16 initializes a stream in CS16 format.
+func (dev *SyncDevice) SetupSDRStreamCS16(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCS16, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCS16", func() {
+		stream, err = dev.device.SetupSDRStreamCS16(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// SetupSDRStreamCF32 initializes a stream in CF32 format.
+func (dev *SyncDevice) SetupSDRStreamCF32(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF32, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCF32", func() {
+		stream, err = dev.device.SetupSDRStreamCF32(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// SetupSDRStreamCF64 initializes a stream in CF64 format.
+func (dev *SyncDevice) SetupSDRStreamCF64(direction Direction, channels []uint, args map[string]string) (stream TypedStreamCF64, err error) {
+
+	if rejected := dev.do("SetupSDRStreamCF64", func() {
+		stream, err = dev.device.SetupSDRStreamCF64(direction, channels, args)
+	}); rejected != nil {
+		return stream, rejected
+	}
+
+	return stream, err
+}
+
+// ListAntennas gets a list of available antennas to select on a given chain.
+func (dev *SyncDevice) ListAntennas(direction Direction, channel uint) (names []string) {
+
+	dev.do("ListAntennas", func() {
+		names = dev.device.ListAntennas(direction, channel)
+	})
+
+	return names
+}
+
+// ListAntennasChecked gets a list of available antennas to select on a given chain, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) ListAntennasChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListAntennas", func() {
+		names, err = dev.device.ListAntennasChecked(direction, channel)
+	}); rejected != nil {
+		return names, rejected
+	}
+
+	return names, err
+}
+
+// SetAntennas sets the selected antenna on a chain.
+func (dev *SyncDevice) SetAntennas(direction Direction, channel uint, name string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetAntennas", func() {
+		err = dev.device.SetAntennas(direction, channel, name)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetAntennas gets the selected antenna on a chain.
+func (dev *SyncDevice) GetAntennas(direction Direction, channel uint) (antenna string) {
+
+	dev.do("GetAntennas", func() {
+		antenna = dev.device.GetAntennas(direction, channel)
+	})
+
+	return antenna
+}
+
+// GetAntennasChecked gets the selected antenna on a chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetAntennasChecked(direction Direction, channel uint) (antenna string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetAntennas", func() {
+		antenna, err = dev.device.GetAntennasChecked(direction, channel)
+	}); rejected != nil {
+		return antenna, rejected
+	}
+
+	return antenna, err
+}
+
+// HasDCOffsetMode returns if the device support automatic DC offset corrections.
+func (dev *SyncDevice) HasDCOffsetMode(direction Direction, channel uint) (supported bool) {
+
+	dev.do("HasDCOffsetMode", func() {
+		supported = dev.device.HasDCOffsetMode(direction, channel)
+	})
+
+	return supported
+}
+
+// HasDCOffsetModeChecked returns if the device support automatic DC offset corrections, returning the failure reported
+// by the driver.
+func (dev *SyncDevice) HasDCOffsetModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasDCOffsetMode", func() {
+		supported, err = dev.device.HasDCOffsetModeChecked(direction, channel)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// SetDCOffsetMode sets the automatic DC offset corrections mode.
+func (dev *SyncDevice) SetDCOffsetMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetDCOffsetMode", func() {
+		err = dev.device.SetDCOffsetMode(direction, channel, automatic)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetDCOffsetMode gets the automatic DC offset corrections mode.
+func (dev *SyncDevice) GetDCOffsetMode(direction Direction, channel uint) (automatic bool) {
+
+	dev.do("GetDCOffsetMode", func() {
+		automatic = dev.device.GetDCOffsetMode(direction, channel)
+	})
+
+	return automatic
+}
+
+// GetDCOffsetModeChecked gets the automatic DC offset corrections mode, returning the failure reported by the driver.
+func (dev *SyncDevice) GetDCOffsetModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetDCOffsetMode", func() {
+		automatic, err = dev.device.GetDCOffsetModeChecked(direction, channel)
+	}); rejected != nil {
+		return automatic, rejected
+	}
+
+	return automatic, err
+}
+
+// HasDCOffset returns if the device support frontend DC offset correction.
+func (dev *SyncDevice) HasDCOffset(direction Direction, channel uint) (supported bool) {
+
+	dev.do("HasDCOffset", func() {
+		supported = dev.device.HasDCOffset(direction, channel)
+	})
+
+	return supported
+}
+
+// HasDCOffsetChecked returns if the device support frontend DC offset correction, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) HasDCOffsetChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasDCOffset", func() {
+		supported, err = dev.device.HasDCOffsetChecked(direction, channel)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// SetDCOffset sets the frontend DC offset correction.
+func (dev *SyncDevice) SetDCOffset(direction Direction, channel uint, offsetI float64, offsetQ float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetDCOffset", func() {
+		err = dev.device.SetDCOffset(direction, channel, offsetI, offsetQ)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetDCOffset gets frontend DC offset correction.
+func (dev *SyncDevice) GetDCOffset(direction Direction, channel uint) (offsetI float64, offsetQ float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetDCOffset", func() {
+		offsetI, offsetQ, err = dev.device.GetDCOffset(direction, channel)
+	}); rejected != nil {
+		return offsetI, offsetQ, rejected
+	}
+
+	return offsetI, offsetQ, err
+}
+
+// HasIQBalance returns if the device support frontend IQ balance correction.
+func (dev *SyncDevice) HasIQBalance(direction Direction, channel uint) (supported bool) {
+
+	dev.do("HasIQBalance", func() {
+		supported = dev.device.HasIQBalance(direction, channel)
+	})
+
+	return supported
+}
+
+// HasIQBalanceChecked returns if the device support frontend IQ balance correction, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) HasIQBalanceChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasIQBalance", func() {
+		supported, err = dev.device.HasIQBalanceChecked(direction, channel)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// SetIQBalance sets the frontend IQ balance correction.
+func (dev *SyncDevice) SetIQBalance(direction Direction, channel uint, balanceI float64, balanceQ float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetIQBalance", func() {
+		err = dev.device.SetIQBalance(direction, channel, balanceI, balanceQ)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetIQBalance gets the IQ balance correction.
+func (dev *SyncDevice) GetIQBalance(direction Direction, channel uint) (balanceI float64, balanceQ float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetIQBalance", func() {
+		balanceI, balanceQ, err = dev.device.GetIQBalance(direction, channel)
+	}); rejected != nil {
+		return balanceI, balanceQ, rejected
+	}
+
+	return balanceI, balanceQ, err
+}
+
+// HasFrequencyCorrection returns if the device support frontend frequency correction.
+func (dev *SyncDevice) HasFrequencyCorrection(direction Direction, channel uint) (supported bool) {
+
+	dev.do("HasFrequencyCorrection", func() {
+		supported = dev.device.HasFrequencyCorrection(direction, channel)
+	})
+
+	return supported
+}
+
+// HasFrequencyCorrectionChecked returns if the device support frontend frequency correction, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) HasFrequencyCorrectionChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasFrequencyCorrection", func() {
+		supported, err = dev.device.HasFrequencyCorrectionChecked(direction, channel)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// SetFrequencyCorrection fine tunes the frontend frequency correction.
+func (dev *SyncDevice) SetFrequencyCorrection(direction Direction, channel uint, value float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetFrequencyCorrection", func() {
+		err = dev.device.SetFrequencyCorrection(direction, channel, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetFrequencyCorrection gets the frontend frequency correction value.
+func (dev *SyncDevice) GetFrequencyCorrection(direction Direction, channel uint) (value float64) {
+
+	dev.do("GetFrequencyCorrection", func() {
+		value = dev.device.GetFrequencyCorrection(direction, channel)
+	})
+
+	return value
+}
+
+// GetFrequencyCorrectionChecked gets the frontend frequency correction value, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetFrequencyCorrectionChecked(direction Direction, channel uint) (value float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequencyCorrection", func() {
+		value, err = dev.device.GetFrequencyCorrectionChecked(direction, channel)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// ListGains lists available amplification elements.
+func (dev *SyncDevice) ListGains(direction Direction, channel uint) (names []string) {
+
+	dev.do("ListGains", func() {
+		names = dev.device.ListGains(direction, channel)
+	})
+
+	return names
+}
+
+// ListGainsChecked lists available amplification elements, returning the failure reported by the driver.
+func (dev *SyncDevice) ListGainsChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListGains", func() {
+		names, err = dev.device.ListGainsChecked(direction, channel)
+	}); rejected != nil {
+		return names, rejected
+	}
+
+	return names, err
+}
+
+// HasGainMode returns if the device support automatic gain control.
+func (dev *SyncDevice) HasGainMode(direction Direction, channel uint) (supported bool) {
+
+	dev.do("HasGainMode", func() {
+		supported = dev.device.HasGainMode(direction, channel)
+	})
+
+	return supported
+}
+
+// HasGainModeChecked returns if the device support automatic gain control, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) HasGainModeChecked(direction Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasGainMode", func() {
+		supported, err = dev.device.HasGainModeChecked(direction, channel)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// SetGainMode sets the automatic gain mode on the chain.
+func (dev *SyncDevice) SetGainMode(direction Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetGainMode", func() {
+		err = dev.device.SetGainMode(direction, channel, automatic)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetGainMode gets the automatic gain mode on the chain.
+func (dev *SyncDevice) GetGainMode(direction Direction, channel uint) (automatic bool) {
+
+	dev.do("GetGainMode", func() {
+		automatic = dev.device.GetGainMode(direction, channel)
+	})
+
+	return automatic
+}
+
+// GetGainModeChecked gets the automatic gain mode on the chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetGainModeChecked(direction Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetGainMode", func() {
+		automatic, err = dev.device.GetGainModeChecked(direction, channel)
+	}); rejected != nil {
+		return automatic, rejected
+	}
+
+	return automatic, err
+}
+
+// SetGain sets the overall amplification in a chain.
+func (dev *SyncDevice) SetGain(direction Direction, channel uint, gain float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetGain", func() {
+		err = dev.device.SetGain(direction, channel, gain)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// SetGainElement sets the value of a amplification element in a chain.
+func (dev *SyncDevice) SetGainElement(direction Direction, channel uint, name string, gain float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetGainElement", func() {
+		err = dev.device.SetGainElement(direction, channel, name, gain)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetGain gets the overall value of the gain elements in a chain.
+func (dev *SyncDevice) GetGain(direction Direction, channel uint) (gain float64) {
+
+	dev.do("GetGain", func() {
+		gain = dev.device.GetGain(direction, channel)
+	})
+
+	return gain
+}
+
+// GetGainChecked gets the overall value of the gain elements in a chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetGainChecked(direction Direction, channel uint) (gain float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetGain", func() {
+		gain, err = dev.device.GetGainChecked(direction, channel)
+	}); rejected != nil {
+		return gain, rejected
+	}
+
+	return gain, err
+}
+
+// GetGainElement gets the value of an individual amplification element in a chain.
+func (dev *SyncDevice) GetGainElement(direction Direction, channel uint, name string) (gain float64) {
+
+	dev.do("GetGainElement", func() {
+		gain = dev.device.GetGainElement(direction, channel, name)
+	})
+
+	return gain
+}
+
+// GetGainElementChecked gets the value of an individual amplification element in a chain, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) GetGainElementChecked(direction Direction, channel uint, name string) (gain float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetGainElement", func() {
+		gain, err = dev.device.GetGainElementChecked(direction, channel, name)
+	}); rejected != nil {
+		return gain, rejected
+	}
+
+	return gain, err
+}
+
+// GetGainRange gets the overall range of possible gain values.
+func (dev *SyncDevice) GetGainRange(direction Direction, channel uint) (gainRange SDRRange) {
+
+	dev.do("GetGainRange", func() {
+		gainRange = dev.device.GetGainRange(direction, channel)
+	})
+
+	return gainRange
+}
+
+// GetGainRangeChecked gets the overall range of possible gain values, returning the failure reported by the driver.
+func (dev *SyncDevice) GetGainRangeChecked(direction Direction, channel uint) (gainRange SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetGainRange", func() {
+		gainRange, err = dev.device.GetGainRangeChecked(direction, channel)
+	}); rejected != nil {
+		return gainRange, rejected
+	}
+
+	return gainRange, err
+}
+
+// GetGainElementRange gets the range of possible gain values for a specific element.
+func (dev *SyncDevice) GetGainElementRange(direction Direction, channel uint, name string) (gainRange SDRRange) {
+
+	dev.do("GetGainElementRange", func() {
+		gainRange = dev.device.GetGainElementRange(direction, channel, name)
+	})
+
+	return gainRange
+}
+
+// GetGainElementRangeChecked gets the range of possible gain values for a specific element, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) GetGainElementRangeChecked(direction Direction, channel uint, name string) (gainRange SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetGainElementRange", func() {
+		gainRange, err = dev.device.GetGainElementRangeChecked(direction, channel, name)
+	}); rejected != nil {
+		return gainRange, rejected
+	}
+
+	return gainRange, err
+}
+
+// SetFrequency sets the center frequency of the chain.
+func (dev *SyncDevice) SetFrequency(direction Direction, channel uint, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetFrequency", func() {
+		err = dev.device.SetFrequency(direction, channel, frequency, args)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// SetFrequencyComponent tunes the center frequency of the specified element.
+func (dev *SyncDevice) SetFrequencyComponent(direction Direction, channel uint, name string, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetFrequencyComponent", func() {
+		err = dev.device.SetFrequencyComponent(direction, channel, name, frequency, args)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetFrequency gets the overall center frequency of the chain.
+func (dev *SyncDevice) GetFrequency(direction Direction, channel uint) (frequency float64) {
+
+	dev.do("GetFrequency", func() {
+		frequency = dev.device.GetFrequency(direction, channel)
+	})
+
+	return frequency
+}
+
+// GetFrequencyChecked gets the overall center frequency of the chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetFrequencyChecked(direction Direction, channel uint) (frequency float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequency", func() {
+		frequency, err = dev.device.GetFrequencyChecked(direction, channel)
+	}); rejected != nil {
+		return frequency, rejected
+	}
+
+	return frequency, err
+}
+
+// GetFrequencyComponent gets the frequency of a tunable element in the chain.
+func (dev *SyncDevice) GetFrequencyComponent(direction Direction, channel uint, name string) (frequency float64) {
+
+	dev.do("GetFrequencyComponent", func() {
+		frequency = dev.device.GetFrequencyComponent(direction, channel, name)
+	})
+
+	return frequency
+}
+
+// GetFrequencyComponentChecked gets the frequency of a tunable element in the chain, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) GetFrequencyComponentChecked(direction Direction, channel uint, name string) (frequency float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequencyComponent", func() {
+		frequency, err = dev.device.GetFrequencyComponentChecked(direction, channel, name)
+	}); rejected != nil {
+		return frequency, rejected
+	}
+
+	return frequency, err
+}
+
+// ListFrequencies lists available tunable elements in the chain.
+func (dev *SyncDevice) ListFrequencies(direction Direction, channel uint) (names []string) {
+
+	dev.do("ListFrequencies", func() {
+		names = dev.device.ListFrequencies(direction, channel)
+	})
+
+	return names
+}
+
+// ListFrequenciesChecked lists available tunable elements in the chain, returning the failure reported by the driver.
+func (dev *SyncDevice) ListFrequenciesChecked(direction Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListFrequencies", func() {
+		names, err = dev.device.ListFrequenciesChecked(direction, channel)
+	}); rejected != nil {
+		return names, rejected
+	}
+
+	return names, err
+}
+
+// GetFrequencyRange gets the range of overall frequency values.
+func (dev *SyncDevice) GetFrequencyRange(direction Direction, channel uint) (ranges []SDRRange) {
+
+	dev.do("GetFrequencyRange", func() {
+		ranges = dev.device.GetFrequencyRange(direction, channel)
+	})
+
+	return ranges
+}
+
+// GetFrequencyRangeChecked gets the range of overall frequency values, returning the failure reported by the driver.
+func (dev *SyncDevice) GetFrequencyRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequencyRange", func() {
+		ranges, err = dev.device.GetFrequencyRangeChecked(direction, channel)
+	}); rejected != nil {
+		return ranges, rejected
+	}
+
+	return ranges, err
+}
+
+// GetFrequencyRangeComponent gets the range of tunable values for the specified element.
+func (dev *SyncDevice) GetFrequencyRangeComponent(direction Direction, channel uint, name string) (ranges []SDRRange) {
+
+	dev.do("GetFrequencyRangeComponent", func() {
+		ranges = dev.device.GetFrequencyRangeComponent(direction, channel, name)
+	})
+
+	return ranges
+}
+
+// GetFrequencyRangeComponentChecked gets the range of tunable values for the specified element, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) GetFrequencyRangeComponentChecked(direction Direction, channel uint, name string) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequencyRangeComponent", func() {
+		ranges, err = dev.device.GetFrequencyRangeComponentChecked(direction, channel, name)
+	}); rejected != nil {
+		return ranges, rejected
+	}
+
+	return ranges, err
+}
+
+// GetFrequencyArgsInfo queries the argument info description for tune args.
+func (dev *SyncDevice) GetFrequencyArgsInfo(direction Direction, channel uint) (infos []SDRArgInfo) {
+
+	dev.do("GetFrequencyArgsInfo", func() {
+		infos = dev.device.GetFrequencyArgsInfo(direction, channel)
+	})
+
+	return infos
+}
+
+// GetFrequencyArgsInfoChecked queries the argument info description for tune args, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) GetFrequencyArgsInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetFrequencyArgsInfo", func() {
+		infos, err = dev.device.GetFrequencyArgsInfoChecked(direction, channel)
+	}); rejected != nil {
+		return infos, rejected
+	}
+
+	return infos, err
+}
+
+// SetSampleRate sets the baseband sample rate of the chain.
+func (dev *SyncDevice) SetSampleRate(direction Direction, channel uint, rate float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetSampleRate", func() {
+		err = dev.device.SetSampleRate(direction, channel, rate)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetSampleRate gets the baseband sample rate of the chain.
+func (dev *SyncDevice) GetSampleRate(direction Direction, channel uint) (rate float64) {
+
+	dev.do("GetSampleRate", func() {
+		rate = dev.device.GetSampleRate(direction, channel)
+	})
+
+	return rate
+}
+
+// GetSampleRateChecked gets the baseband sample rate of the chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetSampleRateChecked(direction Direction, channel uint) (rate float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetSampleRate", func() {
+		rate, err = dev.device.GetSampleRateChecked(direction, channel)
+	}); rejected != nil {
+		return rate, rejected
+	}
+
+	return rate, err
+}
+
+// GetSampleRateRange gets the range of possible baseband sample rates.
+func (dev *SyncDevice) GetSampleRateRange(direction Direction, channel uint) (ranges []SDRRange) {
+
+	dev.do("GetSampleRateRange", func() {
+		ranges = dev.device.GetSampleRateRange(direction, channel)
+	})
+
+	return ranges
+}
+
+// GetSampleRateRangeChecked gets the range of possible baseband sample rates, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetSampleRateRangeChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetSampleRateRange", func() {
+		ranges, err = dev.device.GetSampleRateRangeChecked(direction, channel)
+	}); rejected != nil {
+		return ranges, rejected
+	}
+
+	return ranges, err
+}
+
+// SetBandwidth sets the baseband filter width of the chain.
+func (dev *SyncDevice) SetBandwidth(direction Direction, channel uint, bw float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetBandwidth", func() {
+		err = dev.device.SetBandwidth(direction, channel, bw)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetBandwidth gets the baseband filter width of the chain.
+func (dev *SyncDevice) GetBandwidth(direction Direction, channel uint) (bw float64) {
+
+	dev.do("GetBandwidth", func() {
+		bw = dev.device.GetBandwidth(direction, channel)
+	})
+
+	return bw
+}
+
+// GetBandwidthChecked gets the baseband filter width of the chain, returning the failure reported by the driver.
+func (dev *SyncDevice) GetBandwidthChecked(direction Direction, channel uint) (bw float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetBandwidth", func() {
+		bw, err = dev.device.GetBandwidthChecked(direction, channel)
+	}); rejected != nil {
+		return bw, rejected
+	}
+
+	return bw, err
+}
+
+// GetBandwidthRanges gets the range of possible baseband filter widths.
+func (dev *SyncDevice) GetBandwidthRanges(direction Direction, channel uint) (ranges []SDRRange) {
+
+	dev.do("GetBandwidthRanges", func() {
+		ranges = dev.device.GetBandwidthRanges(direction, channel)
+	})
+
+	return ranges
+}
+
+// GetBandwidthRangesChecked gets the range of possible baseband filter widths, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetBandwidthRangesChecked(direction Direction, channel uint) (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetBandwidthRanges", func() {
+		ranges, err = dev.device.GetBandwidthRangesChecked(direction, channel)
+	}); rejected != nil {
+		return ranges, rejected
+	}
+
+	return ranges, err
+}
+
+// SetMasterClockRate sets the master clock rate of the device.
+func (dev *SyncDevice) SetMasterClockRate(rate float64) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetMasterClockRate", func() {
+		err = dev.device.SetMasterClockRate(rate)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetMasterClockRate gets the master clock rate of the device.
+func (dev *SyncDevice) GetMasterClockRate() (rate float64) {
+
+	dev.do("GetMasterClockRate", func() {
+		rate = dev.device.GetMasterClockRate()
+	})
+
+	return rate
+}
+
+// GetMasterClockRateChecked gets the master clock rate of the device, returning the failure reported by the driver.
+func (dev *SyncDevice) GetMasterClockRateChecked() (rate float64, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetMasterClockRate", func() {
+		rate, err = dev.device.GetMasterClockRateChecked()
+	}); rejected != nil {
+		return rate, rejected
+	}
+
+	return rate, err
+}
+
+// GetMasterClockRates gets the range of available master clock rates.
+func (dev *SyncDevice) GetMasterClockRates() (ranges []SDRRange) {
+
+	dev.do("GetMasterClockRates", func() {
+		ranges = dev.device.GetMasterClockRates()
+	})
+
+	return ranges
+}
+
+// GetMasterClockRatesChecked gets the range of available master clock rates, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetMasterClockRatesChecked() (ranges []SDRRange, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetMasterClockRates", func() {
+		ranges, err = dev.device.GetMasterClockRatesChecked()
+	}); rejected != nil {
+		return ranges, rejected
+	}
+
+	return ranges, err
+}
+
+// ListClockSources gets the list of available clock sources.
+func (dev *SyncDevice) ListClockSources() (sources []string) {
+
+	dev.do("ListClockSources", func() {
+		sources = dev.device.ListClockSources()
+	})
+
+	return sources
+}
+
+// ListClockSourcesChecked gets the list of available clock sources, returning the failure reported by the driver.
+func (dev *SyncDevice) ListClockSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListClockSources", func() {
+		sources, err = dev.device.ListClockSourcesChecked()
+	}); rejected != nil {
+		return sources, rejected
+	}
+
+	return sources, err
+}
+
+// SetClockSource set the clock source on the device.
+func (dev *SyncDevice) SetClockSource(source string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetClockSource", func() {
+		err = dev.device.SetClockSource(source)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetClockSource gets the clock source of the device.
+func (dev *SyncDevice) GetClockSource() (source string) {
+
+	dev.do("GetClockSource", func() {
+		source = dev.device.GetClockSource()
+	})
+
+	return source
+}
+
+// GetClockSourceChecked gets the clock source of the device, returning the failure reported by the driver.
+func (dev *SyncDevice) GetClockSourceChecked() (source string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetClockSource", func() {
+		source, err = dev.device.GetClockSourceChecked()
+	}); rejected != nil {
+		return source, rejected
+	}
+
+	return source, err
+}
+
+// ListTimeSources gets the list of available time sources.
+func (dev *SyncDevice) ListTimeSources() (sources []string) {
+
+	dev.do("ListTimeSources", func() {
+		sources = dev.device.ListTimeSources()
+	})
+
+	return sources
+}
+
+// ListTimeSourcesChecked gets the list of available time sources, returning the failure reported by the driver.
+func (dev *SyncDevice) ListTimeSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListTimeSources", func() {
+		sources, err = dev.device.ListTimeSourcesChecked()
+	}); rejected != nil {
+		return sources, rejected
+	}
+
+	return sources, err
+}
+
+// SetTimeSource set the time source on the device.
+func (dev *SyncDevice) SetTimeSource(source string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetTimeSource", func() {
+		err = dev.device.SetTimeSource(source)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// GetTimeSource gets the time source of the device.
+func (dev *SyncDevice) GetTimeSource() (source string) {
+
+	dev.do("GetTimeSource", func() {
+		source = dev.device.GetTimeSource()
+	})
+
+	return source
+}
+
+// GetTimeSourceChecked gets the time source of the device, returning the failure reported by the driver.
+func (dev *SyncDevice) GetTimeSourceChecked() (source string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetTimeSource", func() {
+		source, err = dev.device.GetTimeSourceChecked()
+	}); rejected != nil {
+		return source, rejected
+	}
+
+	return source, err
+}
+
+// HasHardwareTime checks if the device have a hardware clock.
+func (dev *SyncDevice) HasHardwareTime(what string) (supported bool) {
+
+	dev.do("HasHardwareTime", func() {
+		supported = dev.device.HasHardwareTime(what)
+	})
+
+	return supported
+}
+
+// HasHardwareTimeChecked checks if the device have a hardware clock, returning the failure reported by the driver.
+func (dev *SyncDevice) HasHardwareTimeChecked(what string) (supported bool, err sdrerror.SDRError) {
+
+	if rejected := dev.do("HasHardwareTime", func() {
+		supported, err = dev.device.HasHardwareTimeChecked(what)
+	}); rejected != nil {
+		return supported, rejected
+	}
+
+	return supported, err
+}
+
+// GetHardwareTime reads the time from the hardware clock on the device.
+func (dev *SyncDevice) GetHardwareTime(what string) (timeNs uint) {
+
+	dev.do("GetHardwareTime", func() {
+		timeNs = dev.device.GetHardwareTime(what)
+	})
+
+	return timeNs
+}
+
+// GetHardwareTimeChecked reads the time from the hardware clock on the device, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetHardwareTimeChecked(what string) (timeNs uint, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetHardwareTime", func() {
+		timeNs, err = dev.device.GetHardwareTimeChecked(what)
+	}); rejected != nil {
+		return timeNs, rejected
+	}
+
+	return timeNs, err
+}
+
+// SetHardwareTime writes the time to the hardware clock on the device.
+func (dev *SyncDevice) SetHardwareTime(timeNs uint, what string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("SetHardwareTime", func() {
+		err = dev.device.SetHardwareTime(timeNs, what)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ListSensors gets a list of the available global readable sensors.
+func (dev *SyncDevice) ListSensors() (keys []string) {
+
+	dev.do("ListSensors", func() {
+		keys = dev.device.ListSensors()
+	})
+
+	return keys
+}
+
+// ListSensorsChecked gets a list of the available global readable sensors, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) ListSensorsChecked() (keys []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListSensors", func() {
+		keys, err = dev.device.ListSensorsChecked()
+	}); rejected != nil {
+		return keys, rejected
+	}
+
+	return keys, err
+}
+
+// GetSensorInfo gets meta-information about a sensor.
+func (dev *SyncDevice) GetSensorInfo(key string) (info SDRArgInfo) {
+
+	dev.do("GetSensorInfo", func() {
+		info = dev.device.GetSensorInfo(key)
+	})
+
+	return info
+}
+
+// GetSensorInfoChecked gets meta-information about a sensor, returning the failure reported by the driver.
+func (dev *SyncDevice) GetSensorInfoChecked(key string) (info SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetSensorInfo", func() {
+		info, err = dev.device.GetSensorInfoChecked(key)
+	}); rejected != nil {
+		return info, rejected
+	}
+
+	return info, err
+}
+
+// ReadSensor reads a global sensor given the name.
+func (dev *SyncDevice) ReadSensor(key string) (value string) {
+
+	dev.do("ReadSensor", func() {
+		value = dev.device.ReadSensor(key)
+	})
+
+	return value
+}
+
+// ReadSensorChecked reads a global sensor given the name, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadSensorChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadSensor", func() {
+		value, err = dev.device.ReadSensorChecked(key)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// ListChannelSensors gets a list of the available channel readable sensors.
+func (dev *SyncDevice) ListChannelSensors(direction Direction, channel uint) (keys []string) {
+
+	dev.do("ListChannelSensors", func() {
+		keys = dev.device.ListChannelSensors(direction, channel)
+	})
+
+	return keys
+}
+
+// ListChannelSensorsChecked gets a list of the available channel readable sensors, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) ListChannelSensorsChecked(direction Direction, channel uint) (keys []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListChannelSensors", func() {
+		keys, err = dev.device.ListChannelSensorsChecked(direction, channel)
+	}); rejected != nil {
+		return keys, rejected
+	}
+
+	return keys, err
+}
+
+// GetChannelSensorInfo gets meta-information about a channel sensor.
+func (dev *SyncDevice) GetChannelSensorInfo(direction Direction, channel uint, key string) (info SDRArgInfo) {
+
+	dev.do("GetChannelSensorInfo", func() {
+		info = dev.device.GetChannelSensorInfo(direction, channel, key)
+	})
+
+	return info
+}
+
+// GetChannelSensorInfoChecked gets meta-information about a channel sensor, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetChannelSensorInfoChecked(direction Direction, channel uint, key string) (info SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetChannelSensorInfo", func() {
+		info, err = dev.device.GetChannelSensorInfoChecked(direction, channel, key)
+	}); rejected != nil {
+		return info, rejected
+	}
+
+	return info, err
+}
+
+// ReadChannelSensor reads a channel sensor given the name.
+func (dev *SyncDevice) ReadChannelSensor(direction Direction, channel uint, key string) (value string) {
+
+	dev.do("ReadChannelSensor", func() {
+		value = dev.device.ReadChannelSensor(direction, channel, key)
+	})
+
+	return value
+}
+
+// ReadChannelSensorChecked reads a channel sensor given the name, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadChannelSensorChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadChannelSensor", func() {
+		value, err = dev.device.ReadChannelSensorChecked(direction, channel, key)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// ListRegisterInterfaces gets a list of available register interfaces by name.
+func (dev *SyncDevice) ListRegisterInterfaces() (names []string) {
+
+	dev.do("ListRegisterInterfaces", func() {
+		names = dev.device.ListRegisterInterfaces()
+	})
+
+	return names
+}
+
+// ListRegisterInterfacesChecked gets a list of available register interfaces by name, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) ListRegisterInterfacesChecked() (names []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListRegisterInterfaces", func() {
+		names, err = dev.device.ListRegisterInterfacesChecked()
+	}); rejected != nil {
+		return names, rejected
+	}
+
+	return names, err
+}
+
+// WriteRegister writes a register on the device given the interface name.
+func (dev *SyncDevice) WriteRegister(name string, addr uint32, value uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteRegister", func() {
+		err = dev.device.WriteRegister(name, addr, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadRegister reads a register on the device given the interface name.
+func (dev *SyncDevice) ReadRegister(name string, addr uint32) (value uint32) {
+
+	dev.do("ReadRegister", func() {
+		value = dev.device.ReadRegister(name, addr)
+	})
+
+	return value
+}
+
+// ReadRegisterChecked reads a register on the device given the interface name, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) ReadRegisterChecked(name string, addr uint32) (value uint32, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadRegister", func() {
+		value, err = dev.device.ReadRegisterChecked(name, addr)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// WriteRegisters writes a memory block on the device given the interface name.
+func (dev *SyncDevice) WriteRegisters(name string, addr uint32, value []uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteRegisters", func() {
+		err = dev.device.WriteRegisters(name, addr, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadRegisters reads a memory block on the device given the interface name.
+func (dev *SyncDevice) ReadRegisters(name string, addr uint32, length uint) (values []uint32) {
+
+	dev.do("ReadRegisters", func() {
+		values = dev.device.ReadRegisters(name, addr, length)
+	})
+
+	return values
+}
+
+// ReadRegistersChecked reads a memory block on the device given the interface name, returning the failure reported by
+// the driver.
+func (dev *SyncDevice) ReadRegistersChecked(name string, addr uint32, length uint) (values []uint32, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadRegisters", func() {
+		values, err = dev.device.ReadRegistersChecked(name, addr, length)
+	}); rejected != nil {
+		return values, rejected
+	}
+
+	return values, err
+}
+
+// GetSettingInfo describes the allowed keys and values used for settings.
+func (dev *SyncDevice) GetSettingInfo() (infos []SDRArgInfo) {
+
+	dev.do("GetSettingInfo", func() {
+		infos = dev.device.GetSettingInfo()
+	})
+
+	return infos
+}
+
+// GetSettingInfoChecked describes the allowed keys and values used for settings, returning the failure reported by the
+// driver.
+func (dev *SyncDevice) GetSettingInfoChecked() (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetSettingInfo", func() {
+		infos, err = dev.device.GetSettingInfoChecked()
+	}); rejected != nil {
+		return infos, rejected
+	}
+
+	return infos, err
+}
+
+// WriteSetting writes an arbitrary setting on the device.
+func (dev *SyncDevice) WriteSetting(key string, value string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteSetting", func() {
+		err = dev.device.WriteSetting(key, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadSetting reads an arbitrary setting on the device.
+func (dev *SyncDevice) ReadSetting(key string) (value string) {
+
+	dev.do("ReadSetting", func() {
+		value = dev.device.ReadSetting(key)
+	})
+
+	return value
+}
+
+// ReadSettingChecked reads an arbitrary setting on the device, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadSettingChecked(key string) (value string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadSetting", func() {
+		value, err = dev.device.ReadSettingChecked(key)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// GetChannelSettingInfo describes the allowed keys and values used for channel settings.
+func (dev *SyncDevice) GetChannelSettingInfo(direction Direction, channel uint) (infos []SDRArgInfo) {
+
+	dev.do("GetChannelSettingInfo", func() {
+		infos = dev.device.GetChannelSettingInfo(direction, channel)
+	})
+
+	return infos
+}
+
+// GetChannelSettingInfoChecked describes the allowed keys and values used for channel settings, returning the failure
+// reported by the driver.
+func (dev *SyncDevice) GetChannelSettingInfoChecked(direction Direction, channel uint) (infos []SDRArgInfo, err sdrerror.SDRError) {
+
+	if rejected := dev.do("GetChannelSettingInfo", func() {
+		infos, err = dev.device.GetChannelSettingInfoChecked(direction, channel)
+	}); rejected != nil {
+		return infos, rejected
+	}
+
+	return infos, err
+}
+
+// WriteChannelSetting writes an arbitrary channel setting on the device.
+func (dev *SyncDevice) WriteChannelSetting(direction Direction, channel uint, key string, value string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteChannelSetting", func() {
+		err = dev.device.WriteChannelSetting(direction, channel, key, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadChannelSetting an arbitrary channel setting on the device.
+func (dev *SyncDevice) ReadChannelSetting(direction Direction, channel uint, key string) (value string) {
+
+	dev.do("ReadChannelSetting", func() {
+		value = dev.device.ReadChannelSetting(direction, channel, key)
+	})
+
+	return value
+}
+
+// ReadChannelSettingChecked an arbitrary channel setting on the device, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadChannelSettingChecked(direction Direction, channel uint, key string) (value string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadChannelSetting", func() {
+		value, err = dev.device.ReadChannelSettingChecked(direction, channel, key)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// ListGPIOBanks a list of available GPIO banks by name.
+func (dev *SyncDevice) ListGPIOBanks() (banks []string) {
+
+	dev.do("ListGPIOBanks", func() {
+		banks = dev.device.ListGPIOBanks()
+	})
+
+	return banks
+}
+
+// ListGPIOBanksChecked a list of available GPIO banks by name, returning the failure reported by the driver.
+func (dev *SyncDevice) ListGPIOBanksChecked() (banks []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListGPIOBanks", func() {
+		banks, err = dev.device.ListGPIOBanksChecked()
+	}); rejected != nil {
+		return banks, rejected
+	}
+
+	return banks, err
+}
+
+// WriteGPIO writes the value of a GPIO bank.
+func (dev *SyncDevice) WriteGPIO(bank string, value uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteGPIO", func() {
+		err = dev.device.WriteGPIO(bank, value)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// WriteGPIOMasked writes the value of a GPIO bank with modification mask.
+func (dev *SyncDevice) WriteGPIOMasked(bank string, value uint32, mask uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteGPIOMasked", func() {
+		err = dev.device.WriteGPIOMasked(bank, value, mask)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadGPIO reads the value of a GPIO bank.
+func (dev *SyncDevice) ReadGPIO(bank string) (value uint32) {
+
+	dev.do("ReadGPIO", func() {
+		value = dev.device.ReadGPIO(bank)
+	})
+
+	return value
+}
+
+// ReadGPIOChecked reads the value of a GPIO bank, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadGPIOChecked(bank string) (value uint32, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadGPIO", func() {
+		value, err = dev.device.ReadGPIOChecked(bank)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// WriteGPIODir writes the data direction of a GPIO bank.
+func (dev *SyncDevice) WriteGPIODir(bank string, dir uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteGPIODir", func() {
+		err = dev.device.WriteGPIODir(bank, dir)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// WriteGPIODirMasked writes the data direction of a GPIO bank with modification mask.
+func (dev *SyncDevice) WriteGPIODirMasked(bank string, dir uint32, mask uint32) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteGPIODirMasked", func() {
+		err = dev.device.WriteGPIODirMasked(bank, dir, mask)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadGPIODir read the data direction of a GPIO bank.
+func (dev *SyncDevice) ReadGPIODir(bank string) (dir uint32) {
+
+	dev.do("ReadGPIODir", func() {
+		dir = dev.device.ReadGPIODir(bank)
+	})
+
+	return dir
+}
+
+// ReadGPIODirChecked read the data direction of a GPIO bank, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadGPIODirChecked(bank string) (dir uint32, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadGPIODir", func() {
+		dir, err = dev.device.ReadGPIODirChecked(bank)
+	}); rejected != nil {
+		return dir, rejected
+	}
+
+	return dir, err
+}
+
+// WriteI2C writes to an available I2C slave.
+func (dev *SyncDevice) WriteI2C(addr int32, data []uint8) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteI2C", func() {
+		err = dev.device.WriteI2C(addr, data)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadI2C reads from an available I2C slave.
+func (dev *SyncDevice) ReadI2C(addr int32, numBytes uint) (data []uint8) {
+
+	dev.do("ReadI2C", func() {
+		data = dev.device.ReadI2C(addr, numBytes)
+	})
+
+	return data
+}
+
+// ReadI2CChecked reads from an available I2C slave, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadI2CChecked(addr int32, numBytes uint) (data []uint8, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadI2C", func() {
+		data, err = dev.device.ReadI2CChecked(addr, numBytes)
+	}); rejected != nil {
+		return data, rejected
+	}
+
+	return data, err
+}
+
+// TransactSPI performs a SPI transaction and return the result.
+func (dev *SyncDevice) TransactSPI(addr int32, data uint32, numBits uint32) (value uint32) {
+
+	dev.do("TransactSPI", func() {
+		value = dev.device.TransactSPI(addr, data, numBits)
+	})
+
+	return value
+}
+
+// TransactSPIChecked performs a SPI transaction, returning the failure reported by the driver.
+func (dev *SyncDevice) TransactSPIChecked(addr int32, data uint32, numBits uint32) (value uint32, err sdrerror.SDRError) {
+
+	if rejected := dev.do("TransactSPI", func() {
+		value, err = dev.device.TransactSPIChecked(addr, data, numBits)
+	}); rejected != nil {
+		return value, rejected
+	}
+
+	return value, err
+}
+
+// ListUARTs enumerate the available UART devices.
+func (dev *SyncDevice) ListUARTs() (uarts []string) {
+
+	dev.do("ListUARTs", func() {
+		uarts = dev.device.ListUARTs()
+	})
+
+	return uarts
+}
+
+// ListUARTsChecked enumerate the available UART devices, returning the failure reported by the driver.
+func (dev *SyncDevice) ListUARTsChecked() (uarts []string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ListUARTs", func() {
+		uarts, err = dev.device.ListUARTsChecked()
+	}); rejected != nil {
+		return uarts, rejected
+	}
+
+	return uarts, err
+}
+
+// WriteUART writes data to a UART device.
+func (dev *SyncDevice) WriteUART(which string, data string) (err sdrerror.SDRError) {
+
+	if rejected := dev.do("WriteUART", func() {
+		err = dev.device.WriteUART(which, data)
+	}); rejected != nil {
+		return rejected
+	}
+
+	return err
+}
+
+// ReadUART read bytes from a UART until timeout or newline.
+func (dev *SyncDevice) ReadUART(which string, timeoutUs uint) (data string) {
+
+	dev.do("ReadUART", func() {
+		data = dev.device.ReadUART(which, timeoutUs)
+	})
+
+	return data
+}
+
+// ReadUARTChecked read bytes from a UART until timeout or newline, returning the failure reported by the driver.
+func (dev *SyncDevice) ReadUARTChecked(which string, timeoutUs uint) (data string, err sdrerror.SDRError) {
+
+	if rejected := dev.do("ReadUART", func() {
+		data, err = dev.device.ReadUARTChecked(which, timeoutUs)
+	}); rejected != nil {
+		return data, rejected
+	}
+
+	return data, err
+}
@@ -0,0 +1,37 @@
+package device_test
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+func TestSyncDeviceDedicatedThread(t *testing.T) {
+
+	dev := device.NewSyncDevice(sim.New(sim.Config{}), true)
+	defer dev.Unmake()
+
+	// The calls made from several goroutines all run on the same OS thread
+	threads := make(chan int, 16)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(threads); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dev.Do(func(device.Device) { threads <- syscall.Gettid() }); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(threads)
+
+	first := <-threads
+	for thread := range threads {
+		if thread != first {
+			t.Errorf("calls made from the threads %v and %v", first, thread)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package device_test
+
+import (
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncDeviceUnmake(t *testing.T) {
+
+	for _, dedicatedThread := range []bool{false, true} {
+
+		dev := device.NewSyncDevice(sim.New(sim.Config{}), dedicatedThread)
+		if err := dev.SetFrequency(device.DirectionRX, 0, 100e6, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := dev.Unmake(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := dev.GetFrequencyChecked(device.DirectionRX, 0); !errors.Is(err, sdrerror.ErrClosed) {
+			t.Errorf("GetFrequencyChecked after Unmake returned %v", err)
+		}
+		if err := dev.SetGain(device.DirectionRX, 0, 10); !errors.Is(err, sdrerror.ErrClosed) {
+			t.Errorf("SetGain after Unmake returned %v", err)
+		}
+		if _, err := dev.SetupSDRStreamCS16(device.DirectionRX, []uint{0}, nil); !errors.Is(err, sdrerror.ErrClosed) {
+			t.Errorf("SetupSDRStreamCS16 after Unmake returned %v", err)
+		}
+		if frequency := dev.GetFrequency(device.DirectionRX, 0); frequency != 0 {
+			t.Errorf("GetFrequency after Unmake returned %v", frequency)
+		}
+
+		called := false
+		if err := dev.Do(func(device.Device) { called = true }); !errors.Is(err, sdrerror.ErrClosed) || called {
+			t.Errorf("Do after Unmake returned %v and ran the function: %v", err, called)
+		}
+		if err := dev.Unmake(); !errors.Is(err, sdrerror.ErrClosed) {
+			t.Errorf("second Unmake returned %v", err)
+		}
+	}
+}
+
+// exclusiveDevice is a device checking that its gain is never accessed by concurrent calls
+type exclusiveDevice struct {
+	device.Device
+	active   int32
+	overlaps int32
+}
+
+// enter records the start of a call, and if another call is in progress
+func (dev *exclusiveDevice) enter() {
+
+	if atomic.AddInt32(&dev.active, 1) > 1 {
+		atomic.AddInt32(&dev.overlaps, 1)
+	}
+	// Leave time to the other goroutines to overlap
+	runtime.Gosched()
+}
+
+// leave records the end of a call
+func (dev *exclusiveDevice) leave() {
+
+	atomic.AddInt32(&dev.active, -1)
+}
+
+func (dev *exclusiveDevice) SetGain(direction device.Direction, channel uint, gain float64) (err sdrerror.SDRError) {
+
+	dev.enter()
+	defer dev.leave()
+
+	return dev.Device.SetGain(direction, channel, gain)
+}
+
+func (dev *exclusiveDevice) GetGainChecked(direction device.Direction, channel uint) (gain float64, err sdrerror.SDRError) {
+
+	dev.enter()
+	defer dev.leave()
+
+	return dev.Device.GetGainChecked(direction, channel)
+}
+
+func TestSyncDeviceConcurrentCalls(t *testing.T) {
+
+	for _, dedicatedThread := range []bool{false, true} {
+
+		wrapped := &exclusiveDevice{Device: sim.New(sim.Config{})}
+		dev := device.NewSyncDevice(wrapped, dedicatedThread)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(gain float64) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if err := dev.SetGain(device.DirectionRX, 0, gain); err != nil {
+						t.Error(err)
+						return
+					}
+					if _, err := dev.GetGainChecked(device.DirectionRX, 0); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(float64(i))
+		}
+		wg.Wait()
+
+		if overlaps := atomic.LoadInt32(&wrapped.overlaps); overlaps != 0 {
+			t.Errorf("dedicated thread %v: %v concurrent calls on the wrapped device", dedicatedThread, overlaps)
+		}
+		if err := dev.Unmake(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSyncDevicePanic(t *testing.T) {
+
+	// A panic on the dedicated thread is returned as an error, and the thread keeps running the next calls
+	dev := device.NewSyncDevice(sim.New(sim.Config{}), true)
+	defer dev.Unmake()
+
+	err := dev.Do(func(device.Device) { panic("driver failure") })
+	if !errors.Is(err, sdrerror.ErrUnknown) || !strings.Contains(err.Error(), "driver failure") {
+		t.Errorf("panicking call returned %v", err)
+	}
+	if err := dev.SetFrequency(device.DirectionRX, 0, 100e6, nil); err != nil {
+		t.Errorf("call after the panic returned %v", err)
+	}
+
+	// Without dedicated thread, the panic reaches the caller and the device stays usable
+	direct := device.NewSyncDevice(sim.New(sim.Config{}), false)
+	defer direct.Unmake()
+
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != "driver failure" {
+				t.Errorf("the call panicked with %v", recovered)
+			}
+		}()
+		_ = direct.Do(func(device.Device) { panic("driver failure") })
+	}()
+	if err := direct.SetFrequency(device.DirectionRX, 0, 100e6, nil); err != nil {
+		t.Errorf("call after the panic returned %v", err)
+	}
+}
+
+func TestSyncDeviceUnmakeRace(t *testing.T) {
+
+	for _, dedicatedThread := range []bool{false, true} {
+
+		dev := device.NewSyncDevice(sim.New(sim.Config{}), dedicatedThread)
+
+		// The calls in progress complete, the calls after Unmake are rejected
+		var wg sync.WaitGroup
+		started := make(chan struct{})
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; ; j++ {
+					if j == 10 && i == 0 {
+						close(started)
+					}
+					_, err := dev.GetFrequencyChecked(device.DirectionRX, 0)
+					if errors.Is(err, sdrerror.ErrClosed) {
+						return
+					}
+					if err != nil {
+						t.Errorf("call during Unmake returned %v", err)
+						return
+					}
+				}
+			}(i)
+		}
+
+		<-started
+		if err := dev.Unmake(); err != nil {
+			t.Fatal(err)
+		}
+		wg.Wait()
+	}
+}
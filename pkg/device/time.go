@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -18,12 +19,28 @@ import (
 // Return a list of time source names
 func (dev *SDRDevice) ListTimeSources() []string {
 
+	sources, _ := dev.ListTimeSourcesChecked()
+
+	return sources
+}
+
+// ListTimeSourcesChecked gets the list of available time sources, returning the failure reported by the driver. See
+// ListTimeSources() for the details.
+//
+// Return a list of time source names and an error if the call failed
+func (dev *SDRDevice) ListTimeSourcesChecked() (sources []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListTimeSources", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listTimeSources(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listTimeSources(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListTimeSources")
 }
 
 // SetTimeSource set the time source on the device.
@@ -34,10 +51,17 @@ func (dev *SDRDevice) ListTimeSources() []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetTimeSource(source string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetTimeSource", "")
+	}
+
 	cSource := C.CString(source)
 	defer C.free(unsafe.Pointer(cSource))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setTimeSource(dev.device, cSource)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setTimeSource(dev.device, cSource, &status)
+
+	return callError(&status, "SetTimeSource")
 }
 
 // GetTimeSource gets the time source of the device.
@@ -45,10 +69,26 @@ func (dev *SDRDevice) SetTimeSource(source string) (err sdrerror.SDRError) {
 // Return the name of a time source
 func (dev *SDRDevice) GetTimeSource() string {
 
-	val := (*C.char)(C.SoapySDRDevice_getTimeSource(dev.device))
+	source, _ := dev.GetTimeSourceChecked()
+
+	return source
+}
+
+// GetTimeSourceChecked gets the time source of the device, returning the failure reported by the driver. See
+// GetTimeSource() for the details.
+//
+// Return the name of a time source and an error if the call failed
+func (dev *SDRDevice) GetTimeSourceChecked() (source string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "GetTimeSource", "")
+	}
+
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_getTimeSource(dev.device, &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "GetTimeSource")
 }
 
 // HasHardwareTime checks if the device have a hardware clock
@@ -59,10 +99,28 @@ func (dev *SDRDevice) GetTimeSource() string {
 // Return true if the hardware clock exists
 func (dev *SDRDevice) HasHardwareTime(what string) bool {
 
+	supported, _ := dev.HasHardwareTimeChecked(what)
+
+	return supported
+}
+
+// HasHardwareTimeChecked checks if the device have a hardware clock, returning the failure reported by the driver. See
+// HasHardwareTime() for the details.
+//
+// Return true if the hardware clock exists and an error if the call failed
+func (dev *SDRDevice) HasHardwareTimeChecked(what string) (supported bool, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return false, rejectedError(sdrerror.ErrClosed, "HasHardwareTime", "")
+	}
+
 	cWhat := C.CString(what)
 	defer C.free(unsafe.Pointer(cWhat))
 
-	return bool(C.SoapySDRDevice_hasHardwareTime(dev.device, cWhat))
+	var status C.SoapySDRGoStatus
+	supported = bool(C.SoapySDRGo_hasHardwareTime(dev.device, cWhat, &status))
+
+	return supported, callError(&status, "HasHardwareTime")
 }
 
 // GetHardwareTime reads the time from the hardware clock on the device.
@@ -73,10 +131,28 @@ func (dev *SDRDevice) HasHardwareTime(what string) bool {
 // Return the time in nanoseconds
 func (dev *SDRDevice) GetHardwareTime(what string) uint {
 
+	timeNs, _ := dev.GetHardwareTimeChecked(what)
+
+	return timeNs
+}
+
+// GetHardwareTimeChecked reads the time from the hardware clock on the device, returning the failure reported by the
+// driver. See GetHardwareTime() for the details.
+//
+// Return the time in nanoseconds and an error if the call failed
+func (dev *SDRDevice) GetHardwareTimeChecked(what string) (timeNs uint, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return 0, rejectedError(sdrerror.ErrClosed, "GetHardwareTime", "")
+	}
+
 	cWhat := C.CString(what)
 	defer C.free(unsafe.Pointer(cWhat))
 
-	return uint(C.SoapySDRDevice_getHardwareTime(dev.device, cWhat))
+	var status C.SoapySDRGoStatus
+	timeNs = uint(C.SoapySDRGo_getHardwareTime(dev.device, cWhat, &status))
+
+	return timeNs, callError(&status, "GetHardwareTime")
 }
 
 // SetHardwareTime writes the time to the hardware clock on the device.
@@ -88,10 +164,17 @@ func (dev *SDRDevice) GetHardwareTime(what string) uint {
 // Return an error or nil in case of success
 func (dev *SDRDevice) SetHardwareTime(timeNs uint, what string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "SetHardwareTime", "")
+	}
+
 	cWhat := C.CString(what)
 	defer C.free(unsafe.Pointer(cWhat))
 
 	cTimeNs := C.longlong(timeNs)
 
-	return sdrerror.Err(int(C.SoapySDRDevice_setHardwareTime(dev.device, cTimeNs, cWhat)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_setHardwareTime(dev.device, cTimeNs, cWhat, &status)
+
+	return callError(&status, "SetHardwareTime")
 }
@@ -7,6 +7,7 @@ package device
 // #include <SoapySDR/Device.h>
 // #include <SoapySDR/Formats.h>
 // #include <SoapySDR/Types.h>
+// #include "shims.h"
 import "C"
 import (
 	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
@@ -18,12 +19,28 @@ import (
 // Return a list of names of available UARTs
 func (dev *SDRDevice) ListUARTs() []string {
 
+	uarts, _ := dev.ListUARTsChecked()
+
+	return uarts
+}
+
+// ListUARTsChecked enumerate the available UART devices, returning the failure reported by the driver. See ListUARTs()
+// for the details.
+//
+// Return a list of names of available UARTs and an error if the call failed
+func (dev *SDRDevice) ListUARTsChecked() (uarts []string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return nil, rejectedError(sdrerror.ErrClosed, "ListUARTs", "")
+	}
+
 	length := C.size_t(0)
 
-	info := C.SoapySDRDevice_listUARTs(dev.device, &length)
+	var status C.SoapySDRGoStatus
+	info := C.SoapySDRGo_listUARTs(dev.device, &length, &status)
 	defer stringArrayClear(info, length)
 
-	return stringArray2Go(info, length)
+	return stringArray2Go(info, length), callError(&status, "ListUARTs")
 }
 
 // WriteUART writes data to a UART device.
@@ -37,13 +54,20 @@ func (dev *SDRDevice) ListUARTs() []string {
 // Return an error or nil in case of success
 func (dev *SDRDevice) WriteUART(which string, data string) (err sdrerror.SDRError) {
 
+	if dev.device == nil {
+		return rejectedError(sdrerror.ErrClosed, "WriteUART", "")
+	}
+
 	cWhich := C.CString(which)
 	defer C.free(unsafe.Pointer(cWhich))
 
 	cData := C.CString(data)
 	defer C.free(unsafe.Pointer(cData))
 
-	return sdrerror.Err(int(C.SoapySDRDevice_writeUART(dev.device, cWhich, cData)))
+	var status C.SoapySDRGoStatus
+	C.SoapySDRGo_writeUART(dev.device, cWhich, cData, &status)
+
+	return callError(&status, "WriteUART")
 }
 
 // ReadUART read bytes from a UART until timeout or newline.
@@ -54,14 +78,30 @@ func (dev *SDRDevice) WriteUART(which string, data string) (err sdrerror.SDRErro
 //  - which: the name of an available UART
 //  - timeoutUs: a timeout in microseconds
 //
-// Return an array of byte packed as a string fdr convenience
+// Return an array of byte packed as a string for convenience
 func (dev *SDRDevice) ReadUART(which string, timeoutUs uint) string {
 
+	data, _ := dev.ReadUARTChecked(which, timeoutUs)
+
+	return data
+}
+
+// ReadUARTChecked read bytes from a UART until timeout or newline, returning the failure reported by the driver. See
+// ReadUART() for the details.
+//
+// Return an array of byte packed as a string for convenience and an error if the call failed
+func (dev *SDRDevice) ReadUARTChecked(which string, timeoutUs uint) (data string, err sdrerror.SDRError) {
+
+	if dev.device == nil {
+		return "", rejectedError(sdrerror.ErrClosed, "ReadUART", "")
+	}
+
 	cWhich := C.CString(which)
 	defer C.free(unsafe.Pointer(cWhich))
 
-	val := (*C.char)(C.SoapySDRDevice_readUART(dev.device, cWhich, C.long(timeoutUs)))
+	var status C.SoapySDRGoStatus
+	val := (*C.char)(C.SoapySDRGo_readUART(dev.device, cWhich, C.long(timeoutUs), &status))
 	defer C.free(unsafe.Pointer(val))
 
-	return C.GoString(val)
+	return C.GoString(val), callError(&status, "ReadUART")
 }
@@ -0,0 +1,271 @@
+package rtltcp
+
+import (
+	"context"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"net"
+	"sync"
+	"time"
+)
+
+// clientDriverKey is the driver key reported by the clients
+const clientDriverKey = "rtl_tcp"
+
+// tunerGainElement is the name of the single gain element of the clients
+const tunerGainElement = "TUNER"
+
+// tunerInfo describes a type of tuner: its name, its gains in tenths of dB as listed by librtlsdr and its frequency
+// ranges
+type tunerInfo struct {
+	name        string
+	gains       []int
+	frequencies []device.SDRRange
+}
+
+// tuners describes the known types of tuner
+var tuners = map[TunerType]tunerInfo{
+	TunerE4000: {
+		name:        "E4000",
+		gains:       []int{-10, 15, 40, 65, 90, 115, 140, 165, 190, 215, 240, 290, 340, 420},
+		frequencies: []device.SDRRange{{Minimum: 52e6, Maximum: 2200e6}},
+	},
+	TunerFC0012: {
+		name:        "FC0012",
+		gains:       []int{-99, -40, 71, 179, 192},
+		frequencies: []device.SDRRange{{Minimum: 22e6, Maximum: 948.6e6}},
+	},
+	TunerFC0013: {
+		name:        "FC0013",
+		gains:       []int{-99, -73, -65, -63, -60, -58, -54, 58, 61, 63, 65, 67, 68, 70, 71, 179, 181, 182, 184, 186, 188, 191, 197},
+		frequencies: []device.SDRRange{{Minimum: 22e6, Maximum: 1100e6}},
+	},
+	TunerFC2580: {
+		name:        "FC2580",
+		frequencies: []device.SDRRange{{Minimum: 146e6, Maximum: 308e6}, {Minimum: 438e6, Maximum: 924e6}},
+	},
+	TunerR820T: {
+		name:        "R820T",
+		gains:       []int{0, 9, 14, 27, 37, 77, 87, 125, 144, 157, 166, 197, 207, 229, 254, 280, 297, 328, 338, 364, 372, 386, 402, 421, 434, 439, 445, 480, 496},
+		frequencies: []device.SDRRange{{Minimum: 24e6, Maximum: 1766e6}},
+	},
+	TunerR828D: {
+		name:        "R828D",
+		gains:       []int{0, 9, 14, 27, 37, 77, 87, 125, 144, 157, 166, 197, 207, 229, 254, 280, 297, 328, 338, 364, 372, 386, 402, 421, 434, 439, 445, 480, 496},
+		frequencies: []device.SDRRange{{Minimum: 24e6, Maximum: 1766e6}},
+	},
+}
+
+// sampleRateRanges are the sample rates accepted by the RTL2832
+var sampleRateRanges = []device.SDRRange{{Minimum: 225001, Maximum: 300000}, {Minimum: 900001, Maximum: 3200000}}
+
+// Client is a remote dongle reached through an rtl_tcp server. It implements the identification, frequency, gain,
+// sample rate and stream functions of the device API, so that code written against these interfaces of the device
+// package can use a remote dongle. The client has a single receive channel, 0, streamed in CU8 format.
+//
+// The rtl_tcp protocol only carries commands to the server: the getters return the values last set through the
+// client, and the failures of the commands on the server are not reported. The ranges of gains and frequencies are
+// the ones of the type of tuner reported by the server, when it is known.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	conn   net.Conn
+	header Header
+	tuner  tunerInfo
+
+	mutex      sync.Mutex
+	closed     bool
+	frequency  float64
+	sampleRate float64
+	gain       float64
+	gainMode   bool
+	stream     *clientStream
+}
+
+// Ensure Client implements the device API it supports
+var _ device.IdentificationAPI = (*Client)(nil)
+var _ device.StreamAPI = (*Client)(nil)
+var _ device.GainAPI = (*Client)(nil)
+var _ device.FrequencyAPI = (*Client)(nil)
+var _ device.SampleRateAPI = (*Client)(nil)
+
+// Dial connects to an rtl_tcp server.
+//
+// Params:
+//  - ctx: the context of the connection, bounding the connection and the reception of the header
+//  - address: the TCP address of the server, such as "192.168.1.10:1234"
+//
+// Return the client or an error
+func Dial(ctx context.Context, address string) (*Client, error) {
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, found := ctx.Deadline(); found {
+		_ = conn.SetReadDeadline(deadline)
+	}
+
+	client, err := NewClient(conn)
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	return client, nil
+}
+
+// NewClient creates a client over an established connection to an rtl_tcp server, such as one end of a net.Pipe()
+// served by Server.ServeConn(). The header sent by the server is read.
+//
+// Params:
+//  - conn: the connection. It is closed by Unmake(), or by NewClient if the header can not be read.
+//
+// Return the client or an error
+func NewClient(conn net.Conn) (*Client, error) {
+
+	header, err := ReadHeader(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	tuner, found := tuners[header.TunerType]
+	if !found {
+		tuner.name = "unknown"
+	}
+
+	return &Client{conn: conn, header: header, tuner: tuner}, nil
+}
+
+// Header returns the header sent by the server
+func (client *Client) Header() Header {
+
+	return client.header
+}
+
+// Unmake closes the connection to the server.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the client is already closed
+func (client *Client) Unmake() (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", "Unmake", "", -1)
+	}
+	client.closed = true
+
+	if err := client.conn.Close(); err != nil {
+		return sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), err.Error(), "Unmake", "", -1)
+	}
+
+	return nil
+}
+
+// checkChannel returns the error reported by a call on a channel which does not exist or on a closed client, or nil
+// if the channel exists. The mutex of the client must be held.
+//
+// Params:
+//  - op: the name of the call
+//  - direction: the channel direction RX or TX
+//  - channel: the channel
+//
+// Return the error or nil
+func (client *Client) checkChannel(op string, direction device.Direction, channel uint) sdrerror.SDRError {
+
+	switch {
+	case client.closed:
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", op, direction.String(), int(channel))
+	case direction != device.DirectionRX || channel != 0:
+		return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("no %v channel %v", direction, channel), op, direction.String(), int(channel))
+	}
+
+	return nil
+}
+
+// checkName returns the error reported by a call on a channel which does not exist, or naming an element other than
+// the given one. The mutex of the client must be held.
+//
+// Params:
+//  - op: the name of the call
+//  - direction: the channel direction RX or TX
+//  - channel: the channel
+//  - expected: the name of the element of the channel
+//  - name: the name given to the call
+//
+// Return the error or nil
+func (client *Client) checkName(op string, direction device.Direction, channel uint, expected string, name string) sdrerror.SDRError {
+
+	if err := client.checkChannel(op, direction, channel); err != nil {
+		return err
+	}
+
+	if name != expected {
+		return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("unknown name %q", name), op, direction.String(), int(channel))
+	}
+
+	return nil
+}
+
+// send sends a command to the server. The mutex of the client must be held.
+//
+// Params:
+//  - op: the name of the call sending the command
+//  - command: the command
+//
+// Return an error or nil in case of success
+func (client *Client) send(op string, command Command) sdrerror.SDRError {
+
+	if err := WriteCommand(client.conn, command); err != nil {
+		return sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), err.Error(), op, device.DirectionRX.String(), 0)
+	}
+
+	return nil
+}
+
+// GetDriverKey returns "rtl_tcp"
+func (client *Client) GetDriverKey() (driverKey string) {
+
+	return clientDriverKey
+}
+
+// GetDriverKeyChecked returns "rtl_tcp". See GetDriverKey() for the details.
+func (client *Client) GetDriverKeyChecked() (driverKey string, err sdrerror.SDRError) {
+
+	return clientDriverKey, nil
+}
+
+// GetHardwareKey returns the name of the type of tuner reported by the server, such as "R820T"
+func (client *Client) GetHardwareKey() (hardwareKey string) {
+
+	return client.tuner.name
+}
+
+// GetHardwareKeyChecked returns the name of the type of tuner reported by the server. See GetHardwareKey() for the
+// details.
+func (client *Client) GetHardwareKeyChecked() (hardwareKey string, err sdrerror.SDRError) {
+
+	return client.tuner.name, nil
+}
+
+// GetHardwareInfo returns the address of the server and the header it sent
+func (client *Client) GetHardwareInfo() (hardwareInfo map[string]string) {
+
+	return map[string]string{
+		"remote":     client.conn.RemoteAddr().String(),
+		"tuner_type": fmt.Sprint(uint32(client.header.TunerType)),
+		"gain_count": fmt.Sprint(client.header.GainCount),
+	}
+}
+
+// GetHardwareInfoChecked returns the address of the server and the header it sent. See GetHardwareInfo() for the
+// details.
+func (client *Client) GetHardwareInfoChecked() (hardwareInfo map[string]string, err sdrerror.SDRError) {
+
+	return client.GetHardwareInfo(), nil
+}
@@ -0,0 +1,185 @@
+package rtltcp
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetFrequency tunes the receive channel to the given frequency, rounded to the Hz.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//  - frequency: the center frequency in Hz
+//  - args: ignored
+//
+// Return an error or nil in case of success
+func (client *Client) SetFrequency(direction device.Direction, channel uint, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("SetFrequency", direction, channel); err != nil {
+		return err
+	}
+	if frequency < 0 || frequency > float64(^uint32(0)) {
+		return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("frequency %v out of range", frequency), "SetFrequency", direction.String(), int(channel))
+	}
+
+	if err := client.send("SetFrequency", Command{Opcode: OpcodeSetFrequency, Param: uint32(frequency + 0.5)}); err != nil {
+		return err
+	}
+	client.frequency = float64(uint32(frequency + 0.5))
+
+	return nil
+}
+
+// SetFrequencyComponent tunes the "RF" element of the receive channel, the only element. See SetFrequency() for the
+// details.
+func (client *Client) SetFrequencyComponent(direction device.Direction, channel uint, name string, frequency float64, args map[string]string) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("SetFrequencyComponent", direction, channel, "RF", name)
+	client.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return client.SetFrequency(direction, channel, frequency, args)
+}
+
+// GetFrequency returns the frequency last set, 0 if none was set.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//
+// Return the center frequency in Hz
+func (client *Client) GetFrequency(direction device.Direction, channel uint) float64 {
+
+	frequency, _ := client.GetFrequencyChecked(direction, channel)
+
+	return frequency
+}
+
+// GetFrequencyChecked returns the frequency last set, reporting an invalid channel. See GetFrequency() for the details.
+func (client *Client) GetFrequencyChecked(direction device.Direction, channel uint) (frequency float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetFrequency", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return client.frequency, nil
+}
+
+// GetFrequencyComponent returns the frequency last set of the "RF" element. See GetFrequency() for the details.
+func (client *Client) GetFrequencyComponent(direction device.Direction, channel uint, name string) float64 {
+
+	frequency, _ := client.GetFrequencyComponentChecked(direction, channel, name)
+
+	return frequency
+}
+
+// GetFrequencyComponentChecked returns the frequency last set of the "RF" element, reporting an invalid channel or
+// name. See GetFrequency() for the details.
+func (client *Client) GetFrequencyComponentChecked(direction device.Direction, channel uint, name string) (frequency float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("GetFrequencyComponent", direction, channel, "RF", name)
+	client.mutex.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return client.GetFrequencyChecked(direction, channel)
+}
+
+// ListFrequencies lists the tunable elements of the channel: "RF".
+func (client *Client) ListFrequencies(direction device.Direction, channel uint) []string {
+
+	names, _ := client.ListFrequenciesChecked(direction, channel)
+
+	return names
+}
+
+// ListFrequenciesChecked lists the tunable elements of the channel, reporting an invalid channel. See
+// ListFrequencies() for the details.
+func (client *Client) ListFrequenciesChecked(direction device.Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("ListFrequencies", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return []string{"RF"}, nil
+}
+
+// GetFrequencyRange returns the frequency ranges of the tuner reported by the server, none if the tuner is unknown.
+func (client *Client) GetFrequencyRange(direction device.Direction, channel uint) []device.SDRRange {
+
+	ranges, _ := client.GetFrequencyRangeChecked(direction, channel)
+
+	return ranges
+}
+
+// GetFrequencyRangeChecked returns the frequency ranges of the tuner, reporting an invalid channel. See
+// GetFrequencyRange() for the details.
+func (client *Client) GetFrequencyRangeChecked(direction device.Direction, channel uint) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetFrequencyRange", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return append([]device.SDRRange{}, client.tuner.frequencies...), nil
+}
+
+// GetFrequencyRangeComponent returns the frequency ranges of the "RF" element. See GetFrequencyRange() for the details.
+func (client *Client) GetFrequencyRangeComponent(direction device.Direction, channel uint, name string) []device.SDRRange {
+
+	ranges, _ := client.GetFrequencyRangeComponentChecked(direction, channel, name)
+
+	return ranges
+}
+
+// GetFrequencyRangeComponentChecked returns the frequency ranges of the "RF" element, reporting an invalid channel or
+// name. See GetFrequencyRange() for the details.
+func (client *Client) GetFrequencyRangeComponentChecked(direction device.Direction, channel uint, name string) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("GetFrequencyRangeComponent", direction, channel, "RF", name)
+	client.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetFrequencyRangeChecked(direction, channel)
+}
+
+// GetFrequencyArgsInfo returns no argument: the tuning has no argument.
+func (client *Client) GetFrequencyArgsInfo(direction device.Direction, channel uint) []device.SDRArgInfo {
+
+	return []device.SDRArgInfo{}
+}
+
+// GetFrequencyArgsInfoChecked returns no argument, reporting an invalid channel. See GetFrequencyArgsInfo() for the
+// details.
+func (client *Client) GetFrequencyArgsInfoChecked(direction device.Direction, channel uint) (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetFrequencyArgsInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return []device.SDRArgInfo{}, nil
+}
@@ -0,0 +1,243 @@
+package rtltcp
+
+import (
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"sort"
+)
+
+// ListGains lists the amplification elements of the channel: "TUNER".
+func (client *Client) ListGains(direction device.Direction, channel uint) []string {
+
+	names, _ := client.ListGainsChecked(direction, channel)
+
+	return names
+}
+
+// ListGainsChecked lists the amplification elements of the channel, reporting an invalid channel. See ListGains() for
+// the details.
+func (client *Client) ListGainsChecked(direction device.Direction, channel uint) (names []string, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("ListGains", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return []string{tunerGainElement}, nil
+}
+
+// HasGainMode returns true: the tuner has an automatic gain mode.
+func (client *Client) HasGainMode(direction device.Direction, channel uint) bool {
+
+	supported, _ := client.HasGainModeChecked(direction, channel)
+
+	return supported
+}
+
+// HasGainModeChecked returns true, reporting an invalid channel. See HasGainMode() for the details.
+func (client *Client) HasGainModeChecked(direction device.Direction, channel uint) (supported bool, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("HasGainMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SetGainMode selects the automatic or the manual gain mode of the tuner.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//  - automatic: true for the automatic gain mode
+//
+// Return an error or nil in case of success
+func (client *Client) SetGainMode(direction device.Direction, channel uint, automatic bool) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("SetGainMode", direction, channel); err != nil {
+		return err
+	}
+
+	command := Command{Opcode: OpcodeSetGainMode, Param: 1}
+	if automatic {
+		command.Param = 0
+	}
+	if err := client.send("SetGainMode", command); err != nil {
+		return err
+	}
+	client.gainMode = automatic
+
+	return nil
+}
+
+// GetGainMode returns the gain mode last set, false (manual) if none was set.
+func (client *Client) GetGainMode(direction device.Direction, channel uint) bool {
+
+	automatic, _ := client.GetGainModeChecked(direction, channel)
+
+	return automatic
+}
+
+// GetGainModeChecked returns the gain mode last set, reporting an invalid channel. See GetGainMode() for the details.
+func (client *Client) GetGainModeChecked(direction device.Direction, channel uint) (automatic bool, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetGainMode", direction, channel); err != nil {
+		return false, err
+	}
+
+	return client.gainMode, nil
+}
+
+// SetGain sets the gain of the tuner, rounded to the tenth of dB. The gain applies in manual gain mode only.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//  - gain: the gain in dB
+//
+// Return an error or nil in case of success
+func (client *Client) SetGain(direction device.Direction, channel uint, gain float64) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("SetGain", direction, channel); err != nil {
+		return err
+	}
+
+	tenths := int32(gain*10 + 0.5)
+	if gain < 0 {
+		tenths = int32(gain*10 - 0.5)
+	}
+	if err := client.send("SetGain", Command{Opcode: OpcodeSetGain, Param: uint32(tenths)}); err != nil {
+		return err
+	}
+	client.gain = float64(tenths) / 10
+
+	return nil
+}
+
+// SetGainElement sets the gain of the "TUNER" element. See SetGain() for the details.
+func (client *Client) SetGainElement(direction device.Direction, channel uint, name string, gain float64) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("SetGainElement", direction, channel, tunerGainElement, name)
+	client.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return client.SetGain(direction, channel, gain)
+}
+
+// GetGain returns the gain last set, 0 if none was set.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//
+// Return the gain in dB
+func (client *Client) GetGain(direction device.Direction, channel uint) float64 {
+
+	gain, _ := client.GetGainChecked(direction, channel)
+
+	return gain
+}
+
+// GetGainChecked returns the gain last set, reporting an invalid channel. See GetGain() for the details.
+func (client *Client) GetGainChecked(direction device.Direction, channel uint) (gain float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetGain", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return client.gain, nil
+}
+
+// GetGainElement returns the gain last set of the "TUNER" element. See GetGain() for the details.
+func (client *Client) GetGainElement(direction device.Direction, channel uint, name string) float64 {
+
+	gain, _ := client.GetGainElementChecked(direction, channel, name)
+
+	return gain
+}
+
+// GetGainElementChecked returns the gain last set of the "TUNER" element, reporting an invalid channel or name. See
+// GetGain() for the details.
+func (client *Client) GetGainElementChecked(direction device.Direction, channel uint, name string) (gain float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("GetGainElement", direction, channel, tunerGainElement, name)
+	client.mutex.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	return client.GetGainChecked(direction, channel)
+}
+
+// GetGainRange returns the range of the gains of the tuner reported by the server, an empty range if the tuner is
+// unknown.
+func (client *Client) GetGainRange(direction device.Direction, channel uint) device.SDRRange {
+
+	gainRange, _ := client.GetGainRangeChecked(direction, channel)
+
+	return gainRange
+}
+
+// GetGainRangeChecked returns the range of the gains of the tuner, reporting an invalid channel. See GetGainRange()
+// for the details.
+func (client *Client) GetGainRangeChecked(direction device.Direction, channel uint) (gainRange device.SDRRange, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetGainRange", direction, channel); err != nil {
+		return gainRange, err
+	}
+
+	if len(client.tuner.gains) == 0 {
+		return gainRange, nil
+	}
+
+	gains := append([]int(nil), client.tuner.gains...)
+	sort.Ints(gains)
+
+	return device.SDRRange{Minimum: float64(gains[0]) / 10, Maximum: float64(gains[len(gains)-1]) / 10}, nil
+}
+
+// GetGainElementRange returns the range of the gains of the "TUNER" element. See GetGainRange() for the details.
+func (client *Client) GetGainElementRange(direction device.Direction, channel uint, name string) device.SDRRange {
+
+	gainRange, _ := client.GetGainElementRangeChecked(direction, channel, name)
+
+	return gainRange
+}
+
+// GetGainElementRangeChecked returns the range of the gains of the "TUNER" element, reporting an invalid channel or
+// name. See GetGainRange() for the details.
+func (client *Client) GetGainElementRangeChecked(direction device.Direction, channel uint, name string) (gainRange device.SDRRange, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	err = client.checkName("GetGainElementRange", direction, channel, tunerGainElement, name)
+	client.mutex.Unlock()
+	if err != nil {
+		return gainRange, err
+	}
+
+	return client.GetGainRangeChecked(direction, channel)
+}
@@ -0,0 +1,85 @@
+package rtltcp
+
+import (
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+)
+
+// SetSampleRate sets the sample rate of the receive channel, rounded to the sample per second.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//  - rate: the sample rate in samples per second
+//
+// Return an error or nil in case of success
+func (client *Client) SetSampleRate(direction device.Direction, channel uint, rate float64) (err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("SetSampleRate", direction, channel); err != nil {
+		return err
+	}
+	if rate <= 0 || rate > float64(^uint32(0)) {
+		return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), fmt.Sprintf("sample rate %v out of range", rate), "SetSampleRate", direction.String(), int(channel))
+	}
+
+	if err := client.send("SetSampleRate", Command{Opcode: OpcodeSetSampleRate, Param: uint32(rate + 0.5)}); err != nil {
+		return err
+	}
+	client.sampleRate = float64(uint32(rate + 0.5))
+
+	return nil
+}
+
+// GetSampleRate returns the sample rate last set, 0 if none was set.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channel: the channel, 0
+//
+// Return the sample rate in samples per second
+func (client *Client) GetSampleRate(direction device.Direction, channel uint) float64 {
+
+	rate, _ := client.GetSampleRateChecked(direction, channel)
+
+	return rate
+}
+
+// GetSampleRateChecked returns the sample rate last set, reporting an invalid channel. See GetSampleRate() for the
+// details.
+func (client *Client) GetSampleRateChecked(direction device.Direction, channel uint) (rate float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetSampleRate", direction, channel); err != nil {
+		return 0, err
+	}
+
+	return client.sampleRate, nil
+}
+
+// GetSampleRateRange returns the ranges of the sample rates accepted by the RTL2832.
+func (client *Client) GetSampleRateRange(direction device.Direction, channel uint) []device.SDRRange {
+
+	ranges, _ := client.GetSampleRateRangeChecked(direction, channel)
+
+	return ranges
+}
+
+// GetSampleRateRangeChecked returns the ranges of the sample rates accepted by the RTL2832, reporting an invalid
+// channel. See GetSampleRateRange() for the details.
+func (client *Client) GetSampleRateRangeChecked(direction device.Direction, channel uint) (ranges []device.SDRRange, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetSampleRateRange", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return append([]device.SDRRange{}, sampleRateRanges...), nil
+}
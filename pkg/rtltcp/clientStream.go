@@ -0,0 +1,401 @@
+package rtltcp
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// clientMTU is the MTU of the streams of the clients in number of elements
+const clientMTU = 16384
+
+// clientStream is the CU8 receive stream of a client, reading the samples sent by the server. The samples carry no
+// timestamp.
+type clientStream struct {
+	client *Client
+
+	mutex  sync.Mutex
+	closed bool
+	active bool
+
+	// readMutex serialises the reads, which share the connection and the odd byte left by the previous read
+	readMutex   sync.Mutex
+	leftover    byte
+	hasLeftover bool
+}
+
+// Ensure clientStream implements device.TypedStream
+var _ device.TypedStream[uint8] = (*clientStream)(nil)
+
+// GetStreamFormats returns the format of the streams of the client: CU8.
+func (client *Client) GetStreamFormats(direction device.Direction, channel uint) []string {
+
+	formats, _ := client.GetStreamFormatsChecked(direction, channel)
+
+	return formats
+}
+
+// GetStreamFormatsChecked returns the format of the streams of the client, reporting an invalid channel. See
+// GetStreamFormats() for the details.
+func (client *Client) GetStreamFormatsChecked(direction device.Direction, channel uint) (formats []string, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetStreamFormats", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return []string{device.FormatCU8}, nil
+}
+
+// GetNativeStreamFormat returns the native format of the streams of the client: CU8 with a full scale of 128.
+func (client *Client) GetNativeStreamFormat(direction device.Direction, channel uint) (format string, fullScale float64) {
+
+	format, fullScale, _ = client.GetNativeStreamFormatChecked(direction, channel)
+
+	return format, fullScale
+}
+
+// GetNativeStreamFormatChecked returns the native format of the streams of the client, reporting an invalid channel.
+// See GetNativeStreamFormat() for the details.
+func (client *Client) GetNativeStreamFormatChecked(direction device.Direction, channel uint) (format string, fullScale float64, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetNativeStreamFormat", direction, channel); err != nil {
+		return "", 0, err
+	}
+
+	return device.FormatCU8, cu8FullScale, nil
+}
+
+// GetStreamArgsInfo returns no argument: the streams of the client have no argument.
+func (client *Client) GetStreamArgsInfo(direction device.Direction, channel uint) []device.SDRArgInfo {
+
+	return []device.SDRArgInfo{}
+}
+
+// GetStreamArgsInfoChecked returns no argument, reporting an invalid channel. See GetStreamArgsInfo() for the details.
+func (client *Client) GetStreamArgsInfoChecked(direction device.Direction, channel uint) (infos []device.SDRArgInfo, err sdrerror.SDRError) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if err := client.checkChannel("GetStreamArgsInfo", direction, channel); err != nil {
+		return nil, err
+	}
+
+	return []device.SDRArgInfo{}, nil
+}
+
+// SetupSDRStreamCU8 initializes the receive stream of the client, in CU8 format. A client has at most one stream
+// open at a time. The samples sent by the server before the stream is read are buffered by the connection: they are
+// returned by the first reads.
+//
+// Params:
+//  - direction: the channel direction, RX
+//  - channels: the channels, [0]
+//  - args: ignored
+//
+// Return the stream and an error
+func (client *Client) SetupSDRStreamCU8(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCU8, err error) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if len(channels) != 1 {
+		return nil, errors.New("the streams of an rtl_tcp client have a single channel")
+	}
+	if err := client.checkChannel("SetupStream", direction, channels[0]); err != nil {
+		return nil, err
+	}
+	if client.stream != nil {
+		return nil, errors.New("the stream of the rtl_tcp client is already set up")
+	}
+
+	client.stream = &clientStream{client: client}
+
+	return client.stream, nil
+}
+
+// SetupSDRStreamCS8 is not supported: the streams of the client are in CU8 format.
+func (client *Client) SetupSDRStreamCS8(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCS8, err error) {
+
+	return nil, notSupported("SetupStream", direction)
+}
+
+// SetupSDRStreamCU16 is not supported: the streams of the client are in CU8 format.
+func (client *Client) SetupSDRStreamCU16(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCU16, err error) {
+
+	return nil, notSupported("SetupStream", direction)
+}
+
+// SetupSDRStreamCS16 is not supported: the streams of the client are in CU8 format.
+func (client *Client) SetupSDRStreamCS16(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCS16, err error) {
+
+	return nil, notSupported("SetupStream", direction)
+}
+
+// SetupSDRStreamCF32 is not supported: the streams of the client are in CU8 format.
+func (client *Client) SetupSDRStreamCF32(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCF32, err error) {
+
+	return nil, notSupported("SetupStream", direction)
+}
+
+// SetupSDRStreamCF64 is not supported: the streams of the client are in CU8 format.
+func (client *Client) SetupSDRStreamCF64(direction device.Direction, channels []uint, args map[string]string) (stream device.TypedStreamCF64, err error) {
+
+	return nil, notSupported("SetupStream", direction)
+}
+
+// notSupported returns the error of the calls which are not supported by the clients
+func notSupported(op string, direction device.Direction) sdrerror.SDRError {
+
+	return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), "not supported by an rtl_tcp client", op, direction.String(), -1)
+}
+
+// GetNumChannels returns the number of channels of the stream: 1.
+func (s *clientStream) GetNumChannels() uint {
+
+	return 1
+}
+
+// GetFormat returns the format of the stream: CU8.
+func (s *clientStream) GetFormat() string {
+
+	return device.FormatCU8
+}
+
+// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
+func (s *clientStream) GetMTU() int {
+
+	return clientMTU
+}
+
+// Close closes the stream, so that a new stream can be set up on the client.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the stream is already closed
+func (s *clientStream) Close() (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Close", false); err != nil {
+		return err
+	}
+	s.closed = true
+	s.active = false
+
+	s.client.mutex.Lock()
+	s.client.stream = nil
+	s.client.mutex.Unlock()
+
+	return nil
+}
+
+// checkState rejects a call on a closed stream or, when the call requires an active stream, on a stream which is not
+// active. The mutex of the stream must be held.
+//
+// Params:
+//  - op: the name of the call
+//  - active: true if the call requires an active stream
+//
+// Return an error matching sdrerror.ErrClosed or sdrerror.ErrNotActive if the call is rejected
+func (s *clientStream) checkState(op string, active bool) sdrerror.SDRError {
+
+	switch {
+	case s.closed:
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", op, device.DirectionRX.String(), -1)
+	case active && !s.active:
+		return sdrerror.Wrap(sdrerror.ErrNotActive.SDRErrorCode(), "", op, device.DirectionRX.String(), -1)
+	}
+
+	return nil
+}
+
+// Activate activates the stream. The server streams continuously: the flags, the time and the number of elements are
+// ignored.
+//
+// Return an error or nil in case of success
+func (s *clientStream) Activate(flags device.StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Activate", false); err != nil {
+		return err
+	}
+	s.active = true
+
+	return nil
+}
+
+// Deactivate deactivates the stream. The server keeps streaming: the samples it sends are buffered by the connection
+// until the stream is read again.
+//
+// Return an error or nil in case of success
+func (s *clientStream) Deactivate(flags device.StreamFlag, timeNs int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Deactivate", false); err != nil {
+		return err
+	}
+	s.active = false
+
+	return nil
+}
+
+// Read reads the samples sent by the server, at most nbElems and the MTU of the stream.
+//
+// Params:
+//  - buffers: the buffer of the channel. See device.Stream.Read() for the details.
+//  - nbElems: the number of elements to read
+//  - outputFlags: the flag indicators of the result, always 0 as the samples carry no timestamp
+//  - timeoutUs: the timeout in microseconds
+//
+// Return 0 as timestamp, the number of elements read and an error
+func (s *clientStream) Read(buffers [][]uint8, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	if err := device.CheckBuffers(buffers, 1, nbElems, 2); err != nil {
+		return 0, 0, err
+	}
+	if len(outputFlags) != 1 {
+		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
+	}
+	outputFlags[0] = 0
+
+	s.mutex.Lock()
+	err = s.checkState("Read", true)
+	s.mutex.Unlock()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if nbElems > clientMTU {
+		nbElems = clientMTU
+	}
+	if nbElems == 0 {
+		return 0, 0, nil
+	}
+
+	s.readMutex.Lock()
+	defer s.readMutex.Unlock()
+
+	samples := buffers[0][:2*nbElems]
+	nbBytes := 0
+	if s.hasLeftover {
+		samples[0] = s.leftover
+		s.hasLeftover = false
+		nbBytes = 1
+	}
+
+	_ = s.client.conn.SetReadDeadline(time.Now().Add(time.Duration(timeoutUs) * time.Microsecond))
+	read, err := io.ReadAtLeast(s.client.conn, samples[nbBytes:], 2-nbBytes)
+	nbBytes += read
+
+	if nbBytes%2 == 1 {
+		s.leftover = samples[nbBytes-1]
+		s.hasLeftover = true
+		nbBytes--
+	}
+
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return 0, 0, sdrerror.Err(sdrerror.ErrTimeout.SDRErrorCode())
+		}
+		return 0, 0, sdrerror.Wrap(sdrerror.ErrStream.SDRErrorCode(), err.Error(), "Read", device.DirectionRX.String(), -1)
+	}
+
+	return 0, uint(nbBytes / 2), nil
+}
+
+// ReadBuffers reads the samples sent by the server, as many as fit in the given buffer. See Read() for the details.
+func (s *clientStream) ReadBuffers(buffers [][]uint8, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	return s.Read(buffers, device.BuffersNumElems(buffers, 2), outputFlags, timeoutUs)
+}
+
+// ReadContext reads the samples sent by the server until samples are received or the context is done. See
+// device.ReadContext() for the details.
+func (s *clientStream) ReadContext(ctx context.Context, buffers [][]uint8, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error) {
+
+	return device.ReadContext[uint8](ctx, s, buffers, nbElems, outputFlags)
+}
+
+// Write is not supported: rtl_tcp only receives.
+func (s *clientStream) Write(buffers [][]uint8, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+
+	return 0, notSupported("Write", device.DirectionRX)
+}
+
+// WriteBuffers is not supported: rtl_tcp only receives.
+func (s *clientStream) WriteBuffers(buffers [][]uint8, flags []int, timeNs uint, timeoutUs uint) (numElemsWritten uint, err error) {
+
+	return 0, notSupported("WriteBuffers", device.DirectionRX)
+}
+
+// WriteContext is not supported: rtl_tcp only receives.
+func (s *clientStream) WriteContext(ctx context.Context, buffers [][]uint8, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	return 0, notSupported("WriteContext", device.DirectionRX)
+}
+
+// TransmitBurst is not supported: rtl_tcp only receives.
+func (s *clientStream) TransmitBurst(ctx context.Context, samples [][]uint8, atTimeNs uint) (report device.BurstReport, err error) {
+
+	return report, notSupported("TransmitBurst", device.DirectionRX)
+}
+
+// ReadStreamStatus is not supported: rtl_tcp reports no status.
+func (s *clientStream) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
+
+	return 0, notSupported("ReadStreamStatus", device.DirectionRX)
+}
+
+// ReadStreamStatusContext is not supported: rtl_tcp reports no status.
+func (s *clientStream) ReadStreamStatusContext(ctx context.Context, chanMask []uint, flags []int) (timeNs uint, err error) {
+
+	return 0, notSupported("ReadStreamStatus", device.DirectionRX)
+}
+
+// GetNumDirectAccessBuffers returns 0: the streams of the clients do not support direct access.
+func (s *clientStream) GetNumDirectAccessBuffers() uint {
+
+	return 0
+}
+
+// GetDirectAccessBufferAddrs is not supported by the streams of the clients.
+func (s *clientStream) GetDirectAccessBufferAddrs(handle uint) (buffers [][]uint8, err error) {
+
+	return nil, notSupported("GetDirectAccessBufferAddrs", device.DirectionRX)
+}
+
+// AcquireReadBuffer is not supported by the streams of the clients.
+func (s *clientStream) AcquireReadBuffer(outputFlags []int, timeoutUs uint) (handle uint, buffers [][]uint8, timeNs uint, numElemsRead uint, err error) {
+
+	return 0, nil, 0, 0, notSupported("AcquireReadBuffer", device.DirectionRX)
+}
+
+// ReleaseReadBuffer does nothing as the streams of the clients do not support direct access.
+func (s *clientStream) ReleaseReadBuffer(handle uint) {
+}
+
+// AcquireWriteBuffer is not supported by the streams of the clients.
+func (s *clientStream) AcquireWriteBuffer(timeoutUs uint) (handle uint, buffers [][]uint8, numElems uint, err error) {
+
+	return 0, nil, 0, notSupported("AcquireWriteBuffer", device.DirectionRX)
+}
+
+// ReleaseWriteBuffer does nothing as the streams of the clients do not support direct access.
+func (s *clientStream) ReleaseWriteBuffer(handle uint, numElems uint, flags []int, timeNs uint) {
+}
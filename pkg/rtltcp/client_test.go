@@ -0,0 +1,126 @@
+package rtltcp_test
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/rtltcp"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"net"
+	"testing"
+	"time"
+)
+
+// startServer serves a simulated device with rtl_tcp on a local port until the end of the test
+func startServer(t *testing.T) (dev *sim.Device, address string) {
+
+	dev = simtest.NewDevice(t, sim.Config{NumRXChannels: 1, Realtime: true})
+
+	server, err := rtltcp.NewServer(dev, rtltcp.Config{TunerType: rtltcp.TunerR820T})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(ctx, listener)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return dev, listener.Addr().String()
+}
+
+// tune configures a receive channel through the device API
+func tune(dev interface {
+	device.FrequencyAPI
+	device.GainAPI
+	device.SampleRateAPI
+}) error {
+
+	if err := dev.SetSampleRate(device.DirectionRX, 0, 1e6); err != nil {
+		return err
+	}
+	if err := dev.SetFrequency(device.DirectionRX, 0, 100e6, nil); err != nil {
+		return err
+	}
+	if err := dev.SetGainMode(device.DirectionRX, 0, false); err != nil {
+		return err
+	}
+
+	return dev.SetGain(device.DirectionRX, 0, 19.7)
+}
+
+func TestClient(t *testing.T) {
+
+	dev, address := startServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := rtltcp.Dial(ctx, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header := client.Header(); header.TunerType != rtltcp.TunerR820T || header.GainCount == 0 {
+		t.Errorf("unexpected header %+v", header)
+	}
+	if gainRange := client.GetGainRange(device.DirectionRX, 0); gainRange.Minimum != 0 || gainRange.Maximum != 49.6 {
+		t.Errorf("unexpected gain range %v", gainRange.ToString())
+	}
+	if _, err := client.GetFrequencyChecked(device.DirectionRX, 1); !errors.Is(err, sdrerror.ErrNotSupported) {
+		t.Errorf("channel 1 accepted: %v", err)
+	}
+
+	if err := tune(client); err != nil {
+		t.Fatal(err)
+	}
+	if client.GetFrequency(device.DirectionRX, 0) != 100e6 || client.GetGain(device.DirectionRX, 0) != 19.7 {
+		t.Errorf("the client does not report the values set")
+	}
+
+	stream, err := client.SetupSDRStreamCU8(device.DirectionRX, []uint{0}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Activate(0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	buffers := [][]uint8{make([]uint8, 2*1000)}
+	_, numElemsRead, err := stream.ReadContext(ctx, buffers, 1000, make([]int, 1))
+	if err != nil || numElemsRead == 0 {
+		t.Fatalf("%v samples read: %v", numElemsRead, err)
+	}
+
+	for dev.GetFrequency(device.DirectionRX, 0) != 100e6 || dev.GetGain(device.DirectionRX, 0) != 19.7 {
+		if ctx.Err() != nil {
+			t.Fatalf("the commands were not applied: frequency %v, gain %v", dev.GetFrequency(device.DirectionRX, 0), dev.GetGain(device.DirectionRX, 0))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if dev.GetSampleRate(device.DirectionRX, 0) != 1e6 || dev.GetGainMode(device.DirectionRX, 0) {
+		t.Errorf("the commands were not applied: sample rate %v, automatic gain %v", dev.GetSampleRate(device.DirectionRX, 0), dev.GetGainMode(device.DirectionRX, 0))
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Error(err)
+	}
+	if err := client.Unmake(); err != nil {
+		t.Error(err)
+	}
+	if err := client.Unmake(); !errors.Is(err, sdrerror.ErrClosed) {
+		t.Errorf("second Unmake returned %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Package rtltcp implements the rtl_tcp protocol, the network protocol of the rtl_tcp server of librtlsdr spoken by
+// many SDR applications (SDR#, GQRX, dump1090...). Once connected, the server sends a 12-byte header describing the
+// dongle then streams the samples as interleaved unsigned 8-bit I and Q values (CU8), while the client sends 5-byte
+// commands: an opcode followed by a big-endian 32-bit parameter.
+package rtltcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Magic is the magic number starting the header sent by an rtl_tcp server
+const Magic = "RTL0"
+
+// HeaderSize is the size in bytes of the header sent by an rtl_tcp server
+const HeaderSize = 12
+
+// CommandSize is the size in bytes of a command sent by an rtl_tcp client
+const CommandSize = 5
+
+// TunerType is the type of tuner of a dongle, as reported in the header
+type TunerType uint32
+
+// Tuner types defined by librtlsdr
+const (
+	TunerUnknown TunerType = iota
+	TunerE4000
+	TunerFC0012
+	TunerFC0013
+	TunerFC2580
+	TunerR820T
+	TunerR828D
+)
+
+// Opcode is the code of a command sent by an rtl_tcp client
+type Opcode uint8
+
+// Opcodes defined by rtl_tcp
+const (
+	// OpcodeSetFrequency sets the center frequency in Hz
+	OpcodeSetFrequency Opcode = 0x01
+	// OpcodeSetSampleRate sets the sample rate in samples per second
+	OpcodeSetSampleRate Opcode = 0x02
+	// OpcodeSetGainMode selects the manual gain mode when the parameter is not 0, the automatic gain mode otherwise
+	OpcodeSetGainMode Opcode = 0x03
+	// OpcodeSetGain sets the gain in tenths of dB
+	OpcodeSetGain Opcode = 0x04
+	// OpcodeSetFrequencyCorrection sets the frequency correction in PPM, as a signed value
+	OpcodeSetFrequencyCorrection Opcode = 0x05
+	// OpcodeSetIFGain sets the gain of an IF stage: the stage in the 16 high bits, the gain in tenths of dB in the 16
+	// low bits
+	OpcodeSetIFGain Opcode = 0x06
+	// OpcodeSetTestMode enables the test mode of the RTL2832 when the parameter is not 0
+	OpcodeSetTestMode Opcode = 0x07
+	// OpcodeSetAGCMode enables the digital automatic gain control of the RTL2832 when the parameter is not 0. It is
+	// distinct from the gain mode of the tuner.
+	OpcodeSetAGCMode Opcode = 0x08
+	// OpcodeSetDirectSampling selects the direct sampling mode: 0 disabled, 1 on I, 2 on Q
+	OpcodeSetDirectSampling Opcode = 0x09
+	// OpcodeSetOffsetTuning enables the offset tuning when the parameter is not 0
+	OpcodeSetOffsetTuning Opcode = 0x0a
+	// OpcodeSetRTLCrystal sets the frequency of the crystal of the RTL2832 in Hz
+	OpcodeSetRTLCrystal Opcode = 0x0b
+	// OpcodeSetTunerCrystal sets the frequency of the crystal of the tuner in Hz
+	OpcodeSetTunerCrystal Opcode = 0x0c
+	// OpcodeSetGainByIndex sets the gain to the gain of the given index in the list of gains of the tuner
+	OpcodeSetGainByIndex Opcode = 0x0d
+	// OpcodeSetBiasTee enables the bias tee when the parameter is not 0
+	OpcodeSetBiasTee Opcode = 0x0e
+)
+
+// Header is the header sent by an rtl_tcp server when a client connects
+type Header struct {
+	// TunerType is the type of tuner of the dongle
+	TunerType TunerType
+	// GainCount is the number of gains of the tuner, the indexes accepted by OpcodeSetGainByIndex
+	GainCount uint32
+}
+
+// Command is a command sent by an rtl_tcp client
+type Command struct {
+	// Opcode is the code of the command
+	Opcode Opcode
+	// Param is the parameter of the command
+	Param uint32
+}
+
+// WriteHeader writes a header.
+//
+// Params:
+//  - writer: the destination of the header
+//  - header: the header
+//
+// Return an error or nil in case of success
+func WriteHeader(writer io.Writer, header Header) error {
+
+	var buffer [HeaderSize]byte
+	copy(buffer[:4], Magic)
+	binary.BigEndian.PutUint32(buffer[4:8], uint32(header.TunerType))
+	binary.BigEndian.PutUint32(buffer[8:12], header.GainCount)
+
+	_, err := writer.Write(buffer[:])
+
+	return err
+}
+
+// ReadHeader reads a header.
+//
+// Params:
+//  - reader: the source of the header
+//
+// Return the header or an error if it can not be read or does not start with the magic number
+func ReadHeader(reader io.Reader) (header Header, err error) {
+
+	var buffer [HeaderSize]byte
+	if _, err := io.ReadFull(reader, buffer[:]); err != nil {
+		return header, err
+	}
+
+	if string(buffer[:4]) != Magic {
+		return header, fmt.Errorf("invalid rtl_tcp header magic %q", buffer[:4])
+	}
+
+	header.TunerType = TunerType(binary.BigEndian.Uint32(buffer[4:8]))
+	header.GainCount = binary.BigEndian.Uint32(buffer[8:12])
+
+	return header, nil
+}
+
+// WriteCommand writes a command.
+//
+// Params:
+//  - writer: the destination of the command
+//  - command: the command
+//
+// Return an error or nil in case of success
+func WriteCommand(writer io.Writer, command Command) error {
+
+	var buffer [CommandSize]byte
+	buffer[0] = byte(command.Opcode)
+	binary.BigEndian.PutUint32(buffer[1:], command.Param)
+
+	_, err := writer.Write(buffer[:])
+
+	return err
+}
+
+// ReadCommand reads a command.
+//
+// Params:
+//  - reader: the source of the command
+//
+// Return the command or an error
+func ReadCommand(reader io.Reader) (command Command, err error) {
+
+	var buffer [CommandSize]byte
+	if _, err := io.ReadFull(reader, buffer[:]); err != nil {
+		return command, err
+	}
+
+	command.Opcode = Opcode(buffer[0])
+	command.Param = binary.BigEndian.Uint32(buffer[1:])
+
+	return command, nil
+}
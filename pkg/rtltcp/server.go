@@ -0,0 +1,444 @@
+package rtltcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrlogger"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"syscall"
+)
+
+// maxGains is the maximum number of gains reported to the clients. The gain range of the device is sampled more
+// coarsely than its step when it holds more gains.
+const maxGains = 256
+
+// digitalAGCSetting is the setting of the RTL-SDR driver enabling the digital AGC of the RTL2832
+const digitalAGCSetting = "digital_agc"
+
+// cu8FullScale is the full scale of the CU8 format, the scaler of the conversion of the float formats to CU8
+const cu8FullScale = 128
+
+// Device is the part of the device API used by the server. SDRDevice, SyncDevice and the simulated device implement it.
+type Device interface {
+	device.ChannelAPI
+	device.StreamAPI
+	device.FrontendAPI
+	device.GainAPI
+	device.FrequencyAPI
+	device.SampleRateAPI
+}
+
+// Config is the configuration of a server
+type Config struct {
+	// Channel is the receive channel of the device which is served
+	Channel uint
+	// TunerType is the type of tuner reported in the header. Default is TunerUnknown.
+	TunerType TunerType
+	// Format is the format of the stream read from the device, converted to CU8 if needed. It must be CU8, CS8, CS16
+	// or CF32. Default is CU8 if the device supports it, its native format if it is one of the others, CF32 otherwise.
+	Format string
+	// StreamArgs are the args given to the setup of the stream
+	StreamArgs map[string]string
+}
+
+// Server serves a receive channel of a device to rtl_tcp clients, one client at a time as rtl_tcp does. The commands
+// of the client are applied to the device while the stream is read: the driver must accept control calls during
+// streaming, which is the case of most drivers.
+type Server struct {
+	dev    Device
+	config Config
+	gains  []float64
+}
+
+// NewServer creates a server.
+//
+// Params:
+//  - dev: the device
+//  - config: the configuration of the server
+//
+// Return the server or an error if the channel does not exist or if the format is not supported
+func NewServer(dev Device, config Config) (*Server, error) {
+
+	if config.Channel >= dev.GetNumChannels(device.DirectionRX) {
+		return nil, fmt.Errorf("the device has no receive channel %v", config.Channel)
+	}
+
+	if config.Format == "" {
+		config.Format = defaultFormat(dev, config.Channel)
+	}
+	switch config.Format {
+	case device.FormatCU8, device.FormatCS8, device.FormatCS16, device.FormatCF32:
+	default:
+		return nil, fmt.Errorf("the stream format %v can not be served", config.Format)
+	}
+
+	return &Server{
+		dev:    dev,
+		config: config,
+		gains:  gainTable(dev.GetGainRange(device.DirectionRX, config.Channel)),
+	}, nil
+}
+
+// defaultFormat selects the format of the stream read from a device: CU8 if the device supports it, its native format
+// if it can be set up and converted, CF32 otherwise
+func defaultFormat(dev Device, channel uint) string {
+
+	for _, format := range dev.GetStreamFormats(device.DirectionRX, channel) {
+		if format == device.FormatCU8 {
+			return format
+		}
+	}
+
+	switch native, _ := dev.GetNativeStreamFormat(device.DirectionRX, channel); native {
+	case device.FormatCS8, device.FormatCS16:
+		return native
+	}
+
+	return device.FormatCF32
+}
+
+// gainTable lists the gains selected by OpcodeSetGainByIndex: the gains of the range from its minimum to its maximum by
+// its step, or by 1 dB if the range has no step
+func gainTable(gainRange device.SDRRange) []float64 {
+
+	if gainRange.Maximum < gainRange.Minimum {
+		return nil
+	}
+
+	step := gainRange.Step
+	if step <= 0 {
+		step = 1
+	}
+	nbGains := int(math.Floor((gainRange.Maximum-gainRange.Minimum)/step+1e-9)) + 1
+	if nbGains > maxGains {
+		nbGains = maxGains
+		step = (gainRange.Maximum - gainRange.Minimum) / (maxGains - 1)
+	}
+
+	gains := make([]float64, nbGains)
+	for i := range gains {
+		gains[i] = gainRange.Minimum + float64(i)*step
+	}
+
+	return gains
+}
+
+// Gains returns the gains in dB selected by the indexes of OpcodeSetGainByIndex
+func (server *Server) Gains() []float64 {
+
+	return append([]float64(nil), server.gains...)
+}
+
+// Format returns the format of the stream read from the device
+func (server *Server) Format() string {
+
+	return server.config.Format
+}
+
+// ListenAndServe listens on a TCP address and serves the clients until the context is done.
+//
+// Params:
+//  - ctx: the context of the server
+//  - address: the TCP address, such as "127.0.0.1:1234"
+//
+// Return the error which stopped the server, the error of the context if it is done
+func (server *Server) ListenAndServe(ctx context.Context, address string) error {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return server.Serve(ctx, listener)
+}
+
+// Serve accepts the clients on a listener and serves them one after the other until the context is done. The listener
+// is closed when Serve returns.
+//
+// Params:
+//  - ctx: the context of the server
+//  - listener: the listener
+//
+// Return the error which stopped the server, the error of the context if it is done
+func (server *Server) Serve(ctx context.Context, listener net.Listener) error {
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		sdrlogger.Logf(sdrlogger.Info, "rtl_tcp: client %v connected", conn.RemoteAddr())
+		err = server.ServeConn(ctx, conn)
+		sdrlogger.Logf(sdrlogger.Info, "rtl_tcp: client %v disconnected: %v", conn.RemoteAddr(), err)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// ServeConn serves a client: it sets up and activates a stream, sends the header and the samples and applies the
+// commands of the client until the client disconnects or the context is done. The connection is closed when ServeConn
+// returns.
+//
+// Params:
+//  - ctx: the context of the session
+//  - conn: the connection of the client
+//
+// Return nil if the client disconnected, the error of the context if it is done, the error which stopped the session
+// otherwise
+func (server *Server) ServeConn(ctx context.Context, conn net.Conn) error {
+
+	defer conn.Close()
+
+	source, err := server.openSource()
+	if err != nil {
+		return err
+	}
+	defer source.close()
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-sessionCtx.Done()
+		_ = conn.Close()
+	}()
+
+	header := Header{TunerType: server.config.TunerType, GainCount: uint32(len(server.gains))}
+	if err := WriteHeader(conn, header); err != nil {
+		return err
+	}
+
+	commandsDone := make(chan error, 1)
+	go func() {
+		commandsDone <- server.readCommands(conn)
+		cancel()
+	}()
+
+	err = server.stream(sessionCtx, conn, source)
+	cancel()
+	if commandsErr := <-commandsDone; errors.Is(err, context.Canceled) && ctx.Err() == nil {
+		// The session was ended by the client
+		err = commandsErr
+	}
+	if errors.Is(err, io.EOF) || disconnected(err) {
+		return nil
+	}
+
+	return err
+}
+
+// disconnected checks if an error of the connection reports that the client closed it
+func disconnected(err error) bool {
+
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// stream sends the samples of the source to the client until an error occurs
+func (server *Server) stream(ctx context.Context, conn net.Conn, source sampleSource) error {
+
+	for {
+		samples, err := source.read(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := conn.Write(samples); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+	}
+}
+
+// readCommands reads and applies the commands of the client until the connection fails
+func (server *Server) readCommands(reader io.Reader) error {
+
+	for {
+		command, err := ReadCommand(reader)
+		if err != nil {
+			return err
+		}
+
+		if err := server.execute(command); err != nil {
+			sdrlogger.Logf(sdrlogger.Warning, "rtl_tcp: command %#02x with parameter %v failed: %v", command.Opcode, command.Param, err)
+		}
+	}
+}
+
+// execute applies a command to the device. The commands which do not apply to SoapySDR devices are ignored.
+//
+// Return an error or nil in case of success
+func (server *Server) execute(command Command) error {
+
+	direction, channel := device.DirectionRX, server.config.Channel
+
+	switch command.Opcode {
+	case OpcodeSetFrequency:
+		return server.dev.SetFrequency(direction, channel, float64(command.Param), nil)
+	case OpcodeSetSampleRate:
+		return server.dev.SetSampleRate(direction, channel, float64(command.Param))
+	case OpcodeSetGainMode:
+		return server.dev.SetGainMode(direction, channel, command.Param == 0)
+	case OpcodeSetGain:
+		return server.dev.SetGain(direction, channel, float64(int32(command.Param))/10)
+	case OpcodeSetFrequencyCorrection:
+		return server.dev.SetFrequencyCorrection(direction, channel, float64(int32(command.Param)))
+	case OpcodeSetAGCMode:
+		return server.setDigitalAGC(command.Param != 0)
+	case OpcodeSetGainByIndex:
+		if command.Param >= uint32(len(server.gains)) {
+			return fmt.Errorf("gain index %v out of the %v gains", command.Param, len(server.gains))
+		}
+		return server.dev.SetGain(direction, channel, server.gains[command.Param])
+	default:
+		sdrlogger.Logf(sdrlogger.Debug, "rtl_tcp: command %#02x ignored", command.Opcode)
+		return nil
+	}
+}
+
+// setDigitalAGC enables or disables the digital AGC of the RTL2832 through the digital_agc setting of the driver. The
+// digital AGC is not the gain mode of the tuner: the command is ignored by the devices which have no such setting.
+//
+// Return an error or nil in case of success
+func (server *Server) setDigitalAGC(enabled bool) error {
+
+	if settings, ok := server.dev.(device.SettingAPI); ok {
+		for _, info := range settings.GetSettingInfo() {
+			if info.Key == digitalAGCSetting {
+				return settings.WriteSetting(digitalAGCSetting, strconv.FormatBool(enabled))
+			}
+		}
+	}
+
+	sdrlogger.Logf(sdrlogger.Debug, "rtl_tcp: command %#02x ignored, the device has no digital AGC", OpcodeSetAGCMode)
+
+	return nil
+}
+
+// sampleSource reads the samples of a stream of the device and converts them to CU8
+type sampleSource interface {
+	// read reads the next samples, in CU8 format. The samples are valid until the next read.
+	read(ctx context.Context) (samples []byte, err error)
+	// close deactivates and closes the stream
+	close()
+}
+
+// typedSource is a sampleSource reading a stream whose elements are of type T
+type typedSource[T device.Sample] struct {
+	stream    device.TypedStream[T]
+	buffers   [][]T
+	flags     []int
+	converter convert.Func
+	samples   []byte
+}
+
+// openSource sets up and activates the stream of the server
+func (server *Server) openSource() (sampleSource, error) {
+
+	channels := []uint{server.config.Channel}
+	args := server.config.StreamArgs
+
+	switch server.config.Format {
+	case device.FormatCU8:
+		return newTypedSource(server.dev.SetupSDRStreamCU8(device.DirectionRX, channels, args))
+	case device.FormatCS8:
+		return newTypedSource(server.dev.SetupSDRStreamCS8(device.DirectionRX, channels, args))
+	case device.FormatCS16:
+		return newTypedSource(server.dev.SetupSDRStreamCS16(device.DirectionRX, channels, args))
+	default:
+		return newTypedSource(server.dev.SetupSDRStreamCF32(device.DirectionRX, channels, args))
+	}
+}
+
+// newTypedSource activates a stream which was set up and creates its source.
+//
+// Params:
+//  - stream: the stream
+//  - err: the error of the setup of the stream
+//
+// Return the source or an error
+func newTypedSource[T device.Sample](stream device.TypedStream[T], err error) (sampleSource, error) {
+
+	if err != nil {
+		return nil, err
+	}
+
+	source := &typedSource[T]{stream: stream, flags: make([]int, 1)}
+
+	format, elemsPerSample, err := device.StreamFormat[T]()
+	if err != nil {
+		_ = stream.Close()
+		return nil, err
+	}
+	if format != device.FormatCU8 {
+		if source.converter, err = convert.GetFunction(format, device.FormatCU8); err != nil {
+			_ = stream.Close()
+			return nil, err
+		}
+	}
+
+	if err := stream.Activate(0, 0, 0); err != nil {
+		_ = stream.Close()
+		return nil, err
+	}
+
+	mtu := uint(stream.GetMTU())
+	source.buffers = [][]T{make([]T, mtu*elemsPerSample)}
+	source.samples = make([]byte, 2*mtu)
+
+	return source, nil
+}
+
+// read reads the next samples. The overflows of the stream are ignored.
+func (source *typedSource[T]) read(ctx context.Context) (samples []byte, err error) {
+
+	var numElemsRead uint
+	for {
+		_, numElemsRead, err = source.stream.ReadContext(ctx, source.buffers, uint(len(source.samples)/2), source.flags)
+		if !errors.Is(err, sdrerror.ErrOverflow) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if source.converter == nil {
+		return convert.Bytes(source.buffers[0])[:2*numElemsRead], nil
+	}
+
+	samples = source.samples[:2*numElemsRead]
+	source.converter(samples, convert.Bytes(source.buffers[0]), int(numElemsRead), cu8FullScale)
+
+	return samples, nil
+}
+
+// close deactivates and closes the stream
+func (source *typedSource[T]) close() {
+
+	_ = source.stream.Deactivate(0, 0)
+	_ = source.stream.Close()
+}
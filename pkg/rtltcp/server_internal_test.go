@@ -0,0 +1,180 @@
+package rtltcp
+
+import (
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestGainTable(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		gainRange device.SDRRange
+		expected  []float64
+	}{
+		{"step", device.SDRRange{Minimum: 0, Maximum: 10, Step: 2.5}, []float64{0, 2.5, 5, 7.5, 10}},
+		{"no step", device.SDRRange{Minimum: -2, Maximum: 1}, []float64{-2, -1, 0, 1}},
+		{"partial step", device.SDRRange{Minimum: 0, Maximum: 5, Step: 2}, []float64{0, 2, 4}},
+		{"single gain", device.SDRRange{Minimum: 3, Maximum: 3}, []float64{3}},
+		{"inverted", device.SDRRange{Minimum: 10, Maximum: 0}, nil},
+	}
+
+	for _, test := range tests {
+		gains := gainTable(test.gainRange)
+		if len(gains) != len(test.expected) {
+			t.Errorf("%v: gains %v, expected %v", test.name, gains, test.expected)
+			continue
+		}
+		for i := range gains {
+			if gains[i] != test.expected[i] {
+				t.Errorf("%v: gains %v, expected %v", test.name, gains, test.expected)
+				break
+			}
+		}
+	}
+
+	// A range holding more gains than a client can select is sampled from its minimum to its maximum
+	gains := gainTable(device.SDRRange{Minimum: 0, Maximum: 100, Step: 0.1})
+	if len(gains) != maxGains || gains[0] != 0 || gains[maxGains-1] != 100 {
+		t.Errorf("%v gains from %v to %v, expected %v gains from 0 to 100", len(gains), gains[0], gains[len(gains)-1], maxGains)
+	}
+}
+
+func TestExecute(t *testing.T) {
+
+	dev := simtest.NewDevice(t, sim.Config{
+		GainElements: []sim.GainElement{{Name: "LNA", Range: device.SDRRange{Minimum: 0, Maximum: 20, Step: 0.5}}},
+	})
+
+	server, err := NewServer(dev, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.gains) != 41 {
+		t.Fatalf("%v gains, expected 41", len(server.gains))
+	}
+
+	// The gain index is bounded by the gain table
+	if err := server.execute(Command{Opcode: OpcodeSetGainByIndex, Param: 40}); err != nil {
+		t.Fatal(err)
+	}
+	if gain := dev.GetGain(device.DirectionRX, 0); gain != 20 {
+		t.Errorf("gain %v after the selection of the last index, expected 20", gain)
+	}
+	if err := server.execute(Command{Opcode: OpcodeSetGainByIndex, Param: 41}); err == nil {
+		t.Error("gain index out of the table accepted")
+	}
+	if err := server.execute(Command{Opcode: OpcodeSetGain, Param: 105}); err != nil {
+		t.Fatal(err)
+	}
+	if gain := dev.GetGain(device.DirectionRX, 0); gain != 10.5 {
+		t.Errorf("gain %v after a gain of 105 tenths of dB, expected 10.5", gain)
+	}
+
+	// The gain mode selects the manual mode when its parameter is not 0, the digital AGC does not change the gain mode
+	modes := []struct {
+		opcode    Opcode
+		param     uint32
+		automatic bool
+	}{
+		{OpcodeSetGainMode, 1, false},
+		{OpcodeSetAGCMode, 1, false},
+		{OpcodeSetGainMode, 0, true},
+		{OpcodeSetAGCMode, 0, true},
+	}
+	for _, mode := range modes {
+		if err := server.execute(Command{Opcode: mode.opcode, Param: mode.param}); err != nil {
+			t.Fatal(err)
+		}
+		if automatic := dev.GetGainMode(device.DirectionRX, 0); automatic != mode.automatic {
+			t.Errorf("opcode %v with parameter %v: automatic gain %v, expected %v", mode.opcode, mode.param, automatic, mode.automatic)
+		}
+	}
+
+	// The commands which do not apply to SoapySDR devices are ignored
+	if err := server.execute(Command{Opcode: OpcodeSetBiasTee, Param: 1}); err != nil {
+		t.Errorf("ignored command returned %v", err)
+	}
+}
+
+func TestExecuteDigitalAGC(t *testing.T) {
+
+	dev := simtest.NewDevice(t, sim.Config{
+		Settings: []device.SDRArgInfo{{Key: digitalAGCSetting, Value: "false", Type: device.ArgInfoBool}},
+	})
+
+	server, err := NewServer(dev, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The AGC mode controls the digital AGC setting of the driver
+	for _, param := range []uint32{1, 0} {
+		if err := server.execute(Command{Opcode: OpcodeSetAGCMode, Param: param}); err != nil {
+			t.Fatal(err)
+		}
+		if value, expected := dev.ReadSetting(digitalAGCSetting), strconv.FormatBool(param != 0); value != expected {
+			t.Errorf("AGC mode %v: digital AGC %v, expected %v", param, value, expected)
+		}
+	}
+}
+
+// brokenConn is a server connection whose writes fail after the header
+type brokenConn struct {
+	net.Conn
+	headerSent bool
+	err        error
+}
+
+func (conn *brokenConn) Write(p []byte) (int, error) {
+
+	if !conn.headerSent {
+		conn.headerSent = true
+		return conn.Conn.Write(p)
+	}
+
+	return 0, conn.err
+}
+
+func TestServeConnDisconnection(t *testing.T) {
+
+	dev := simtest.NewDevice(t, sim.Config{})
+	server, err := NewServer(dev, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errBroken := errors.New("broken connection")
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"closed", &net.OpError{Op: "write", Net: "tcp", Err: net.ErrClosed}, nil},
+		{"broken pipe", &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}, nil},
+		{"reset", &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.ECONNRESET)}, nil},
+		{"other error", errBroken, errBroken},
+	}
+
+	for _, test := range tests {
+		serverConn, clientConn := net.Pipe()
+		go func() {
+			_, _ = ReadHeader(clientConn)
+		}()
+
+		// A client which disconnects ends the session without error
+		err := server.ServeConn(context.Background(), &brokenConn{Conn: serverConn, err: test.err})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%v: the session ended with %v, expected %v", test.name, err, test.expected)
+		}
+		_ = clientConn.Close()
+	}
+}
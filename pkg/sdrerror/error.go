@@ -0,0 +1,110 @@
+package sdrerror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors of the SDR layer, to be used with errors.Is(). Any error of the SDR layer matches the sentinel
+// having the same SoapySDR error code, including the errors returned with the context of the failed call.
+var (
+	// ErrTimeout matches the Timeout errors
+	ErrTimeout SDRError = &Timeout{}
+	// ErrStream matches the StreamError errors
+	ErrStream SDRError = &StreamError{}
+	// ErrCorruption matches the Corruption errors
+	ErrCorruption SDRError = &Corruption{}
+	// ErrOverflow matches the Overflow errors
+	ErrOverflow SDRError = &Overflow{}
+	// ErrNotSupported matches the NotSupported errors
+	ErrNotSupported SDRError = &NotSupported{}
+	// ErrTime matches the TimeError errors
+	ErrTime SDRError = &TimeError{}
+	// ErrUnderflow matches the Underflow errors
+	ErrUnderflow SDRError = &Underflow{}
+	// ErrUnknown matches the Unknown errors
+	ErrUnknown SDRError = &Unknown{}
+	// ErrClosed matches the Closed errors, returned by the calls on a released device or on a closed stream
+	ErrClosed SDRError = &Closed{}
+	// ErrNotActive matches the NotActive errors, returned by the reads and the writes on a stream which is not active
+	ErrNotActive SDRError = &NotActive{}
+)
+
+// Error is an error of the SDR layer carrying the context of the failed call: the operation, the direction and the
+// channel it applied to and the message reported by the driver. The SDR error matching the code is available through
+// errors.As() and errors.Is(), for example errors.Is(err, ErrTimeout).
+type Error struct {
+	// Code is the original error code for the SoapySDR
+	Code int
+	// Message is the last error message reported by SoapySDR for the call, empty if none
+	Message string
+	// Op is the name of the failed operation, such as "SetGain"
+	Op string
+	// Direction is the direction of the failed operation ("RX" or "TX"), empty if the operation does not apply to a
+	// direction
+	Direction string
+	// Channel is the channel of the failed operation, negative if the operation does not apply to a single channel
+	Channel int
+	// Err is the SDR error matching the code
+	Err SDRError
+}
+
+// Wrap builds an SDR error carrying the context of the failed call. If the SDR error code is 0 then, there was no
+// error and nil is returned.
+//
+// Params:
+//  - errorCode: the SDR error code
+//  - message: the last error message reported by SoapySDR, or empty
+//  - op: the name of the operation
+//  - direction: the direction of the operation ("RX" or "TX"), or empty
+//  - channel: the channel of the operation, or a negative value if the operation does not apply to a single channel
+//
+// Return the error or nil
+func Wrap(errorCode int, message string, op string, direction string, channel int) SDRError {
+
+	if errorCode == 0 {
+		return nil
+	}
+
+	return &Error{
+		Code:      errorCode,
+		Message:   message,
+		Op:        op,
+		Direction: direction,
+		Channel:   channel,
+		Err:       Err(errorCode),
+	}
+}
+
+// Error returns the error message
+func (err *Error) Error() string {
+
+	var builder strings.Builder
+
+	builder.WriteString(err.Op)
+	if err.Direction != "" {
+		builder.WriteString(" ")
+		builder.WriteString(err.Direction)
+	}
+	if err.Channel >= 0 {
+		builder.WriteString(fmt.Sprintf(" channel %v", err.Channel))
+	}
+	builder.WriteString(": ")
+	builder.WriteString(err.Err.Error())
+	if err.Message != "" {
+		builder.WriteString(": ")
+		builder.WriteString(err.Message)
+	}
+
+	return builder.String()
+}
+
+// SDRErrorCode returns the original error code for the SoapySDR
+func (err *Error) SDRErrorCode() int {
+	return err.Code
+}
+
+// Unwrap returns the SDR error matching the code
+func (err *Error) Unwrap() error {
+	return err.Err
+}
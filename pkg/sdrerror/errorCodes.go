@@ -1,6 +1,8 @@
 package sdrerror
 
-// SDRError is an error of the SDR layer
+// SDRError is an error of the SDR layer. Each error type implements Is() to match the errors of the same type whatever
+// their instance, so that errors.Is() matches the sentinels such as ErrTimeout, including through the Error carrying
+// the context of a failed call.
 type SDRError interface {
 	// Error returns the error message
 	Error() string
@@ -28,6 +30,10 @@ func Err(errorCode int) SDRError {
 		return &TimeError{}
 	case -7:
 		return &Underflow{}
+	case -253:
+		return &NotActive{}
+	case -254:
+		return &Closed{}
 	default:
 		return &Unknown{}
 	}
@@ -47,6 +53,12 @@ func (err *Timeout) SDRErrorCode() int {
 	return -1
 }
 
+// Is matches any timeout, such as ErrTimeout
+func (err *Timeout) Is(target error) bool {
+	_, same := target.(*Timeout)
+	return same
+}
+
 // StreamError denotes a Stream error
 type StreamError struct {
 }
@@ -61,6 +73,12 @@ func (err *StreamError) SDRErrorCode() int {
 	return -2
 }
 
+// Is matches any non-specific stream error, such as ErrStream
+func (err *StreamError) Is(target error) bool {
+	_, same := target.(*StreamError)
+	return same
+}
+
 // Corruption denotes that read has data corruption. For example, the driver saw a malformed packet.
 type Corruption struct {
 }
@@ -75,6 +93,12 @@ func (err *Corruption) SDRErrorCode() int {
 	return -3
 }
 
+// Is matches any data corruption, such as ErrCorruption
+func (err *Corruption) Is(target error) bool {
+	_, same := target.(*Corruption)
+	return same
+}
+
 // Overflow denotes that read has an overflow condition. For example, and internal buffer has filled.
 type Overflow struct {
 }
@@ -89,6 +113,12 @@ func (err *Overflow) SDRErrorCode() int {
 	return -4
 }
 
+// Is matches any overflow, such as ErrOverflow
+func (err *Overflow) Is(target error) bool {
+	_, same := target.(*Overflow)
+	return same
+}
+
 // NotSupported denotes that requested operation or flag setting is not supported by the underlying implementation.
 type NotSupported struct {
 }
@@ -103,6 +133,12 @@ func (err *NotSupported) SDRErrorCode() int {
 	return -5
 }
 
+// Is matches any unsupported operation or flag, such as ErrNotSupported
+func (err *NotSupported) Is(target error) bool {
+	_, same := target.(*NotSupported)
+	return same
+}
+
 // TimeError denotes that a the device encountered a stream time which was expired (late) or too early to process.
 type TimeError struct {
 }
@@ -117,6 +153,12 @@ func (err *TimeError) SDRErrorCode() int {
 	return -6
 }
 
+// Is matches any expired or too early stream time, such as ErrTime
+func (err *TimeError) Is(target error) bool {
+	_, same := target.(*TimeError)
+	return same
+}
+
 // Underflow denotes that a write caused an underflow condition. For example, a continuous stream was interrupted.
 type Underflow struct {
 }
@@ -131,6 +173,54 @@ func (err *Underflow) SDRErrorCode() int {
 	return -7
 }
 
+// Is matches any underflow, such as ErrUnderflow
+func (err *Underflow) Is(target error) bool {
+	_, same := target.(*Underflow)
+	return same
+}
+
+// Closed denotes a call on a device or a stream which was already released or closed. It is not a SoapySDR error: the
+// call is rejected by the bindings without reaching the driver.
+type Closed struct {
+}
+
+// Error returns the error message
+func (err *Closed) Error() string {
+	return "use of a released device or of a closed stream"
+}
+
+// SDRErrorCode returns the error code of the bindings, which has no SoapySDR equivalent
+func (err *Closed) SDRErrorCode() int {
+	return -254
+}
+
+// Is matches any call rejected on a released device or a closed stream, such as ErrClosed
+func (err *Closed) Is(target error) bool {
+	_, same := target.(*Closed)
+	return same
+}
+
+// NotActive denotes a read or a write on a stream which is not active. It is not a SoapySDR error: the call is
+// rejected by the bindings without reaching the driver.
+type NotActive struct {
+}
+
+// Error returns the error message
+func (err *NotActive) Error() string {
+	return "the stream is not active"
+}
+
+// SDRErrorCode returns the error code of the bindings, which has no SoapySDR equivalent
+func (err *NotActive) SDRErrorCode() int {
+	return -253
+}
+
+// Is matches any read or write rejected on an inactive stream, such as ErrNotActive
+func (err *NotActive) Is(target error) bool {
+	_, same := target.(*NotActive)
+	return same
+}
+
 // Unknown denotes an unknown error. This should not happen.
 type Unknown struct {
 }
@@ -144,3 +234,9 @@ func (err *Unknown) Error() string {
 func (err *Unknown) SDRErrorCode() int {
 	return -255
 }
+
+// Is matches any unknown error, such as ErrUnknown
+func (err *Unknown) Is(target error) bool {
+	_, same := target.(*Unknown)
+	return same
+}
@@ -0,0 +1,563 @@
+package sigmf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// hostLittleEndian is true if the host stores the numbers in little-endian order, the order of the samples of the
+// SigMF datatypes supported by the file streams
+var hostLittleEndian = *(*uint16)(unsafe.Pointer(&[2]byte{1, 0})) == 1
+
+// FileStreamConfig is the configuration of a file stream
+type FileStreamConfig struct {
+	// MTU is the MTU of the stream in number of elements. Default is 4096.
+	MTU int
+	// Realtime paces the reads at the sample rate of the recording. When false, the samples are read as fast as
+	// possible.
+	Realtime bool
+	// Loop restarts the stream at the first sample of the recording once its last sample is read. When false, the
+	// reads return io.EOF after the last sample.
+	Loop bool
+}
+
+// FileStream is a receive stream reading the samples of a SigMF recording instead of a radio. It implements
+// device.TypedStream, so that the code reading a device stream, such as a demodulator, can be run against a recording.
+//
+// The samples are read in the format of the recording when it can be stored in elements of type T, for instance a
+// ci16_le recording read into int16 or device.CS16 elements. Otherwise they are converted to the default format of T,
+// see device.StreamFormat(), with the converters of the convert package: a ci16_le recording can be read into
+// complex64 elements, in CF32 format.
+//
+// The timestamp of each read is derived from the datetime of the capture segment of its first sample. The reads do
+// not cross the capture segments, so that the samples of a read have been received with the same tuning. Writes, the
+// status of the stream and direct buffer access are not supported.
+type FileStream[T device.Sample] struct {
+	meta           *Metadata
+	file           *os.File
+	config         FileStreamConfig
+	format         string
+	elemsPerSample uint
+	nbChannels     uint
+	sampleSize     int
+	converter      convert.Func
+	scaler         float64
+	numSamples     uint64
+
+	mutex     sync.Mutex
+	closed    bool
+	active    bool
+	index     uint64
+	remaining uint64
+	started   time.Time
+	nbRead    uint64
+
+	// activations counts the activations, so that a read waiting for its samples detects a reactivation
+	activations uint
+
+	// readMutex serialises the reads, which share the buffers of the stream so that a read does not allocate
+	readMutex sync.Mutex
+	raw       []byte
+	samples   []byte
+}
+
+// Ensure FileStream implements device.TypedStream
+var _ device.TypedStream[complex64] = (*FileStream[complex64])(nil)
+
+// OpenFileStream opens a SigMF recording as a receive stream. The stream must be activated before reading.
+//
+// Params:
+//  - basePath: the path of the recording without extension: the files basePath.sigmf-data and basePath.sigmf-meta are
+//    read
+//  - config: the configuration of the stream
+//
+// Return the stream or an error if the recording can not be read or if its datatype can not be read into elements
+// of type T
+func OpenFileStream[T device.Sample](basePath string, config FileStreamConfig) (*FileStream[T], error) {
+
+	if !hostLittleEndian {
+		return nil, errors.New("the SigMF recordings can only be read on little-endian hosts")
+	}
+
+	meta, err := ReadMetadata(basePath + MetaExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	fileFormat, err := Format(meta.Global.Datatype)
+	if err != nil {
+		return nil, err
+	}
+	if config.Realtime && meta.Global.SampleRate <= 0 {
+		return nil, errors.New("the recording has no sample rate to pace the stream")
+	}
+	if config.MTU <= 0 {
+		config.MTU = 4096
+	}
+
+	stream := &FileStream[T]{
+		meta:       meta,
+		config:     config,
+		format:     fileFormat,
+		nbChannels: meta.Global.NumChannels,
+		sampleSize: int(device.FormatToSize(fileFormat)),
+	}
+	if stream.nbChannels == 0 {
+		stream.nbChannels = 1
+	}
+
+	stream.elemsPerSample, err = device.FormatElemsPerSample[T](fileFormat)
+	if err != nil {
+		stream.format, stream.elemsPerSample, err = device.StreamFormat[T]()
+		if err != nil {
+			return nil, err
+		}
+		stream.converter, err = convert.GetFunction(fileFormat, stream.format)
+		if err != nil {
+			return nil, fmt.Errorf("the SigMF datatype %v can not be read into %v elements: %w", meta.Global.Datatype, stream.format, err)
+		}
+		stream.scaler = conversionScaler(fileFormat, stream.format)
+	}
+
+	file, err := os.Open(basePath + DataExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	stream.file = file
+	stream.numSamples = uint64(info.Size()) / (uint64(stream.nbChannels) * uint64(stream.sampleSize))
+
+	return stream, nil
+}
+
+// conversionScaler returns the scaler of a conversion between two formats: the full scale of the integer format, 1.0
+// between two float formats
+func conversionScaler(source string, target string) float64 {
+
+	if fullScale := device.FormatFullScale(source); fullScale != 0 {
+		return fullScale
+	}
+	if fullScale := device.FormatFullScale(target); fullScale != 0 {
+		return fullScale
+	}
+
+	return 1
+}
+
+// Metadata returns the metadata of the recording.
+//
+// Return the metadata, which must not be modified
+func (s *FileStream[T]) Metadata() *Metadata {
+
+	return s.meta
+}
+
+// NumSamples returns the number of samples of each channel of the recording
+func (s *FileStream[T]) NumSamples() uint64 {
+
+	return s.numSamples
+}
+
+// Close closes the stream and its data file.
+//
+// Return an error or nil in case of success, sdrerror.ErrClosed if the stream is already closed
+func (s *FileStream[T]) Close() (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Close", false); err != nil {
+		return err
+	}
+
+	s.closed = true
+	s.active = false
+	_ = s.file.Close()
+
+	return nil
+}
+
+// checkState rejects a call on a closed stream or, when the call requires an active stream, on a stream which is not
+// active. The mutex of the stream must be held.
+//
+// Params:
+//  - op: the name of the call
+//  - active: true if the call requires an active stream
+//
+// Return an error matching sdrerror.ErrClosed or sdrerror.ErrNotActive if the call is rejected
+func (s *FileStream[T]) checkState(op string, active bool) sdrerror.SDRError {
+
+	switch {
+	case s.closed:
+		return sdrerror.Wrap(sdrerror.ErrClosed.SDRErrorCode(), "", op, device.DirectionRX.String(), -1)
+	case active && !s.active:
+		return sdrerror.Wrap(sdrerror.ErrNotActive.SDRErrorCode(), "", op, device.DirectionRX.String(), -1)
+	}
+
+	return nil
+}
+
+// notSupported returns the error of the calls which are not supported by the file streams
+func notSupported(op string) sdrerror.SDRError {
+
+	return sdrerror.Wrap(sdrerror.ErrNotSupported.SDRErrorCode(), "not supported by a file stream", op, device.DirectionRX.String(), -1)
+}
+
+// GetMTU gets the stream's maximum transmission unit (MTU) in number of elements.
+//
+// Return the MTU of the configuration of the stream
+func (s *FileStream[T]) GetMTU() int {
+
+	return s.config.MTU
+}
+
+// GetNumChannels returns the number of channels of the stream.
+//
+// Return the number of channels of the recording
+func (s *FileStream[T]) GetNumChannels() uint {
+
+	return s.nbChannels
+}
+
+// GetFormat returns the format of the elements of the stream, such as "CF32".
+//
+// Return the format of the stream
+func (s *FileStream[T]) GetFormat() string {
+
+	return s.format
+}
+
+// Activate activates the stream. The stream restarts at the first sample of the recording.
+//
+// Params:
+//  - flags: ignored
+//  - timeNs: ignored, the timestamps are given by the recording
+//  - numElems: optional element count for burst control. When not 0, the stream ends after numElems elements.
+//
+// Return an error or nil in case of success
+func (s *FileStream[T]) Activate(flags device.StreamFlag, timeNs int, numElems int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Activate", false); err != nil {
+		return err
+	}
+
+	s.active = true
+	s.activations++
+	s.started = time.Now()
+	s.index = 0
+	s.nbRead = 0
+	s.remaining = 0
+	if numElems > 0 {
+		s.remaining = uint64(numElems)
+	}
+
+	return nil
+}
+
+// Deactivate deactivates the stream.
+//
+// Params:
+//  - flags: ignored
+//  - timeNs: ignored
+//
+// Return an error or nil in case of success
+func (s *FileStream[T]) Deactivate(flags device.StreamFlag, timeNs int) (err sdrerror.SDRError) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Deactivate", false); err != nil {
+		return err
+	}
+
+	s.active = false
+
+	return nil
+}
+
+// Read reads elements from the recording. The number of elements read is limited by the MTU of the stream and by the
+// end of the capture segment of the first element.
+//
+// Params:
+//  - buffers: the buffers of each channel. See device.Stream.Read() for the details.
+//  - nbElems: the number of elements to read
+//  - outputFlags: the flag indicators of the result by channel. StreamFlagHasTime is always set, StreamFlagEndBurst
+//    is set on the read of the last sample of the recording (unless the stream loops) or of the burst given to
+//    Activate().
+//  - timeoutUs: the timeout in microseconds, used when the stream is paced at the sample rate
+//
+// Return the timestamp of the first element in nanoseconds, the number of elements read per buffer and an error,
+// io.EOF once the whole recording is read
+func (s *FileStream[T]) Read(buffers [][]T, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	if err := device.CheckBuffers(buffers, s.nbChannels, nbElems, s.elemsPerSample); err != nil {
+		return 0, 0, err
+	}
+	if uint(len(outputFlags)) != s.nbChannels {
+		return 0, 0, errors.New("the flags must have the same number of channels as the stream")
+	}
+
+	s.readMutex.Lock()
+	defer s.readMutex.Unlock()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkState("Read", true); err != nil {
+		return 0, 0, err
+	}
+
+	for i := range outputFlags {
+		outputFlags[i] = 0
+	}
+
+	if s.index >= s.numSamples && s.config.Loop {
+		s.index = 0
+	}
+	if s.index >= s.numSamples {
+		return 0, 0, io.EOF
+	}
+
+	count := uint64(nbElems)
+	if mtu := uint64(s.config.MTU); count > mtu {
+		count = mtu
+	}
+	capture, end := s.captureAt(s.index)
+	if count > end-s.index {
+		count = end - s.index
+	}
+	if s.remaining > 0 && count > s.remaining {
+		count = s.remaining
+	}
+
+	if s.config.Realtime {
+		// The mutex is released during the wait so that the stream can be closed or deactivated meanwhile
+		activations := s.activations
+		due := s.started.Add(time.Duration(float64(s.nbRead+count) / s.meta.Global.SampleRate * float64(time.Second)))
+		wait := time.Until(due)
+		timeout := time.Duration(timeoutUs) * time.Microsecond
+
+		s.mutex.Unlock()
+		if wait > timeout {
+			time.Sleep(timeout)
+		} else {
+			time.Sleep(wait)
+		}
+		s.mutex.Lock()
+
+		if err := s.checkState("Read", true); err != nil {
+			return 0, 0, err
+		}
+		if wait > timeout || s.activations != activations {
+			return 0, 0, sdrerror.Err(sdrerror.ErrTimeout.SDRErrorCode())
+		}
+	}
+
+	frameSize := int(s.nbChannels) * s.sampleSize
+	raw := s.buffer(&s.raw, int(count)*frameSize)
+	if _, err := s.file.ReadAt(raw, int64(s.index)*int64(frameSize)); err != nil {
+		return 0, 0, err
+	}
+
+	for channelIdx, buffer := range buffers {
+		samples := convert.Bytes(buffer)
+		if s.converter != nil {
+			samples = s.buffer(&s.samples, int(count)*s.sampleSize)
+		}
+		for i := 0; i < int(count); i++ {
+			offset := i*frameSize + channelIdx*s.sampleSize
+			copy(samples[i*s.sampleSize:], raw[offset:offset+s.sampleSize])
+		}
+		if s.converter != nil {
+			s.converter(convert.Bytes(buffer), samples, int(count), s.scaler)
+		}
+	}
+
+	timeNs = s.timeNs(capture, s.index)
+
+	s.index += count
+	s.nbRead += count
+	flags := device.StreamFlagHasTime
+	if s.index == s.numSamples && !s.config.Loop {
+		flags |= device.StreamFlagEndBurst
+	}
+	if s.remaining > 0 {
+		s.remaining -= count
+		if s.remaining == 0 {
+			flags |= device.StreamFlagEndBurst
+			s.active = false
+		}
+	}
+	for i := range outputFlags {
+		outputFlags[i] = int(flags)
+	}
+
+	return timeNs, uint(count), nil
+}
+
+// captureAt finds the capture segment of a sample.
+//
+// Params:
+//  - index: the index of the sample
+//
+// Return the capture segment, nil if the recording has none, and the index of the first sample after the segment
+func (s *FileStream[T]) captureAt(index uint64) (capture *Capture, end uint64) {
+
+	end = s.numSamples
+	for i := range s.meta.Captures {
+		if s.meta.Captures[i].SampleStart > index {
+			if s.meta.Captures[i].SampleStart < end {
+				end = s.meta.Captures[i].SampleStart
+			}
+			break
+		}
+		capture = &s.meta.Captures[i]
+	}
+
+	return capture, end
+}
+
+// timeNs returns the timestamp of a sample: the datetime of its capture segment plus the duration of the samples of
+// the segment before it, the duration of the recording before the sample if the segment has no datetime.
+//
+// Params:
+//  - capture: the capture segment of the sample, nil if the recording has none
+//  - index: the index of the sample
+//
+// Return the timestamp in nanoseconds
+func (s *FileStream[T]) timeNs(capture *Capture, index uint64) uint {
+
+	sampleRate := s.meta.Global.SampleRate
+	if sampleRate <= 0 {
+		return 0
+	}
+
+	if capture != nil && capture.Datetime != "" {
+		if start, err := time.Parse(time.RFC3339Nano, capture.Datetime); err == nil {
+			return uint(start.UnixNano()) + uint(float64(index-capture.SampleStart)*1e9/sampleRate)
+		}
+	}
+
+	return uint(float64(index) * 1e9 / sampleRate)
+}
+
+// buffer returns a scratch buffer of the stream of the given size, growing it if needed
+func (s *FileStream[T]) buffer(scratch *[]byte, size int) []byte {
+
+	if cap(*scratch) < size {
+		*scratch = make([]byte, size)
+	}
+
+	return (*scratch)[:size]
+}
+
+// ReadBuffers reads elements from the recording, as many as fit in the given buffers. See Read() for the details.
+func (s *FileStream[T]) ReadBuffers(buffers [][]T, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	return s.Read(buffers, device.BuffersNumElems(buffers, s.elemsPerSample), outputFlags, timeoutUs)
+}
+
+// ReadContext reads elements from the recording until elements are read or the context is done. See
+// device.ReadContext() for the details.
+func (s *FileStream[T]) ReadContext(ctx context.Context, buffers [][]T, nbElems uint, outputFlags []int) (timeNs uint, numElemsRead uint, err error) {
+
+	return device.ReadContext[T](ctx, s, buffers, nbElems, outputFlags)
+}
+
+// Write is not supported by the file streams.
+func (s *FileStream[T]) Write(buffers [][]T, nbElems uint, flags []int, timeNs uint, timeoutUs uint) (NbElemsWritten uint, err error) {
+
+	return 0, notSupported("Write")
+}
+
+// WriteBuffers is not supported by the file streams.
+func (s *FileStream[T]) WriteBuffers(buffers [][]T, flags []int, timeNs uint, timeoutUs uint) (numElemsWritten uint, err error) {
+
+	return 0, notSupported("WriteBuffers")
+}
+
+// WriteContext is not supported by the file streams.
+func (s *FileStream[T]) WriteContext(ctx context.Context, buffers [][]T, nbElems uint, flags []int, timeNs uint) (numElemsWritten uint, err error) {
+
+	return 0, notSupported("WriteContext")
+}
+
+// TransmitBurst is not supported by the file streams.
+func (s *FileStream[T]) TransmitBurst(ctx context.Context, samples [][]T, atTimeNs uint) (report device.BurstReport, err error) {
+
+	return report, notSupported("TransmitBurst")
+}
+
+// ReadStreamStatus is not supported by the file streams.
+func (s *FileStream[T]) ReadStreamStatus(chanMask []uint, flags []int, timeoutUs uint) (timeNs uint, err error) {
+
+	return 0, notSupported("ReadStreamStatus")
+}
+
+// ReadStreamStatusContext is not supported by the file streams.
+func (s *FileStream[T]) ReadStreamStatusContext(ctx context.Context, chanMask []uint, flags []int) (timeNs uint, err error) {
+
+	return 0, notSupported("ReadStreamStatus")
+}
+
+// GetNumDirectAccessBuffers returns how many direct access buffers can the stream provide. The file streams do not
+// support direct access.
+//
+// Return 0
+func (s *FileStream[T]) GetNumDirectAccessBuffers() uint {
+
+	return 0
+}
+
+// GetDirectAccessBufferAddrs is not supported by the file streams.
+func (s *FileStream[T]) GetDirectAccessBufferAddrs(handle uint) (buffers [][]T, err error) {
+
+	return nil, notSupported("GetDirectAccessBufferAddrs")
+}
+
+// AcquireReadBuffer is not supported by the file streams.
+func (s *FileStream[T]) AcquireReadBuffer(outputFlags []int, timeoutUs uint) (handle uint, buffers [][]T, timeNs uint, numElemsRead uint, err error) {
+
+	return 0, nil, 0, 0, notSupported("AcquireReadBuffer")
+}
+
+// ReleaseReadBuffer does nothing as the file streams do not support direct access.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireReadBuffer()
+func (s *FileStream[T]) ReleaseReadBuffer(handle uint) {
+}
+
+// AcquireWriteBuffer is not supported by the file streams.
+func (s *FileStream[T]) AcquireWriteBuffer(timeoutUs uint) (handle uint, buffers [][]T, numElems uint, err error) {
+
+	return 0, nil, 0, notSupported("AcquireWriteBuffer")
+}
+
+// ReleaseWriteBuffer does nothing as the file streams do not support direct access.
+//
+// Params:
+//  - handle: the opaque handle returned by AcquireWriteBuffer()
+//  - numElems: the number of elements written to each buffer
+//  - flags: input flags
+//  - timeNs: the buffer's timestamp in nanoseconds
+func (s *FileStream[T]) ReleaseWriteBuffer(handle uint, numElems uint, flags []int, timeNs uint) {
+}
@@ -0,0 +1,205 @@
+// Package sigmf records and plays back streams in the SigMF format (https://sigmf.org): the samples are stored raw in
+// a .sigmf-data file and described by a .sigmf-meta JSON file carrying the datatype, the sample rate, the capture
+// segments and the hardware which recorded them.
+package sigmf
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"os"
+)
+
+// Version is the version of the SigMF specification the metadata files are written with
+const Version = "1.0.0"
+
+// File extensions of a SigMF recording
+const (
+	// DataExtension is the extension of the file holding the samples
+	DataExtension = ".sigmf-data"
+	// MetaExtension is the extension of the file holding the metadata
+	MetaExtension = ".sigmf-meta"
+)
+
+// SoapyExtension is the SigMF extension declaring the fields of the "soapy" namespace written by the recorder: the
+// driver and the hardware of the device, and the gain and antenna of each capture segment.
+var SoapyExtension = Extension{Name: "soapy", Version: "1.0.0", Optional: true}
+
+// Extension declares a SigMF extension used by a recording
+type Extension struct {
+	// Name is the namespace of the extension
+	Name string `json:"name"`
+	// Version is the version of the extension
+	Version string `json:"version"`
+	// Optional is true if the recording can be read without understanding the extension
+	Optional bool `json:"optional"`
+}
+
+// Global holds the global object of a SigMF metadata file
+type Global struct {
+	// Datatype is the format of the samples, such as "cf32_le"
+	Datatype string `json:"core:datatype"`
+	// SampleRate is the sample rate in samples per second
+	SampleRate float64 `json:"core:sample_rate,omitempty"`
+	// Version is the version of the SigMF specification
+	Version string `json:"core:version"`
+	// NumChannels is the number of interleaved channels of the data file, 0 meaning 1
+	NumChannels uint `json:"core:num_channels,omitempty"`
+	// Description describes the recording
+	Description string `json:"core:description,omitempty"`
+	// Author is the author of the recording
+	Author string `json:"core:author,omitempty"`
+	// Recorder is the name of the software which made the recording
+	Recorder string `json:"core:recorder,omitempty"`
+	// Hardware describes the hardware which made the recording
+	Hardware string `json:"core:hw,omitempty"`
+	// Extensions are the extensions used by the recording
+	Extensions []Extension `json:"core:extensions,omitempty"`
+
+	// DriverKey is the key of the SoapySDR driver of the device
+	DriverKey string `json:"soapy:driver_key,omitempty"`
+	// HardwareKey is the hardware key of the device
+	HardwareKey string `json:"soapy:hardware_key,omitempty"`
+	// HardwareInfo is the hardware information of the device
+	HardwareInfo map[string]string `json:"soapy:hardware_info,omitempty"`
+}
+
+// Capture holds a capture segment of a SigMF metadata file: the parameters of the recording from a given sample
+type Capture struct {
+	// SampleStart is the index of the first sample of the segment
+	SampleStart uint64 `json:"core:sample_start"`
+	// Frequency is the center frequency of the segment in Hz
+	Frequency float64 `json:"core:frequency,omitempty"`
+	// Datetime is the time of the first sample of the segment, in ISO-8601 format
+	Datetime string `json:"core:datetime,omitempty"`
+
+	// Gain is the overall gain of the first channel in dB
+	Gain float64 `json:"soapy:gain"`
+	// Antenna is the antenna of the first channel
+	Antenna string `json:"soapy:antenna,omitempty"`
+}
+
+// Annotation holds an annotation of a SigMF metadata file
+type Annotation struct {
+	// SampleStart is the index of the first annotated sample
+	SampleStart uint64 `json:"core:sample_start"`
+	// SampleCount is the number of annotated samples
+	SampleCount uint64 `json:"core:sample_count,omitempty"`
+	// FreqLowerEdge is the lower frequency of the annotated signal in Hz
+	FreqLowerEdge float64 `json:"core:freq_lower_edge,omitempty"`
+	// FreqUpperEdge is the upper frequency of the annotated signal in Hz
+	FreqUpperEdge float64 `json:"core:freq_upper_edge,omitempty"`
+	// Label is a short label of the annotation
+	Label string `json:"core:label,omitempty"`
+	// Comment is a comment on the annotation
+	Comment string `json:"core:comment,omitempty"`
+}
+
+// Metadata is the content of a SigMF metadata file
+type Metadata struct {
+	// Global describes the whole recording
+	Global Global `json:"global"`
+	// Captures are the capture segments, in increasing order of first sample
+	Captures []Capture `json:"captures"`
+	// Annotations are the annotations of the recording
+	Annotations []Annotation `json:"annotations"`
+}
+
+// datatypes gives the SigMF datatype of the stream formats whose samples can be stored as is
+var datatypes = map[string]string{
+	device.FormatCF64: "cf64_le",
+	device.FormatCF32: "cf32_le",
+	device.FormatCS32: "ci32_le",
+	device.FormatCU32: "cu32_le",
+	device.FormatCS16: "ci16_le",
+	device.FormatCU16: "cu16_le",
+	device.FormatCS8:  "ci8",
+	device.FormatCU8:  "cu8",
+	device.FormatF64:  "rf64_le",
+	device.FormatF32:  "rf32_le",
+	device.FormatS32:  "ri32_le",
+	device.FormatU32:  "ru32_le",
+	device.FormatS16:  "ri16_le",
+	device.FormatU16:  "ru16_le",
+	device.FormatS8:   "ri8",
+	device.FormatU8:   "ru8",
+}
+
+// Datatype returns the SigMF datatype of a stream format.
+//
+// Params:
+//  - format: the stream format, such as "CS16"
+//
+// Return the datatype, such as "ci16_le", or an error if the format has no SigMF equivalent, which is the case of the
+// packed formats
+func Datatype(format string) (datatype string, err error) {
+
+	datatype, found := datatypes[format]
+	if !found {
+		return "", fmt.Errorf("the stream format %v has no SigMF datatype", format)
+	}
+
+	return datatype, nil
+}
+
+// Format returns the stream format of a SigMF datatype.
+//
+// Params:
+//  - datatype: the datatype, such as "ci16_le"
+//
+// Return the stream format, such as "CS16", or an error if the datatype has no stream format equivalent, which is the
+// case of the big-endian datatypes
+func Format(datatype string) (format string, err error) {
+
+	for format, known := range datatypes {
+		if known == datatype {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("the SigMF datatype %v has no stream format", datatype)
+}
+
+// ReadMetadata reads a SigMF metadata file.
+//
+// Params:
+//  - path: the path of the metadata file
+//
+// Return the metadata or an error
+func ReadMetadata(path string) (*Metadata, error) {
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &Metadata{}
+	if err := json.Unmarshal(content, meta); err != nil {
+		return nil, fmt.Errorf("invalid SigMF metadata file %v: %w", path, err)
+	}
+
+	return meta, nil
+}
+
+// Write writes the metadata to a SigMF metadata file.
+//
+// Params:
+//  - path: the path of the metadata file
+//
+// Return an error or nil in case of success
+func (meta *Metadata) Write(path string) error {
+
+	if meta.Captures == nil {
+		meta.Captures = []Capture{}
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = []Annotation{}
+	}
+
+	content, err := json.MarshalIndent(meta, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
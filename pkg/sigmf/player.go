@@ -0,0 +1,74 @@
+package sigmf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"io"
+)
+
+// Play transmits a SigMF recording on an activated transmit stream, as a single burst. The recording is read with a
+// FileStream, and so converted to the format of the stream if needed, and written as fast as the stream accepts it:
+// the device must be configured beforehand with the sample rate of the recording, given by ReadMetadata(). The
+// writes are made by a device.BurstWriter: the first one carries StreamFlagHasTime when a time is given and only the
+// one which completes the burst StreamFlagEndBurst; the end of the burst can be awaited with a StatusMonitor.
+//
+// Params:
+//  - ctx: the context of the playback
+//  - basePath: the path of the recording without extension
+//  - stream: the transmit stream, with as many channels as the recording
+//  - atTimeNs: the time of the first sample in nanoseconds, 0 to transmit the recording as soon as possible
+//
+// Return the number of samples of each channel transmitted and an error, which is the error of the context if it is
+// done before the end of the recording
+func Play[T device.Sample](ctx context.Context, basePath string, stream device.TypedStream[T], atTimeNs uint) (numSamples uint64, err error) {
+
+	file, err := OpenFileStream[T](basePath, FileStreamConfig{MTU: stream.GetMTU()})
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	if file.GetFormat() != stream.GetFormat() {
+		return 0, fmt.Errorf("the recording is read in %v format, the stream has the %v format", file.GetFormat(), stream.GetFormat())
+	}
+	nbChannels := stream.GetNumChannels()
+	if file.GetNumChannels() != nbChannels {
+		return 0, fmt.Errorf("the recording has %v channels, the stream has %v channels", file.GetNumChannels(), nbChannels)
+	}
+
+	if err := file.Activate(0, 0, 0); err != nil {
+		return 0, err
+	}
+
+	writer, err := device.NewBurstWriter[T](stream, atTimeNs)
+	if err != nil {
+		return 0, err
+	}
+
+	elemsPerSample, _ := device.FormatElemsPerSample[T](stream.GetFormat())
+	mtu := uint(file.GetMTU())
+	buffers := make([][]T, nbChannels)
+	for channelIdx := range buffers {
+		buffers[channelIdx] = make([]T, mtu*elemsPerSample)
+	}
+	readFlags := make([]int, nbChannels)
+
+	for {
+		_, numElemsRead, err := file.Read(buffers, mtu, readFlags, 0)
+		if errors.Is(err, io.EOF) {
+			return numSamples, nil
+		}
+		if err != nil {
+			return numSamples, err
+		}
+
+		endBurst := device.StreamFlag(readFlags[0]).Has(device.StreamFlagEndBurst)
+		numElemsWritten, err := writer.Write(ctx, buffers, numElemsRead, endBurst)
+		numSamples += uint64(numElemsWritten)
+		if err != nil || endBurst {
+			return numSamples, err
+		}
+	}
+}
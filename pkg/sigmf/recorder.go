@@ -0,0 +1,240 @@
+package sigmf
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// recorderName is the name of the recorder written in the metadata
+const recorderName = "go-soapy-sdr"
+
+// recorderTimeoutUs is the timeout of each read of the stream by the recorder in microseconds. It bounds the delay
+// between the cancellation of the context of a recording and its end.
+const recorderTimeoutUs = 100000
+
+// recorderChunkSize is the size in bytes of the chunks of samples read from the stream at once
+const recorderChunkSize = 1 << 16
+
+// DeviceInfo is the part of the device API read by the recorder to describe the recording. SDRDevice, SyncDevice and
+// the simulated device implement it.
+type DeviceInfo interface {
+	device.IdentificationAPI
+	device.AntennaAPI
+	device.GainAPI
+	device.FrequencyAPI
+	device.SampleRateAPI
+}
+
+// Recorder records a receive stream to a SigMF recording. The metadata are populated from the device: the datatype
+// from the format of the stream, the sample rate, the driver and the hardware when the recorder is created, and a
+// capture segment with the frequency, the gain and the antenna of the first channel of the stream each time one of
+// them changes during the recording.
+//
+// A Recorder is not safe for concurrent use.
+type Recorder[T device.Sample] struct {
+	dev       DeviceInfo
+	channel   uint
+	reader    *device.SampleReader[T]
+	dataFile  *os.File
+	data      *bufio.Writer
+	metaPath  string
+	meta      Metadata
+	frameSize uint64
+	nbBytes   uint64
+	chunk     []byte
+	closed    bool
+}
+
+// NewRecorder creates a SigMF recording and a recorder reading a receive stream into it. The stream must be activated
+// before recording.
+//
+// Params:
+//  - basePath: the path of the recording without extension. The files basePath.sigmf-data and basePath.sigmf-meta are
+//    created, overwriting any existing file.
+//  - dev: the device of the stream
+//  - stream: the receive stream
+//  - channels: the channels of the stream, as given to its setup
+//
+// Return the recorder or an error
+func NewRecorder[T device.Sample](basePath string, dev DeviceInfo, stream device.TypedStream[T], channels []uint) (*Recorder[T], error) {
+
+	if uint(len(channels)) != stream.GetNumChannels() {
+		return nil, fmt.Errorf("%v channels given for a stream of %v channels", len(channels), stream.GetNumChannels())
+	}
+
+	datatype, err := Datatype(stream.GetFormat())
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := device.NewSampleReader[T](stream, recorderTimeoutUs)
+	if err != nil {
+		return nil, err
+	}
+
+	var elem T
+	elemsPerSample, _ := device.FormatElemsPerSample[T](stream.GetFormat())
+
+	dataFile, err := os.Create(basePath + DataExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := &Recorder[T]{
+		dev:       dev,
+		channel:   channels[0],
+		reader:    reader,
+		dataFile:  dataFile,
+		data:      bufio.NewWriterSize(dataFile, recorderChunkSize),
+		metaPath:  basePath + MetaExtension,
+		frameSize: uint64(len(channels)) * uint64(elemsPerSample) * uint64(unsafe.Sizeof(elem)),
+		chunk:     make([]byte, recorderChunkSize),
+	}
+
+	recorder.meta.Global = Global{
+		Datatype:     datatype,
+		SampleRate:   dev.GetSampleRate(device.DirectionRX, channels[0]),
+		Version:      Version,
+		Recorder:     recorderName,
+		Hardware:     hardwareDescription(dev.GetHardwareKey(), dev.GetHardwareInfo()),
+		Extensions:   []Extension{SoapyExtension},
+		DriverKey:    dev.GetDriverKey(),
+		HardwareKey:  dev.GetHardwareKey(),
+		HardwareInfo: dev.GetHardwareInfo(),
+	}
+	if len(channels) > 1 {
+		recorder.meta.Global.NumChannels = uint(len(channels))
+	}
+
+	return recorder, nil
+}
+
+// Metadata returns the metadata of the recording, which can be completed (description, annotations...) before the
+// recorder is closed
+func (recorder *Recorder[T]) Metadata() *Metadata {
+
+	return &recorder.meta
+}
+
+// NumSamples returns the number of samples of each channel recorded so far
+func (recorder *Recorder[T]) NumSamples() uint64 {
+
+	return recorder.nbBytes / recorder.frameSize
+}
+
+// Record reads the stream and records its samples until the given number of samples is recorded, the context is done
+// or the stream fails. A new capture segment is added when the frequency, the gain or the antenna of the first channel
+// changed since the previous segment. Record can be called several times to extend the recording.
+//
+// Params:
+//  - ctx: the context of the recording
+//  - numSamples: the number of samples of each channel to record, 0 to record until the context is done
+//
+// Return the number of samples of each channel recorded by the call and an error, which is the error of the context if
+// it is done before numSamples are recorded
+func (recorder *Recorder[T]) Record(ctx context.Context, numSamples uint64) (recorded uint64, err error) {
+
+	if recorder.closed {
+		return 0, errors.New("the recorder is closed")
+	}
+
+	start := recorder.NumSamples()
+	end := uint64(0)
+	if numSamples > 0 {
+		end = recorder.nbBytes + numSamples*recorder.frameSize
+	}
+
+	for end == 0 || recorder.nbBytes < end {
+
+		recorder.updateCapture()
+
+		chunk := recorder.chunk
+		if end > 0 && uint64(len(chunk)) > end-recorder.nbBytes {
+			chunk = chunk[:end-recorder.nbBytes]
+		}
+
+		n, err := recorder.reader.ReadContext(ctx, chunk)
+		if n > 0 {
+			if _, err := recorder.data.Write(chunk[:n]); err != nil {
+				return recorder.NumSamples() - start, err
+			}
+			recorder.nbBytes += uint64(n)
+		}
+		if err != nil {
+			return recorder.NumSamples() - start, err
+		}
+	}
+
+	return recorder.NumSamples() - start, nil
+}
+
+// Close ends the recording: it flushes the data file and writes the metadata file.
+//
+// Return an error or nil in case of success
+func (recorder *Recorder[T]) Close() error {
+
+	if recorder.closed {
+		return nil
+	}
+	recorder.closed = true
+
+	err := recorder.data.Flush()
+	if closeErr := recorder.dataFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return recorder.meta.Write(recorder.metaPath)
+}
+
+// updateCapture adds a capture segment if the first channel was retuned since the previous segment
+func (recorder *Recorder[T]) updateCapture() {
+
+	capture := Capture{
+		SampleStart: recorder.NumSamples(),
+		Frequency:   recorder.dev.GetFrequency(device.DirectionRX, recorder.channel),
+		Gain:        recorder.dev.GetGain(device.DirectionRX, recorder.channel),
+		Antenna:     recorder.dev.GetAntennas(device.DirectionRX, recorder.channel),
+	}
+
+	captures := recorder.meta.Captures
+	if len(captures) > 0 {
+		last := &captures[len(captures)-1]
+		if last.Frequency == capture.Frequency && last.Gain == capture.Gain && last.Antenna == capture.Antenna {
+			return
+		}
+		// A segment which did not receive any sample is replaced
+		if last.SampleStart == capture.SampleStart {
+			recorder.meta.Captures = captures[:len(captures)-1]
+		}
+	}
+
+	capture.Datetime = time.Now().UTC().Format(time.RFC3339Nano)
+	recorder.meta.Captures = append(recorder.meta.Captures, capture)
+}
+
+// hardwareDescription describes the hardware of a device for the core:hw field
+func hardwareDescription(hardwareKey string, hardwareInfo map[string]string) string {
+
+	pairs := make([]string, 0, len(hardwareInfo))
+	for key, value := range hardwareInfo {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+
+	if len(pairs) == 0 {
+		return hardwareKey
+	}
+
+	return hardwareKey + " (" + strings.Join(pairs, ", ") + ")"
+}
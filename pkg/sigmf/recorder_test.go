@@ -0,0 +1,210 @@
+package sigmf_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/sigmf"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// teeStream is a receive stream keeping a copy of the bytes of the first channel of every read
+type teeStream struct {
+	device.TypedStream[int16]
+	received []byte
+}
+
+func (s *teeStream) Read(buffers [][]int16, nbElems uint, outputFlags []int, timeoutUs uint) (timeNs uint, numElemsRead uint, err error) {
+
+	timeNs, numElemsRead, err = s.TypedStream.Read(buffers, nbElems, outputFlags, timeoutUs)
+	s.received = append(s.received, convert.Bytes(buffers[0][:2*numElemsRead])...)
+
+	return timeNs, numElemsRead, err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+
+	const numSamples = 5000
+
+	dev, stream := simtest.NewActiveRXStream[int16](t, sim.Config{})
+	tee := &teeStream{TypedStream: stream}
+	basePath := filepath.Join(t.TempDir(), "recording")
+
+	recorder, err := sigmf.NewRecorder[int16](basePath, dev, tee, []uint{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := recorder.Record(ctx, numSamples); err != nil {
+		t.Fatal(err)
+	}
+	frequency := dev.GetFrequency(device.DirectionRX, 0) + 1e6
+	if err := dev.SetFrequency(device.DirectionRX, 0, frequency, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.Record(ctx, numSamples); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The data file holds the samples read from the stream
+	data, err := os.ReadFile(basePath + sigmf.DataExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2*numSamples*4 || !bytes.Equal(data, tee.received[:len(data)]) {
+		t.Fatalf("the data file holds %v bytes which differ from the %v bytes read", len(data), len(tee.received))
+	}
+
+	// The metadata describe the stream and the retune
+	meta, err := sigmf.ReadMetadata(basePath + sigmf.MetaExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Global.Datatype != "ci16_le" {
+		t.Errorf("datatype %v, expected ci16_le", meta.Global.Datatype)
+	}
+	if len(meta.Captures) != 2 {
+		t.Fatalf("%v capture segments, expected 2", len(meta.Captures))
+	}
+	if meta.Captures[0].SampleStart != 0 || meta.Captures[1].SampleStart != numSamples {
+		t.Errorf("capture segments starting at %v and %v", meta.Captures[0].SampleStart, meta.Captures[1].SampleStart)
+	}
+	if meta.Captures[1].Frequency != frequency {
+		t.Errorf("second capture segment at %v Hz, expected %v Hz", meta.Captures[1].Frequency, frequency)
+	}
+
+	// The file stream replays the data, stamped with the datetime of the capture segments
+	file, err := sigmf.OpenFileStream[int16](basePath, sigmf.FileStreamConfig{MTU: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := file.Activate(0, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var replayed []byte
+	buffers := [][]int16{make([]int16, 2*1000)}
+	flags := make([]int, 1)
+	for {
+		timeNs, numElemsRead, err := file.Read(buffers, 1000, flags, 0)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index := len(replayed) / 4
+		if index == 0 || index == numSamples {
+			capture := meta.Captures[index/numSamples]
+			start, err := time.Parse(time.RFC3339Nano, capture.Datetime)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if timeNs != uint(start.UnixNano()) {
+				t.Errorf("read at sample %v stamped %v, expected %v", index, timeNs, start.UnixNano())
+			}
+		}
+		replayed = append(replayed, convert.Bytes(buffers[0][:2*numElemsRead])...)
+	}
+
+	if !bytes.Equal(replayed, data) {
+		t.Errorf("%v bytes replayed, which differ from the %v bytes of the data file", len(replayed), len(data))
+	}
+}
+
+func TestRecorderMultiChannel(t *testing.T) {
+
+	const numSamples = 1000
+
+	dev, stream := simtest.NewActiveRXStream[int16](t, sim.Config{NumRXChannels: 2})
+	basePath := filepath.Join(t.TempDir(), "recording")
+
+	recorder, err := sigmf.NewRecorder[int16](basePath, dev, stream, []uint{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A sample of each channel is 2 elements of 2 bytes
+	if recorded, err := recorder.Record(ctx, numSamples); recorded != numSamples || err != nil {
+		t.Fatalf("%v samples recorded: %v", recorded, err)
+	}
+	if recorder.NumSamples() != numSamples {
+		t.Errorf("%v samples recorded, expected %v", recorder.NumSamples(), numSamples)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(basePath + sigmf.DataExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != numSamples*2*2*2 {
+		t.Errorf("the data file holds %v bytes, expected %v", info.Size(), numSamples*2*2*2)
+	}
+
+	raw, err := os.ReadFile(basePath + sigmf.MetaExtension)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(raw, []byte(`"core:num_channels": 2`)) {
+		t.Errorf("the metadata do not hold 2 channels: %s", raw)
+	}
+}
+
+func TestRecorderRetuneBeforeSamples(t *testing.T) {
+
+	dev, stream := simtest.NewActiveRXStream[int16](t, sim.Config{})
+	basePath := filepath.Join(t.TempDir(), "recording")
+
+	recorder, err := sigmf.NewRecorder[int16](basePath, dev, stream, []uint{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Close()
+
+	// A recording cancelled before any sample opens a capture segment which receives no sample
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if recorded, err := recorder.Record(cancelled, 1000); recorded != 0 || !errors.Is(err, context.Canceled) {
+		t.Fatalf("%v samples recorded with a cancelled context: %v", recorded, err)
+	}
+	if captures := recorder.Metadata().Captures; len(captures) != 1 {
+		t.Fatalf("%v capture segments, expected 1", len(captures))
+	}
+
+	// The untouched segment is replaced by the segment of the new frequency
+	frequency := dev.GetFrequency(device.DirectionRX, 0) + 1e6
+	if err := dev.SetFrequency(device.DirectionRX, 0, frequency, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancelRecord := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelRecord()
+	if _, err := recorder.Record(ctx, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	captures := recorder.Metadata().Captures
+	if len(captures) != 1 || captures[0].SampleStart != 0 || captures[0].Frequency != frequency {
+		t.Errorf("capture segments %+v, expected a single segment at %v Hz", captures, frequency)
+	}
+}
@@ -0,0 +1,343 @@
+// Package vita49 converts the samples of streams to VITA Radio Transport (VITA-49.0) packets, to feed the systems
+// receiving them, usually over UDP: IF data packets carrying the samples of a channel with their timestamp, and IF
+// context packets describing the tuning of the channel. A decoder of the packets is provided for the receiving side
+// and for round-trip tests.
+//
+// The packets are in network order (big-endian), without trailer. The packetiser adds a class ID to the IF data
+// packets only to give the number of pad bits ending a payload which does not fill whole words.
+package vita49
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"math"
+	"unsafe"
+)
+
+// hostLittleEndian is true if the host stores the numbers in little-endian order
+var hostLittleEndian = *(*uint16)(unsafe.Pointer(&[2]byte{1, 0})) == 1
+
+// PacketType is the type of a packet, given by the 4 high bits of its header
+type PacketType uint8
+
+const (
+	// PacketTypeIFData is the type of the IF data packets with a stream ID, carrying samples
+	PacketTypeIFData PacketType = 0x1
+	// PacketTypeIFContext is the type of the IF context packets, describing the samples of the IF data packets of the
+	// same stream ID
+	PacketTypeIFContext PacketType = 0x4
+)
+
+// TSI is the type of the integer timestamp of a packet
+type TSI uint8
+
+const (
+	// TSINone means that the packet has no integer timestamp
+	TSINone TSI = iota
+	// TSIUTC is a number of seconds since the UTC epoch
+	TSIUTC
+	// TSIGPS is a number of seconds since the GPS epoch
+	TSIGPS
+	// TSIOther is a number of seconds of another time base
+	TSIOther
+)
+
+// TSF is the type of the fractional timestamp of a packet
+type TSF uint8
+
+const (
+	// TSFNone means that the packet has no fractional timestamp
+	TSFNone TSF = iota
+	// TSFSampleCount is a number of samples since the integer timestamp
+	TSFSampleCount
+	// TSFRealTime is a number of picoseconds since the integer timestamp
+	TSFRealTime
+	// TSFFreeRunning is a free running count of samples
+	TSFFreeRunning
+)
+
+// Context indicator bits of the CIF0 word of a context packet
+const (
+	// CIFChangeIndicator is set when a field of the context changed since the previous context packet
+	CIFChangeIndicator uint32 = 1 << 31
+	// CIFBandwidth indicates the bandwidth field
+	CIFBandwidth uint32 = 1 << 29
+	// CIFRFReferenceFrequency indicates the RF reference frequency field, the center frequency of the channel
+	CIFRFReferenceFrequency uint32 = 1 << 27
+	// CIFGain indicates the gain field
+	CIFGain uint32 = 1 << 23
+	// CIFSampleRate indicates the sample rate field
+	CIFSampleRate uint32 = 1 << 21
+)
+
+// cifFieldSizes gives the size in words of the fields of the CIF0 word from bit 30 to bit 21, which are the fields the
+// decoder can skip or read
+var cifFieldSizes = map[uint32]int{
+	1 << 30:                 1,
+	CIFBandwidth:            2,
+	1 << 28:                 2,
+	CIFRFReferenceFrequency: 2,
+	1 << 26:                 2,
+	1 << 25:                 2,
+	1 << 24:                 1,
+	CIFGain:                 1,
+	1 << 22:                 1,
+	CIFSampleRate:           2,
+}
+
+// headerPacketSizeMax is the maximum size of a packet in words, given by the 16-bit size field of the header
+const headerPacketSizeMax = 0xffff
+
+// Indicator bits of the header of a packet
+const (
+	// headerClassID is set when the packet carries a class ID
+	headerClassID uint32 = 1 << 27
+	// headerTrailer is set when an IF data packet carries a trailer
+	headerTrailer uint32 = 1 << 26
+)
+
+// Context is the content of an IF context packet. Only the fields whose indicator is set in Indicators are valid.
+type Context struct {
+	// Indicators is the CIF0 word: the context indicator bits of the fields present in the packet, and
+	// CIFChangeIndicator
+	Indicators uint32
+	// Bandwidth is the bandwidth in Hz
+	Bandwidth float64
+	// RFReferenceFrequency is the center frequency in Hz
+	RFReferenceFrequency float64
+	// Gain is the gain in dB, with a resolution of 1/128 dB
+	Gain float64
+	// SampleRate is the sample rate in samples per second
+	SampleRate float64
+}
+
+// Packet is a decoded packet
+type Packet struct {
+	// Type is the type of the packet
+	Type PacketType
+	// Count is the 4-bit counter of the packets of the stream
+	Count uint8
+	// StreamID is the stream ID of the packet
+	StreamID uint32
+	// HasClassID is true if the packet carries a class ID
+	HasClassID bool
+	// PadBits is the number of pad bits ending the payload, given by the class ID. They are removed from Payload.
+	PadBits uint8
+	// OUI is the organizationally unique identifier of the class ID
+	OUI uint32
+	// InformationClassCode is the information class code of the class ID
+	InformationClassCode uint16
+	// PacketClassCode is the packet class code of the class ID
+	PacketClassCode uint16
+	// TSI is the type of the integer timestamp
+	TSI TSI
+	// TSF is the type of the fractional timestamp
+	TSF TSF
+	// IntegerTimestamp is the integer timestamp, valid if TSI is not TSINone
+	IntegerTimestamp uint32
+	// FractionalTimestamp is the fractional timestamp, valid if TSF is not TSFNone
+	FractionalTimestamp uint64
+	// Payload is the payload of an IF data packet: the samples in network order. It shares the memory of the
+	// decoded bytes.
+	Payload []byte
+	// Context is the content of an IF context packet
+	Context Context
+}
+
+// TimeNs returns the timestamp of a packet with a UTC, GPS or other integer timestamp and a real-time fractional
+// timestamp.
+//
+// Return the timestamp in nanoseconds, and false if the packet has no such timestamp
+func (packet *Packet) TimeNs() (timeNs uint, valid bool) {
+
+	if packet.TSI == TSINone || packet.TSF != TSFRealTime {
+		return 0, false
+	}
+
+	return uint(packet.IntegerTimestamp)*1e9 + uint(packet.FractionalTimestamp/1000), true
+}
+
+// Decode decodes an IF data packet or an IF context packet.
+//
+// Params:
+//  - data: the bytes of the packet, such as the content of a UDP datagram
+//
+// Return the packet, sharing the memory of data, or an error if the packet is truncated, of another type or carries
+// a context field which is not supported
+func Decode(data []byte) (packet Packet, err error) {
+
+	if len(data) < 8 {
+		return packet, errors.New("truncated VITA-49 packet")
+	}
+
+	header := binary.BigEndian.Uint32(data)
+	packet.Type = PacketType(header >> 28)
+	packet.TSI = TSI(header >> 22 & 0x3)
+	packet.TSF = TSF(header >> 20 & 0x3)
+	packet.Count = uint8(header >> 16 & 0xf)
+
+	size := int(header&0xffff) * 4
+	if size < 8 || size > len(data) {
+		return packet, fmt.Errorf("VITA-49 packet of %v bytes received in %v bytes", size, len(data))
+	}
+	if packet.Type != PacketTypeIFData && packet.Type != PacketTypeIFContext {
+		return packet, fmt.Errorf("VITA-49 packets of type %v are not supported", packet.Type)
+	}
+
+	words := data[4:size]
+	packet.StreamID, words = binary.BigEndian.Uint32(words), words[4:]
+
+	if header&headerClassID != 0 {
+		if len(words) < 8 {
+			return packet, errors.New("truncated VITA-49 packet")
+		}
+		classID := binary.BigEndian.Uint64(words)
+		packet.HasClassID = true
+		packet.PadBits = uint8(classID >> 59)
+		packet.OUI = uint32(classID>>32) & 0xffffff
+		packet.InformationClassCode = uint16(classID >> 16)
+		packet.PacketClassCode = uint16(classID)
+		words = words[8:]
+	}
+
+	if packet.TSI != TSINone {
+		if len(words) < 4 {
+			return packet, errors.New("truncated VITA-49 packet")
+		}
+		packet.IntegerTimestamp, words = binary.BigEndian.Uint32(words), words[4:]
+	}
+	if packet.TSF != TSFNone {
+		if len(words) < 8 {
+			return packet, errors.New("truncated VITA-49 packet")
+		}
+		packet.FractionalTimestamp, words = binary.BigEndian.Uint64(words), words[8:]
+	}
+
+	if packet.Type == PacketTypeIFData {
+		if header&headerTrailer != 0 && len(words) >= 4 {
+			words = words[:len(words)-4]
+		}
+		if int(packet.PadBits/8) > len(words) {
+			return packet, fmt.Errorf("VITA-49 packet of %v pad bits with a payload of %v bytes", packet.PadBits, len(words))
+		}
+		packet.Payload = words[:len(words)-int(packet.PadBits/8)]
+		return packet, nil
+	}
+
+	packet.Context, err = decodeContext(words)
+
+	return packet, err
+}
+
+// decodeContext decodes the CIF0 word and the context fields of an IF context packet
+func decodeContext(words []byte) (context Context, err error) {
+
+	if len(words) < 4 {
+		return context, errors.New("truncated VITA-49 context packet")
+	}
+	context.Indicators, words = binary.BigEndian.Uint32(words), words[4:]
+
+	for bit := uint32(1 << 30); bit != 0; bit >>= 1 {
+
+		if context.Indicators&bit == 0 {
+			continue
+		}
+		nbWords, found := cifFieldSizes[bit]
+		if !found {
+			return context, fmt.Errorf("unsupported VITA-49 context field %#08x", bit)
+		}
+		if len(words) < 4*nbWords {
+			return context, errors.New("truncated VITA-49 context packet")
+		}
+
+		switch bit {
+		case CIFBandwidth:
+			context.Bandwidth = fromFixed64(binary.BigEndian.Uint64(words))
+		case CIFRFReferenceFrequency:
+			context.RFReferenceFrequency = fromFixed64(binary.BigEndian.Uint64(words))
+		case CIFGain:
+			context.Gain = float64(int16(binary.BigEndian.Uint32(words))) / (1 << 7)
+		case CIFSampleRate:
+			context.SampleRate = fromFixed64(binary.BigEndian.Uint64(words))
+		}
+		words = words[4*nbWords:]
+	}
+
+	return context, nil
+}
+
+// toFixed64 converts a frequency to the 64-bit fixed point format of the context fields, with a radix point at bit 20
+func toFixed64(value float64) uint64 {
+
+	return uint64(int64(math.Round(value * (1 << 20))))
+}
+
+// fromFixed64 converts a frequency from the 64-bit fixed point format of the context fields
+func fromFixed64(value uint64) float64 {
+
+	return float64(int64(value)) / (1 << 20)
+}
+
+// toFixedGain converts a gain to the 16-bit fixed point format of the gain field, with a radix point at bit 7
+func toFixedGain(gain float64) uint16 {
+
+	return uint16(int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(gain*(1<<7))))))
+}
+
+// componentSize returns the size in bytes of the numbers making the elements of type T: the size of I or Q for the
+// complex types, the size of the element otherwise
+func componentSize[T device.Sample]() int {
+
+	var elem T
+
+	switch any(elem).(type) {
+	case complex64, complex128, device.CU8, device.CS8, device.CU16, device.CS16, device.CU32, device.CS32:
+		return int(unsafe.Sizeof(elem)) / 2
+	}
+
+	return int(unsafe.Sizeof(elem))
+}
+
+// swapComponents copies numbers from src to dst, converting them between the host order and the network order.
+//
+// Params:
+//  - dst: the destination bytes
+//  - src: the source bytes, as long as dst
+//  - size: the size of the numbers in bytes
+func swapComponents(dst []byte, src []byte, size int) {
+
+	if !hostLittleEndian || size == 1 {
+		copy(dst, src)
+		return
+	}
+
+	for offset := 0; offset+size <= len(src); offset += size {
+		for i := 0; i < size; i++ {
+			dst[offset+i] = src[offset+size-1-i]
+		}
+	}
+}
+
+// DecodeSamples decodes the samples of the payload of an IF data packet.
+//
+// Params:
+//  - dst: the elements receiving the samples, in the format of the stream which was packetised
+//  - payload: the payload of the packet
+//
+// Return the number of elements decoded, limited by the length of dst
+func DecodeSamples[T device.Sample](dst []T, payload []byte) int {
+
+	var elem T
+
+	nbElems := len(payload) / int(unsafe.Sizeof(elem))
+	if nbElems > len(dst) {
+		nbElems = len(dst)
+	}
+
+	swapComponents(convert.Bytes(dst[:nbElems]), payload[:nbElems*int(unsafe.Sizeof(elem))], componentSize[T]())
+
+	return nbElems
+}
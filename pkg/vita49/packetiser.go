@@ -0,0 +1,392 @@
+package vita49
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/pothosware/go-soapy-sdr/pkg/convert"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrerror"
+	"github.com/pothosware/go-soapy-sdr/pkg/sdrlogger"
+	"io"
+	"math"
+	"syscall"
+	"unsafe"
+)
+
+// defaultMaxPacketSize is the default maximum size of the packets in bytes: the largest UDP payload of an IPv4
+// datagram in a standard Ethernet frame
+const defaultMaxPacketSize = 1472
+
+// defaultContextPeriod is the default number of IF data packets of a channel between its IF context packets
+const defaultContextPeriod = 64
+
+// contextFieldsWords is the size in words of the CIF0 word and of the fields of the IF context packets
+const contextFieldsWords = 8
+
+// classIDWords is the size in words of the class ID of a packet
+const classIDWords = 2
+
+// ContextDevice is the part of the device API read to fill the IF context packets. SDRDevice, SyncDevice and the
+// simulated device implement it.
+type ContextDevice interface {
+	device.FrequencyAPI
+	device.SampleRateAPI
+	device.BandwidthAPI
+	device.GainAPI
+}
+
+// Config is the configuration of a packetiser
+type Config struct {
+	// StreamID is the stream ID of the packets of the first channel. The packets of the channel at index i of the
+	// stream use StreamID + i.
+	StreamID uint32
+	// MaxPacketSize is the maximum size of the packets in bytes. Default is 1472, which fits a UDP datagram in a
+	// standard Ethernet frame.
+	MaxPacketSize int
+	// ContextPeriod is the number of IF data packets of a channel between its IF context packets. Default is 64.
+	ContextPeriod uint
+	// IntegerTimestamp is the type of the integer timestamp of the packets, which depends on the time base of the
+	// device. Default is TSIUTC.
+	IntegerTimestamp TSI
+	// Format is the format of the stream. Default is the default format of the streams of elements of type T, see
+	// device.StreamFormat().
+	Format string
+}
+
+// channelState is the state of the packets of a channel
+type channelState struct {
+	dataCount           uint8
+	contextCount        uint8
+	packetsSinceContext uint
+	hasContext          bool
+	context             Context
+}
+
+// Packetiser converts the samples read from the receive channels of a stream to IF data packets, one stream ID per
+// channel, and sends before them IF context packets describing the channels, every ContextPeriod data packets. The
+// context packets carry the frequency, the sample rate, the bandwidth and the gain of the channel, read from the
+// device when the packet is sent.
+//
+// The samples are sent in the format of the stream, each number in network order. The IF data packets hold a whole
+// number of words of samples, except the last packet of a read, whose payload is padded with zero bits: this packet
+// then carries a class ID giving the number of pad bits, with a null OUI and null class codes.
+//
+// A Packetiser is not safe for concurrent use.
+type Packetiser[T device.Sample] struct {
+	dev              ContextDevice
+	channels         []uint
+	config           Config
+	elemsPerSample   uint
+	samplesPerPacket uint
+	states           []channelState
+	packet           []byte
+}
+
+// NewPacketiser creates a packetiser.
+//
+// Params:
+//  - dev: the device of the stream
+//  - channels: the receive channels of the stream, in the order of the buffers of the reads
+//  - config: the configuration of the packetiser
+//
+// Return the packetiser or an error if the format is not supported or if a sample does not fit in a packet
+func NewPacketiser[T device.Sample](dev ContextDevice, channels []uint, config Config) (*Packetiser[T], error) {
+
+	var elem T
+
+	if len(channels) == 0 {
+		return nil, errors.New("the packetiser needs at least one channel")
+	}
+	if config.MaxPacketSize == 0 {
+		config.MaxPacketSize = defaultMaxPacketSize
+	}
+	if config.ContextPeriod == 0 {
+		config.ContextPeriod = defaultContextPeriod
+	}
+	if config.IntegerTimestamp == TSINone {
+		config.IntegerTimestamp = TSIUTC
+	}
+	if config.Format == "" {
+		var err error
+		if config.Format, _, err = device.StreamFormat[T](); err != nil {
+			return nil, err
+		}
+	}
+
+	elemsPerSample, err := device.FormatElemsPerSample[T](config.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	maxWords := config.MaxPacketSize / 4
+	if maxWords > headerPacketSizeMax {
+		maxWords = headerPacketSizeMax
+	}
+	// The packets which are not the last one of a read hold a whole number of words of samples
+	sampleSize := int(elemsPerSample) * int(unsafe.Sizeof(elem))
+	wordSamples := 4 / gcd(sampleSize, 4)
+	samplesPerPacket := (maxWords - headerWords(true, true)) * 4 / sampleSize
+	samplesPerPacket -= samplesPerPacket % wordSamples
+	if samplesPerPacket <= 0 || maxWords < headerWords(true, false)+contextFieldsWords {
+		return nil, fmt.Errorf("packets of %v bytes are too small for the samples of format %v", config.MaxPacketSize, config.Format)
+	}
+
+	return &Packetiser[T]{
+		dev:              dev,
+		channels:         append([]uint(nil), channels...),
+		config:           config,
+		elemsPerSample:   elemsPerSample,
+		samplesPerPacket: uint(samplesPerPacket),
+		states:           make([]channelState, len(channels)),
+		packet:           make([]byte, 4*maxWords),
+	}, nil
+}
+
+// headerWords returns the size in words of the header, the stream ID, the class ID and the timestamps of a packet
+func headerWords(hasTime bool, hasClassID bool) int {
+
+	words := 2
+	if hasClassID {
+		words += classIDWords
+	}
+	if hasTime {
+		words += 3
+	}
+
+	return words
+}
+
+// gcd returns the greatest common divisor of two positive integers
+func gcd(a int, b int) int {
+
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// StreamID returns the stream ID of the packets of a channel.
+//
+// Params:
+//  - index: the index of the channel in the channels of the packetiser
+//
+// Return the stream ID
+func (p *Packetiser[T]) StreamID(index int) uint32 {
+
+	return p.config.StreamID + uint32(index)
+}
+
+// SamplesPerPacket returns the maximum number of samples of the IF data packets
+func (p *Packetiser[T]) SamplesPerPacket() uint {
+
+	return p.samplesPerPacket
+}
+
+// Packetise converts the samples of a read to packets. The packets of the channels follow each other: the packets of
+// the first channel, then the packets of the second channel...
+//
+// Params:
+//  - buffers: the buffers of the read, one per channel
+//  - numElems: the number of elements read per channel
+//  - flags: the flags of the read. The packets carry the timestamp of their first sample if StreamFlagHasTime is set,
+//    no timestamp otherwise.
+//  - timeNs: the timestamp of the read in nanoseconds
+//  - emit: the function called with each packet, which is valid until emit returns
+//
+// Return an error if the buffers do not match the channels or the error returned by emit
+func (p *Packetiser[T]) Packetise(buffers [][]T, numElems uint, flags int, timeNs uint, emit func(packet []byte) error) error {
+
+	if err := device.CheckBuffers(buffers, uint(len(p.channels)), numElems, p.elemsPerSample); err != nil {
+		return err
+	}
+	hasTime := device.StreamFlag(flags).Has(device.StreamFlagHasTime)
+
+	for index, buffer := range buffers {
+
+		state := &p.states[index]
+
+		for offset := uint(0); offset < numElems; offset += p.samplesPerPacket {
+
+			nbSamples := numElems - offset
+			if nbSamples > p.samplesPerPacket {
+				nbSamples = p.samplesPerPacket
+			}
+
+			packetTimeNs := timeNs
+			if state.hasContext && state.context.SampleRate > 0 {
+				packetTimeNs += uint(math.Round(float64(offset) * 1e9 / state.context.SampleRate))
+			}
+
+			if !state.hasContext || state.packetsSinceContext >= p.config.ContextPeriod {
+				channelContext := p.updateContext(index)
+				if err := emit(p.contextPacket(index, hasTime, packetTimeNs, channelContext)); err != nil {
+					return err
+				}
+			}
+
+			samples := buffer[offset*p.elemsPerSample : (offset+nbSamples)*p.elemsPerSample]
+			if err := emit(p.dataPacket(index, hasTime, packetTimeNs, samples)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateContext reads the context of a channel from the device.
+//
+// Return the context to send, with the change indicator set if the context changed since the previous context packet
+func (p *Packetiser[T]) updateContext(index int) Context {
+
+	state := &p.states[index]
+	channel := p.channels[index]
+
+	channelContext := Context{
+		Indicators:           CIFBandwidth | CIFRFReferenceFrequency | CIFGain | CIFSampleRate,
+		Bandwidth:            p.dev.GetBandwidth(device.DirectionRX, channel),
+		RFReferenceFrequency: p.dev.GetFrequency(device.DirectionRX, channel),
+		Gain:                 p.dev.GetGain(device.DirectionRX, channel),
+		SampleRate:           p.dev.GetSampleRate(device.DirectionRX, channel),
+	}
+	if !state.hasContext || channelContext != state.context {
+		channelContext.Indicators |= CIFChangeIndicator
+	}
+
+	state.context = channelContext
+	state.context.Indicators &^= CIFChangeIndicator
+	state.hasContext = true
+	state.packetsSinceContext = 0
+
+	return channelContext
+}
+
+// writeHeader writes the header, the stream ID, the class ID and the timestamps of a packet at the start of the
+// packet buffer.
+//
+// Params:
+//  - packetType: the type of the packet
+//  - count: the packet count of the stream
+//  - streamID: the stream ID
+//  - padBits: the number of pad bits at the end of the payload. The packet carries a class ID if it is not 0.
+//  - hasTime: true if the packet carries a timestamp
+//  - timeNs: the timestamp in nanoseconds
+//  - sizeWords: the size of the packet in words
+//
+// Return the size of what was written in bytes
+func (p *Packetiser[T]) writeHeader(packetType PacketType, count uint8, streamID uint32, padBits int, hasTime bool, timeNs uint, sizeWords int) int {
+
+	header := uint32(packetType)<<28 | uint32(count&0xf)<<16 | uint32(sizeWords)
+	if padBits != 0 {
+		header |= headerClassID
+	}
+	if hasTime {
+		header |= uint32(p.config.IntegerTimestamp)<<22 | uint32(TSFRealTime)<<20
+	}
+	binary.BigEndian.PutUint32(p.packet, header)
+	binary.BigEndian.PutUint32(p.packet[4:], streamID)
+	offset := 8
+
+	if padBits != 0 {
+		binary.BigEndian.PutUint64(p.packet[offset:], uint64(padBits)<<59)
+		offset += 4 * classIDWords
+	}
+
+	if hasTime {
+		binary.BigEndian.PutUint32(p.packet[offset:], uint32(timeNs/1e9))
+		binary.BigEndian.PutUint64(p.packet[offset+4:], uint64(timeNs%1e9)*1000)
+		offset += 12
+	}
+
+	return offset
+}
+
+// contextPacket builds an IF context packet of a channel
+func (p *Packetiser[T]) contextPacket(index int, hasTime bool, timeNs uint, channelContext Context) []byte {
+
+	state := &p.states[index]
+
+	sizeWords := headerWords(hasTime, false) + contextFieldsWords
+	fields := p.packet[p.writeHeader(PacketTypeIFContext, state.contextCount, p.StreamID(index), 0, hasTime, timeNs, sizeWords):]
+	state.contextCount++
+
+	binary.BigEndian.PutUint32(fields, channelContext.Indicators)
+	binary.BigEndian.PutUint64(fields[4:], toFixed64(channelContext.Bandwidth))
+	binary.BigEndian.PutUint64(fields[12:], toFixed64(channelContext.RFReferenceFrequency))
+	binary.BigEndian.PutUint32(fields[20:], uint32(toFixedGain(channelContext.Gain)))
+	binary.BigEndian.PutUint64(fields[24:], toFixed64(channelContext.SampleRate))
+
+	return p.packet[:4*sizeWords]
+}
+
+// dataPacket builds an IF data packet of a channel carrying samples
+func (p *Packetiser[T]) dataPacket(index int, hasTime bool, timeNs uint, samples []T) []byte {
+
+	state := &p.states[index]
+
+	src := convert.Bytes(samples)
+	payloadWords := (len(src) + 3) / 4
+	padBits := 8 * (4*payloadWords - len(src))
+	sizeWords := headerWords(hasTime, padBits != 0) + payloadWords
+	payload := p.packet[p.writeHeader(PacketTypeIFData, state.dataCount, p.StreamID(index), padBits, hasTime, timeNs, sizeWords):]
+	state.dataCount++
+	state.packetsSinceContext++
+
+	swapComponents(payload[:len(src)], src, componentSize[T]())
+	for i := len(src); i < 4*payloadWords; i++ {
+		payload[i] = 0
+	}
+
+	return p.packet[:4*sizeWords]
+}
+
+// Forward reads an active receive stream and sends its samples as packets until the context is done or an error
+// occurs. Each packet is written with a single call to Write, so that a connected UDP socket sends it as one datagram.
+// The overflows of the stream are logged and the packets refused by the network, such as the datagrams of a UDP
+// socket whose destination is not listening yet, are dropped.
+//
+// Params:
+//  - ctx: the context of the forwarding
+//  - stream: the stream, set up on the channels of the packetiser and activated
+//  - packetiser: the packetiser
+//  - writer: the destination of the packets, such as the connection returned by net.Dial("udp", address)
+//
+// Return the error which stopped the forwarding, the error of the context if it is done
+func Forward[T device.Sample](ctx context.Context, stream device.TypedStream[T], packetiser *Packetiser[T], writer io.Writer) error {
+
+	if stream.GetNumChannels() != uint(len(packetiser.channels)) {
+		return fmt.Errorf("the stream has %v channels, the packetiser %v", stream.GetNumChannels(), len(packetiser.channels))
+	}
+
+	mtu := uint(stream.GetMTU())
+	buffers := make([][]T, len(packetiser.channels))
+	for i := range buffers {
+		buffers[i] = make([]T, mtu*packetiser.elemsPerSample)
+	}
+	flags := make([]int, len(buffers))
+
+	emit := func(packet []byte) error {
+		if _, err := writer.Write(packet); err != nil && !errors.Is(err, syscall.ECONNREFUSED) {
+			return err
+		}
+		return nil
+	}
+
+	for {
+		timeNs, numElemsRead, err := stream.ReadContext(ctx, buffers, mtu, flags)
+		if errors.Is(err, sdrerror.ErrOverflow) {
+			sdrlogger.Logf(sdrlogger.Warning, "vita49: overflow of the stream")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := packetiser.Packetise(buffers, numElemsRead, flags[0], timeNs, emit); err != nil {
+			return err
+		}
+	}
+}
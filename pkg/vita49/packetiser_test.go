@@ -0,0 +1,198 @@
+package vita49_test
+
+import (
+	"context"
+	"github.com/pothosware/go-soapy-sdr/internal/simtest"
+	"github.com/pothosware/go-soapy-sdr/pkg/device"
+	"github.com/pothosware/go-soapy-sdr/pkg/device/sim"
+	"github.com/pothosware/go-soapy-sdr/pkg/vita49"
+	"net"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// openStream sets up and activates a stream on two channels of a simulated device tuned to known values
+func openStream[T device.Sample](t *testing.T, realtime bool) (*sim.Device, device.TypedStream[T]) {
+
+	dev, stream := simtest.NewActiveRXStream[T](t, sim.Config{NumRXChannels: 2, Realtime: realtime})
+
+	for channel := uint(0); channel < 2; channel++ {
+		if err := dev.SetFrequency(device.DirectionRX, channel, 100e6+float64(channel)*1e6, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := dev.SetBandwidth(device.DirectionRX, channel, 1.5e6); err != nil {
+			t.Fatal(err)
+		}
+		if err := dev.SetGain(device.DirectionRX, channel, 20.5); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dev, stream
+}
+
+// roundTrip packetises a read of a simulated stream, decodes the packets and compares them to the read
+func roundTrip[T device.Sample](t *testing.T, numElems uint) {
+
+	var elem T
+
+	dev, stream := openStream[T](t, false)
+	_, elemsPerSample, _ := device.StreamFormat[T]()
+	sampleSize := int(elemsPerSample) * int(unsafe.Sizeof(elem))
+
+	packetiser, err := vita49.NewPacketiser[T](dev, []uint{0, 1}, vita49.Config{StreamID: 0x100, ContextPeriod: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffers := [][]T{make([]T, elemsPerSample*numElems), make([]T, elemsPerSample*numElems)}
+	flags := make([]int, 2)
+	timeNs, numElemsRead, err := stream.ReadContext(context.Background(), buffers, numElems, flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numElemsRead != numElems {
+		t.Fatalf("%v samples read, %v expected", numElemsRead, numElems)
+	}
+
+	var packets [][]byte
+	err = packetiser.Packetise(buffers, numElemsRead, flags[0], timeNs, func(packet []byte) error {
+		packets = append(packets, append([]byte(nil), packet...))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := [][]T{make([]T, 0, elemsPerSample*numElems), make([]T, 0, elemsPerSample*numElems)}
+	nbContexts := make([]int, 2)
+	nbPadded := make([]int, 2)
+	for _, data := range packets {
+
+		packet, err := vita49.Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index := int(packet.StreamID - 0x100)
+		if index < 0 || index > 1 {
+			t.Fatalf("unexpected stream ID %#x", packet.StreamID)
+		}
+		if _, valid := packet.TimeNs(); !valid || packet.TSI != vita49.TSIUTC {
+			t.Errorf("packet without UTC timestamp: %+v", packet)
+		}
+
+		if packet.Type == vita49.PacketTypeIFContext {
+			expected := vita49.Context{
+				Indicators:           vita49.CIFBandwidth | vita49.CIFRFReferenceFrequency | vita49.CIFGain | vita49.CIFSampleRate,
+				Bandwidth:            1.5e6,
+				RFReferenceFrequency: 100e6 + float64(index)*1e6,
+				Gain:                 20.5,
+				SampleRate:           1e6,
+			}
+			if nbContexts[index] == 0 {
+				expected.Indicators |= vita49.CIFChangeIndicator
+			}
+			if packet.Context != expected || int(packet.Count) != nbContexts[index]%16 {
+				t.Errorf("unexpected context packet %+v", packet)
+			}
+			nbContexts[index]++
+			continue
+		}
+
+		offset := uint(len(decoded[index])) / elemsPerSample
+		if packetTimeNs, _ := packet.TimeNs(); packetTimeNs != timeNs+offset*1000 {
+			t.Errorf("packet of stream %#x at sample %v has time %v, expected %v", packet.StreamID, offset, packetTimeNs, timeNs+offset*1000)
+		}
+		if packet.HasClassID {
+			nbPadded[index]++
+		}
+		if (len(packet.Payload)+int(packet.PadBits/8))%4 != 0 {
+			t.Errorf("payload of %v bytes with %v pad bits", len(packet.Payload), packet.PadBits)
+		}
+
+		samples := make([]T, len(packet.Payload))
+		decoded[index] = append(decoded[index], samples[:vita49.DecodeSamples(samples, packet.Payload)]...)
+	}
+
+	perPacket := packetiser.SamplesPerPacket()
+	expectedContexts := int((numElemsRead + 4*perPacket - 1) / (4 * perPacket))
+	expectedPadded := 0
+	if int(numElemsRead)*sampleSize%4 != 0 {
+		expectedPadded = 1
+	}
+	for index := range buffers {
+		if nbContexts[index] != expectedContexts {
+			t.Errorf("stream %v has %v context packets, expected %v", index, nbContexts[index], expectedContexts)
+		}
+		if nbPadded[index] != expectedPadded {
+			t.Errorf("stream %v has %v padded packets, expected %v", index, nbPadded[index], expectedPadded)
+		}
+		if len(decoded[index]) != int(elemsPerSample*numElemsRead) {
+			t.Fatalf("stream %v decoded %v elements, expected %v", index, len(decoded[index]), elemsPerSample*numElemsRead)
+		}
+		for i, elem := range decoded[index] {
+			if elem != buffers[index][i] {
+				t.Fatalf("element %v of stream %v decoded as %v, expected %v", i, index, elem, buffers[index][i])
+			}
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+
+	t.Run("CS16", func(t *testing.T) { roundTrip[int16](t, 3000) })
+	t.Run("CU8 odd", func(t *testing.T) { roundTrip[uint8](t, 1001) })
+	t.Run("CF32", func(t *testing.T) { roundTrip[complex64](t, 777) })
+}
+
+func TestForward(t *testing.T) {
+
+	dev, stream := openStream[int16](t, true)
+
+	packetiser, err := vita49.NewPacketiser[int16](dev, []uint{0, 1}, vita49.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	conn, err := net.Dial("udp", listener.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- vita49.Forward[int16](ctx, stream, packetiser, conn)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	data := make([]byte, 65536)
+	n, _, err := listener.ReadFrom(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packet, err := vita49.Decode(data[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packet.Type != vita49.PacketTypeIFContext || packet.StreamID != 0 || packet.Context.RFReferenceFrequency != 100e6 {
+		t.Errorf("unexpected first packet %+v", packet)
+	}
+	if n > 1472 {
+		t.Errorf("datagram of %v bytes", n)
+	}
+}